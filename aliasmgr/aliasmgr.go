@@ -0,0 +1,438 @@
+package aliasmgr
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// aliasBucket stores the aliases that we've assigned to our channels.
+	//
+	// maps: alias scid -> base scid
+	aliasBucket = []byte("alias-bucket")
+
+	// pendingAliasBucket stores the aliases that we've assigned to
+	// channels which aren't confirmed yet. They're moved to the
+	// aliasBucket once the confirmed short channel id is known.
+	//
+	// maps: chanID -> alias scid
+	pendingAliasBucket = []byte("pending-alias-bucket")
+
+	// peerAliasBucket stores the aliases that our peers want us to use
+	// when referring to our channels with them, e.g. in route hints.
+	//
+	// maps: chanID -> alias scid
+	peerAliasBucket = []byte("peer-alias-bucket")
+
+	// aliasAllocBucket stores the last alias that was handed out.
+	//
+	// maps: lastAliasKey -> alias scid
+	aliasAllocBucket = []byte("alias-alloc-bucket")
+
+	// lastAliasKey is the key under which the last alias handed out is
+	// stored.
+	lastAliasKey = []byte("last-alias-key")
+
+	byteOrder = binary.BigEndian
+
+	// ErrAliasNotFound is returned when the alias or base scid isn't
+	// known.
+	ErrAliasNotFound = errors.New("alias not found")
+
+	// ErrNotAnAlias is returned when a short channel id outside of the
+	// alias range is used as an alias.
+	ErrNotAnAlias = errors.New("short channel id is not an alias")
+)
+
+var (
+	// StartingAlias is the first alias that is handed out. The block
+	// height is chosen to be far above the current chain height, so that
+	// an alias can't be mistaken for a confirmed short channel id.
+	StartingAlias = lnwire.ShortChannelID{
+		BlockHeight: startingBlockHeight,
+		TxIndex:     0,
+		TxPosition:  0,
+	}
+)
+
+const (
+	// startingBlockHeight is the block height of the first alias.
+	startingBlockHeight = 16_000_000
+
+	// endBlockHeight is the exclusive upper bound of the block heights
+	// that are used for aliases.
+	endBlockHeight = 16_250_000
+
+	// maxTxIndex is the largest transaction index that fits into the
+	// three bytes of a short channel id.
+	maxTxIndex = 1<<24 - 1
+
+	// maxTxPosition is the largest output index that fits into the two
+	// bytes of a short channel id.
+	maxTxPosition = 1<<16 - 1
+)
+
+// IsAlias returns true if the passed short channel id lies within the range
+// of aliases handed out by the Manager.
+func IsAlias(scid lnwire.ShortChannelID) bool {
+	return scid.BlockHeight >= startingBlockHeight &&
+		scid.BlockHeight < endBlockHeight
+}
+
+// Manager assigns and persists the short channel id aliases of our channels,
+// along with the aliases that our peers assigned to them. The local aliases
+// are the ones we recognize when forwarding HTLCs, while the peer aliases are
+// the ones others should use to route to us through that peer.
+type Manager struct {
+	db kvdb.Backend
+
+	// baseToAliases caches the aliases that we assigned to each of our
+	// channels, keyed by the confirmed short channel id.
+	baseToAliases map[lnwire.ShortChannelID][]lnwire.ShortChannelID
+
+	// aliasToBase is the reverse index of baseToAliases.
+	aliasToBase map[lnwire.ShortChannelID]lnwire.ShortChannelID
+
+	// pendingAliases caches the aliases that we assigned to channels that
+	// aren't confirmed yet.
+	pendingAliases map[lnwire.ChannelID]lnwire.ShortChannelID
+
+	// peerAliases caches the alias that the remote party of each channel
+	// assigned to it.
+	peerAliases map[lnwire.ChannelID]lnwire.ShortChannelID
+
+	sync.RWMutex
+}
+
+// NewManager creates a new alias Manager backed by the passed database,
+// loading any previously stored aliases into memory.
+func NewManager(db kvdb.Backend) (*Manager, error) {
+	m := &Manager{
+		db: db,
+		baseToAliases: make(
+			map[lnwire.ShortChannelID][]lnwire.ShortChannelID,
+		),
+		aliasToBase: make(
+			map[lnwire.ShortChannelID]lnwire.ShortChannelID,
+		),
+		pendingAliases: make(
+			map[lnwire.ChannelID]lnwire.ShortChannelID,
+		),
+		peerAliases: make(map[lnwire.ChannelID]lnwire.ShortChannelID),
+	}
+
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		aliases, err := tx.CreateTopLevelBucket(aliasBucket)
+		if err != nil {
+			return err
+		}
+
+		pendingAliases, err := tx.CreateTopLevelBucket(
+			pendingAliasBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		peerAliases, err := tx.CreateTopLevelBucket(peerAliasBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket(aliasAllocBucket)
+		if err != nil {
+			return err
+		}
+
+		err = aliases.ForEach(func(k, v []byte) error {
+			alias := lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(k),
+			)
+			base := lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(v),
+			)
+
+			m.baseToAliases[base] = append(
+				m.baseToAliases[base], alias,
+			)
+			m.aliasToBase[alias] = base
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = pendingAliases.ForEach(func(k, v []byte) error {
+			var chanID lnwire.ChannelID
+			copy(chanID[:], k)
+
+			m.pendingAliases[chanID] = lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(v),
+			)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return peerAliases.ForEach(func(k, v []byte) error {
+			var chanID lnwire.ChannelID
+			copy(chanID[:], k)
+
+			m.peerAliases[chanID] = lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(v),
+			)
+
+			return nil
+		})
+	}, func() {
+		m.baseToAliases = make(
+			map[lnwire.ShortChannelID][]lnwire.ShortChannelID,
+		)
+		m.aliasToBase = make(
+			map[lnwire.ShortChannelID]lnwire.ShortChannelID,
+		)
+		m.pendingAliases = make(
+			map[lnwire.ChannelID]lnwire.ShortChannelID,
+		)
+		m.peerAliases = make(
+			map[lnwire.ChannelID]lnwire.ShortChannelID,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RequestAlias returns a new alias that hasn't been handed out before.
+func (m *Manager) RequestAlias() (lnwire.ShortChannelID, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	var nextAlias lnwire.ShortChannelID
+	err := kvdb.Update(m.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(aliasAllocBucket)
+
+		nextAlias = StartingAlias
+		if lastBytes := bucket.Get(lastAliasKey); lastBytes != nil {
+			lastAlias := lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(lastBytes),
+			)
+			nextAlias = getNextScid(lastAlias)
+		}
+
+		var scratch [8]byte
+		byteOrder.PutUint64(scratch[:], nextAlias.ToUint64())
+
+		return bucket.Put(lastAliasKey, scratch[:])
+	}, func() {
+		nextAlias = lnwire.ShortChannelID{}
+	})
+	if err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	return nextAlias, nil
+}
+
+// AddLocalAlias stores an alias that we assigned to the channel with the
+// given confirmed short channel id.
+func (m *Manager) AddLocalAlias(alias, base lnwire.ShortChannelID) error {
+	if !IsAlias(alias) {
+		return ErrNotAnAlias
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	err := kvdb.Update(m.db, func(tx kvdb.RwTx) error {
+		var aliasBytes, baseBytes [8]byte
+		byteOrder.PutUint64(aliasBytes[:], alias.ToUint64())
+		byteOrder.PutUint64(baseBytes[:], base.ToUint64())
+
+		bucket := tx.ReadWriteBucket(aliasBucket)
+
+		return bucket.Put(aliasBytes[:], baseBytes[:])
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.aliasToBase[alias]; !ok {
+		m.baseToAliases[base] = append(m.baseToAliases[base], alias)
+	}
+	m.aliasToBase[alias] = base
+
+	return nil
+}
+
+// AddPendingAlias stores an alias that we assigned to the channel with the
+// given channel id before its confirmed short channel id is known. The alias
+// is moved over to the confirmed short channel id by ConfirmPendingAlias.
+func (m *Manager) AddPendingAlias(chanID lnwire.ChannelID,
+	alias lnwire.ShortChannelID) error {
+
+	if !IsAlias(alias) {
+		return ErrNotAnAlias
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	err := kvdb.Update(m.db, func(tx kvdb.RwTx) error {
+		var aliasBytes [8]byte
+		byteOrder.PutUint64(aliasBytes[:], alias.ToUint64())
+
+		bucket := tx.ReadWriteBucket(pendingAliasBucket)
+
+		return bucket.Put(chanID[:], aliasBytes[:])
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	m.pendingAliases[chanID] = alias
+
+	return nil
+}
+
+// ConfirmPendingAlias assigns the pending alias of the channel with the given
+// channel id to its confirmed short channel id, and returns the alias.
+// ErrAliasNotFound is returned if the channel has no pending alias.
+func (m *Manager) ConfirmPendingAlias(chanID lnwire.ChannelID,
+	base lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	alias, ok := m.pendingAliases[chanID]
+	if !ok {
+		return lnwire.ShortChannelID{}, ErrAliasNotFound
+	}
+
+	err := kvdb.Update(m.db, func(tx kvdb.RwTx) error {
+		var aliasBytes, baseBytes [8]byte
+		byteOrder.PutUint64(aliasBytes[:], alias.ToUint64())
+		byteOrder.PutUint64(baseBytes[:], base.ToUint64())
+
+		bucket := tx.ReadWriteBucket(aliasBucket)
+		if err := bucket.Put(aliasBytes[:], baseBytes[:]); err != nil {
+			return err
+		}
+
+		pending := tx.ReadWriteBucket(pendingAliasBucket)
+
+		return pending.Delete(chanID[:])
+	}, func() {})
+	if err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	delete(m.pendingAliases, chanID)
+	if _, ok := m.aliasToBase[alias]; !ok {
+		m.baseToAliases[base] = append(m.baseToAliases[base], alias)
+	}
+	m.aliasToBase[alias] = base
+
+	return alias, nil
+}
+
+// GetAliases returns the aliases that we assigned to the channel with the
+// given confirmed short channel id.
+func (m *Manager) GetAliases(
+	base lnwire.ShortChannelID) []lnwire.ShortChannelID {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	aliases := make([]lnwire.ShortChannelID, len(m.baseToAliases[base]))
+	copy(aliases, m.baseToAliases[base])
+
+	return aliases
+}
+
+// FindBaseSCID returns the confirmed short channel id of the channel that we
+// assigned the given alias to.
+func (m *Manager) FindBaseSCID(
+	alias lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	base, ok := m.aliasToBase[alias]
+	if !ok {
+		return lnwire.ShortChannelID{}, ErrAliasNotFound
+	}
+
+	return base, nil
+}
+
+// PutPeerAlias stores the alias that the remote party assigned to the given
+// channel.
+func (m *Manager) PutPeerAlias(chanID lnwire.ChannelID,
+	alias lnwire.ShortChannelID) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	err := kvdb.Update(m.db, func(tx kvdb.RwTx) error {
+		var aliasBytes [8]byte
+		byteOrder.PutUint64(aliasBytes[:], alias.ToUint64())
+
+		bucket := tx.ReadWriteBucket(peerAliasBucket)
+
+		return bucket.Put(chanID[:], aliasBytes[:])
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	m.peerAliases[chanID] = alias
+
+	return nil
+}
+
+// GetPeerAlias returns the alias that the remote party assigned to the given
+// channel.
+func (m *Manager) GetPeerAlias(
+	chanID lnwire.ChannelID) (lnwire.ShortChannelID, error) {
+
+	m.RLock()
+	defer m.RUnlock()
+
+	alias, ok := m.peerAliases[chanID]
+	if !ok {
+		return lnwire.ShortChannelID{}, ErrAliasNotFound
+	}
+
+	return alias, nil
+}
+
+// getNextScid returns the alias following the passed one, incrementing the
+// output index first, then the transaction index and finally the block
+// height.
+func getNextScid(last lnwire.ShortChannelID) lnwire.ShortChannelID {
+	switch {
+	case last.TxPosition < maxTxPosition:
+		last.TxPosition++
+
+	case last.TxIndex < maxTxIndex:
+		last.TxIndex++
+		last.TxPosition = 0
+
+	default:
+		last.BlockHeight++
+		last.TxIndex = 0
+		last.TxPosition = 0
+	}
+
+	return last
+}
@@ -0,0 +1,186 @@
+package aliasmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB creates a new bolt database in a temporary directory.
+func newTestDB(t *testing.T) kvdb.Backend {
+	t.Helper()
+
+	tempDir, err := ioutil.TempDir("", "aliasmgr")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	db, err := kvdb.Create(
+		kvdb.BoltBackendName, filepath.Join(tempDir, "alias.db"), true,
+		kvdb.DefaultDBTimeout,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+// TestAliasStorage asserts that local and peer aliases are stored and
+// survive a restart of the Manager.
+func TestAliasStorage(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	m, err := NewManager(db)
+	require.NoError(t, err)
+
+	base := lnwire.NewShortChanIDFromInt(123)
+	chanID := lnwire.ChannelID{1}
+
+	alias, err := m.RequestAlias()
+	require.NoError(t, err)
+	require.Equal(t, StartingAlias, alias)
+	require.True(t, IsAlias(alias))
+
+	require.NoError(t, m.AddLocalAlias(alias, base))
+	require.ErrorIs(t, m.AddLocalAlias(base, base), ErrNotAnAlias)
+
+	peerAlias := getNextScid(StartingAlias)
+	require.NoError(t, m.PutPeerAlias(chanID, peerAlias))
+
+	// The aliases are reloaded from the database on restart, and new
+	// aliases are never handed out twice.
+	m, err = NewManager(db)
+	require.NoError(t, err)
+
+	require.Equal(t, []lnwire.ShortChannelID{alias}, m.GetAliases(base))
+
+	foundBase, err := m.FindBaseSCID(alias)
+	require.NoError(t, err)
+	require.Equal(t, base, foundBase)
+
+	foundPeerAlias, err := m.GetPeerAlias(chanID)
+	require.NoError(t, err)
+	require.Equal(t, peerAlias, foundPeerAlias)
+
+	nextAlias, err := m.RequestAlias()
+	require.NoError(t, err)
+	require.Equal(t, getNextScid(alias), nextAlias)
+
+	_, err = m.FindBaseSCID(nextAlias)
+	require.ErrorIs(t, err, ErrAliasNotFound)
+
+	_, err = m.GetPeerAlias(lnwire.ChannelID{2})
+	require.ErrorIs(t, err, ErrAliasNotFound)
+
+	require.Empty(t, m.GetAliases(lnwire.NewShortChanIDFromInt(456)))
+}
+
+// TestPendingAlias asserts that an alias assigned to an unconfirmed channel is
+// moved over to its confirmed short channel id, also across restarts.
+func TestPendingAlias(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	m, err := NewManager(db)
+	require.NoError(t, err)
+
+	base := lnwire.NewShortChanIDFromInt(123)
+	chanID := lnwire.ChannelID{1}
+
+	alias, err := m.RequestAlias()
+	require.NoError(t, err)
+
+	require.NoError(t, m.AddPendingAlias(chanID, alias))
+	require.ErrorIs(t, m.AddPendingAlias(chanID, base), ErrNotAnAlias)
+
+	// The pending alias isn't known as a local alias yet.
+	_, err = m.FindBaseSCID(alias)
+	require.ErrorIs(t, err, ErrAliasNotFound)
+
+	// Once confirmed after a restart, the alias resolves to the confirmed
+	// short channel id.
+	m, err = NewManager(db)
+	require.NoError(t, err)
+
+	confirmedAlias, err := m.ConfirmPendingAlias(chanID, base)
+	require.NoError(t, err)
+	require.Equal(t, alias, confirmedAlias)
+	require.Equal(t, []lnwire.ShortChannelID{alias}, m.GetAliases(base))
+
+	// The pending alias is gone after the confirmation, also after
+	// another restart.
+	m, err = NewManager(db)
+	require.NoError(t, err)
+
+	_, err = m.ConfirmPendingAlias(chanID, base)
+	require.ErrorIs(t, err, ErrAliasNotFound)
+
+	foundBase, err := m.FindBaseSCID(alias)
+	require.NoError(t, err)
+	require.Equal(t, base, foundBase)
+}
+
+// TestGetNextScid asserts that aliases are incremented without overflowing
+// any of the components of the short channel id.
+func TestGetNextScid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		last     lnwire.ShortChannelID
+		expected lnwire.ShortChannelID
+	}{
+		{
+			name: "increment tx position",
+			last: StartingAlias,
+			expected: lnwire.ShortChannelID{
+				BlockHeight: startingBlockHeight,
+				TxPosition:  1,
+			},
+		},
+		{
+			name: "increment tx index",
+			last: lnwire.ShortChannelID{
+				BlockHeight: startingBlockHeight,
+				TxIndex:     1,
+				TxPosition:  maxTxPosition,
+			},
+			expected: lnwire.ShortChannelID{
+				BlockHeight: startingBlockHeight,
+				TxIndex:     2,
+			},
+		},
+		{
+			name: "increment block height",
+			last: lnwire.ShortChannelID{
+				BlockHeight: startingBlockHeight,
+				TxIndex:     maxTxIndex,
+				TxPosition:  maxTxPosition,
+			},
+			expected: lnwire.ShortChannelID{
+				BlockHeight: startingBlockHeight + 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, getNextScid(test.last))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package chainreg
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// BackendDriver represents a "driver" for a particular chain backend. A driver
+// is identified by the node types it is able to speak to, along with a 'New()'
+// method which is responsible for creating the chain specific interfaces of a
+// chain control.
+type BackendDriver struct {
+	// NodeTypes are the values of the <chain>.node config option this
+	// driver handles, e.g. "bitcoind" and "litecoind".
+	NodeTypes []string
+
+	// New populates the ChainNotifier, ChainView, ChainSource and
+	// HealthCheck of the passed partial chain control. The default static
+	// fee estimator is already set and may be replaced by the driver if
+	// the backend is able to provide live fee estimates.
+	New func(cfg *Config, cc *PartialChainControl,
+		hintCache *chainntnfs.HeightHintCache) error
+}
+
+var (
+	backends       = make(map[string]*BackendDriver)
+	backendsRegMtx sync.Mutex
+)
+
+// RegisterBackend registers a BackendDriver which is capable of creating the
+// chain specific interfaces for each of its node types. In the case that a node
+// type has already been registered, an error is returned.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisterBackend(driver *BackendDriver) error {
+	backendsRegMtx.Lock()
+	defer backendsRegMtx.Unlock()
+
+	for _, nodeType := range driver.NodeTypes {
+		if _, ok := backends[nodeType]; ok {
+			return fmt.Errorf("backend for node type %v already "+
+				"registered", nodeType)
+		}
+	}
+
+	for _, nodeType := range driver.NodeTypes {
+		backends[nodeType] = driver
+	}
+
+	return nil
+}
+
+// SupportedBackends returns a sorted slice of all node types that have a
+// registered backend driver.
+//
+// NOTE: This function is safe for concurrent access.
+func SupportedBackends() []string {
+	backendsRegMtx.Lock()
+	defer backendsRegMtx.Unlock()
+
+	nodeTypes := make([]string, 0, len(backends))
+	for nodeType := range backends {
+		nodeTypes = append(nodeTypes, nodeType)
+	}
+	sort.Strings(nodeTypes)
+
+	return nodeTypes
+}
+
+// IsRegisteredBackend returns true if a backend driver has been registered for
+// the given node type.
+//
+// NOTE: This function is safe for concurrent access.
+func IsRegisteredBackend(nodeType string) bool {
+	_, ok := lookupBackend(nodeType)
+	return ok
+}
+
+// lookupBackend returns the backend driver registered for the given node type.
+func lookupBackend(nodeType string) (*BackendDriver, bool) {
+	backendsRegMtx.Lock()
+	defer backendsRegMtx.Unlock()
+
+	driver, ok := backends[nodeType]
+	return driver, ok
+}
+
+func init() {
+	builtinBackends := []*BackendDriver{
+		{
+			NodeTypes: []string{"neutrino"},
+			New:       newNeutrinoBackend,
+		},
+		{
+			NodeTypes: []string{"bitcoind", "litecoind"},
+			New:       newBitcoindBackend,
+		},
+		{
+			NodeTypes: []string{"btcd", "ltcd"},
+			New:       newBtcdBackend,
+		},
+		{
+			NodeTypes: []string{"nochainbackend"},
+			New:       newNoChainBackend,
+		},
+	}
+
+	for _, driver := range builtinBackends {
+		if err := RegisterBackend(driver); err != nil {
+			panic(fmt.Sprintf("failed to register backend %v: %v",
+				driver.NodeTypes, err))
+		}
+	}
+}
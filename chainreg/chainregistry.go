@@ -291,7 +291,6 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			"%v is unknown", cfg.PrimaryChain())
 	}
 
-	var err error
 	heightHintCacheConfig := chainntnfs.CacheConfig{
 		QueryDisable: cfg.HeightHintCacheQueryDisable,
 	}
@@ -308,457 +307,493 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			"cache: %v", err)
 	}
 
-	// If spv mode is active, then we'll be using a distinct set of
-	// chainControl interfaces that interface directly with the p2p network
-	// of the selected chain.
-	switch homeChainConfig.Node {
-	case "neutrino":
-		// We'll create ChainNotifier and FilteredChainView instances,
-		// along with the wallet's ChainSource, which are all backed by
-		// the neutrino light client.
-		cc.ChainNotifier = neutrinonotify.New(
-			cfg.NeutrinoCS, hintCache, hintCache, cfg.BlockCache,
-		)
-		cc.ChainView, err = chainview.NewCfFilteredChainView(
-			cfg.NeutrinoCS, cfg.BlockCache,
-		)
-		if err != nil {
-			return nil, nil, err
-		}
+	// Now that the chain agnostic parts are set up, we'll let the driver of
+	// the configured backend create the chain specific interfaces.
+	backend, ok := lookupBackend(homeChainConfig.Node)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown node type: %s",
+			homeChainConfig.Node)
+	}
+	if err := backend.New(cfg, cc, hintCache); err != nil {
+		return nil, nil, err
+	}
 
-		// Map the deprecated neutrino feeurl flag to the general fee
-		// url.
-		if cfg.NeutrinoMode.FeeURL != "" {
-			if cfg.FeeURL != "" {
-				return nil, nil, errors.New("feeurl and " +
-					"neutrino.feeurl are mutually " +
-					"exclusive")
-			}
+	switch {
+	// If the fee URL isn't set, and the user is running mainnet, then
+	// we'll return an error to instruct them to set a proper fee
+	// estimator.
+	case cfg.FeeURL == "" && cfg.Bitcoin.MainNet &&
+		homeChainConfig.Node == "neutrino":
 
-			cfg.FeeURL = cfg.NeutrinoMode.FeeURL
-		}
+		return nil, nil, fmt.Errorf("--feeurl parameter required " +
+			"when running neutrino on mainnet")
 
-		cc.ChainSource = chain.NewNeutrinoClient(
-			cfg.ActiveNetParams.Params, cfg.NeutrinoCS,
-		)
+	// Override default fee estimator if an external service is specified.
+	case cfg.FeeURL != "":
+		// Do not cache fees on regtest to make it easier to execute
+		// manual or automated test cases.
+		cacheFees := !cfg.Bitcoin.RegTest
 
-		// Get our best block as a health check.
-		cc.HealthCheck = func() error {
-			_, _, err := cc.ChainSource.GetBestBlock()
-			return err
-		}
+		log.Infof("Using external fee estimator %v: cached=%v",
+			cfg.FeeURL, cacheFees)
 
-	case "bitcoind", "litecoind":
-		var bitcoindMode *lncfg.Bitcoind
-		switch {
-		case cfg.Bitcoin.Active:
-			bitcoindMode = cfg.BitcoindMode
-		case cfg.Litecoin.Active:
-			bitcoindMode = cfg.LitecoindMode
-		}
-		// Otherwise, we'll be speaking directly via RPC and ZMQ to a
-		// bitcoind node. If the specified host for the btcd/ltcd RPC
-		// server already has a port specified, then we use that
-		// directly. Otherwise, we assume the default port according to
-		// the selected chain parameters.
-		var bitcoindHost string
-		if strings.Contains(bitcoindMode.RPCHost, ":") {
-			bitcoindHost = bitcoindMode.RPCHost
-		} else {
-			// The RPC ports specified in chainparams.go assume
-			// btcd, which picks a different port so that btcwallet
-			// can use the same RPC port as bitcoind. We convert
-			// this back to the btcwallet/bitcoind port.
-			rpcPort, err := strconv.Atoi(cfg.ActiveNetParams.RPCPort)
-			if err != nil {
-				return nil, nil, err
-			}
-			rpcPort -= 2
-			bitcoindHost = fmt.Sprintf("%v:%d",
-				bitcoindMode.RPCHost, rpcPort)
-			if (cfg.Bitcoin.Active &&
-				(cfg.Bitcoin.RegTest || cfg.Bitcoin.SigNet)) ||
-				(cfg.Litecoin.Active && cfg.Litecoin.RegTest) {
-
-				conn, err := net.Dial("tcp", bitcoindHost)
-				if err != nil || conn == nil {
-					switch {
-					case cfg.Bitcoin.Active && cfg.Bitcoin.RegTest:
-						rpcPort = 18443
-					case cfg.Litecoin.Active && cfg.Litecoin.RegTest:
-						rpcPort = 19443
-					case cfg.Bitcoin.Active && cfg.Bitcoin.SigNet:
-						rpcPort = 38332
-					}
-					bitcoindHost = fmt.Sprintf("%v:%d",
-						bitcoindMode.RPCHost,
-						rpcPort)
-				} else {
-					conn.Close()
-				}
+		cc.FeeEstimator = chainfee.NewWebAPIEstimator(
+			chainfee.SparseConfFeeSource{
+				URL: cfg.FeeURL,
+			},
+			!cacheFees,
+		)
+	}
+
+	ccCleanup := func() {
+		if cc.FeeEstimator != nil {
+			if err := cc.FeeEstimator.Stop(); err != nil {
+				log.Errorf("Failed to stop feeEstimator: %v",
+					err)
 			}
 		}
+	}
 
-		// Establish the connection to bitcoind and create the clients
-		// required for our relevant subsystems.
-		bitcoindConn, err := chain.NewBitcoindConn(&chain.BitcoindConfig{
-			ChainParams:        cfg.ActiveNetParams.Params,
-			Host:               bitcoindHost,
-			User:               bitcoindMode.RPCUser,
-			Pass:               bitcoindMode.RPCPass,
-			ZMQBlockHost:       bitcoindMode.ZMQPubRawBlock,
-			ZMQTxHost:          bitcoindMode.ZMQPubRawTx,
-			ZMQReadDeadline:    5 * time.Second,
-			Dialer:             cfg.Dialer,
-			PrunedModeMaxPeers: bitcoindMode.PrunedNodeMaxPeers,
-		})
-		if err != nil {
-			return nil, nil, err
-		}
+	// Start fee estimator.
+	if err := cc.FeeEstimator.Start(); err != nil {
+		return nil, nil, err
+	}
 
-		if err := bitcoindConn.Start(); err != nil {
-			return nil, nil, fmt.Errorf("unable to connect to "+
-				"bitcoind: %v", err)
-		}
+	// Select the default channel constraints for the primary chain.
+	cc.ChannelConstraints = GenDefaultBtcConstraints()
+	if cfg.PrimaryChain() == LitecoinChain {
+		cc.ChannelConstraints = DefaultLtcChannelConstraints
+	}
 
-		cc.ChainNotifier = bitcoindnotify.New(
-			bitcoindConn, cfg.ActiveNetParams.Params, hintCache,
-			hintCache, cfg.BlockCache,
-		)
-		cc.ChainView = chainview.NewBitcoindFilteredChainView(
-			bitcoindConn, cfg.BlockCache,
-		)
-		cc.ChainSource = bitcoindConn.NewBitcoindClient()
-
-		// If we're not in regtest mode, then we'll attempt to use a
-		// proper fee estimator for testnet.
-		rpcConfig := &rpcclient.ConnConfig{
-			Host:                 bitcoindHost,
-			User:                 bitcoindMode.RPCUser,
-			Pass:                 bitcoindMode.RPCPass,
-			DisableConnectOnNew:  true,
-			DisableAutoReconnect: false,
-			DisableTLS:           true,
-			HTTPPostMode:         true,
-		}
-		if cfg.Bitcoin.Active && !cfg.Bitcoin.RegTest {
-			log.Infof("Initializing bitcoind backed fee estimator "+
-				"in %s mode", bitcoindMode.EstimateMode)
-
-			// Finally, we'll re-initialize the fee estimator, as
-			// if we're using bitcoind as a backend, then we can
-			// use live fee estimates, rather than a statically
-			// coded value.
-			fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
-			cc.FeeEstimator, err = chainfee.NewBitcoindEstimator(
-				*rpcConfig, bitcoindMode.EstimateMode,
-				fallBackFeeRate.FeePerKWeight(),
-			)
-			if err != nil {
-				return nil, nil, err
-			}
-		} else if cfg.Litecoin.Active && !cfg.Litecoin.RegTest {
-			log.Infof("Initializing litecoind backed fee "+
-				"estimator in %s mode",
-				bitcoindMode.EstimateMode)
-
-			// Finally, we'll re-initialize the fee estimator, as
-			// if we're using litecoind as a backend, then we can
-			// use live fee estimates, rather than a statically
-			// coded value.
-			fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
-			cc.FeeEstimator, err = chainfee.NewBitcoindEstimator(
-				*rpcConfig, bitcoindMode.EstimateMode,
-				fallBackFeeRate.FeePerKWeight(),
-			)
-			if err != nil {
-				return nil, nil, err
-			}
-		}
+	return cc, ccCleanup, nil
+}
 
-		// We need to use some apis that are not exposed by btcwallet,
-		// for a health check function so we create an ad-hoc bitcoind
-		// connection.
-		chainConn, err := rpcclient.New(rpcConfig, nil)
-		if err != nil {
-			return nil, nil, err
-		}
+// newNeutrinoBackend creates the chain specific interfaces backed by a
+// neutrino light client. As neutrino interfaces directly with the p2p network
+// of the selected chain, no RPC connection is needed.
+func newNeutrinoBackend(cfg *Config, cc *PartialChainControl,
+	hintCache *chainntnfs.HeightHintCache) error {
 
-		// The api we will use for our health check depends on the
-		// bitcoind version.
-		cmd, ver, err := getBitcoindHealthCheckCmd(chainConn)
-		if err != nil {
-			return nil, nil, err
+	// We'll create ChainNotifier and FilteredChainView instances, along
+	// with the wallet's ChainSource, which are all backed by the neutrino
+	// light client.
+	var err error
+	cc.ChainNotifier = neutrinonotify.New(
+		cfg.NeutrinoCS, hintCache, hintCache, cfg.BlockCache,
+	)
+	cc.ChainView, err = chainview.NewCfFilteredChainView(
+		cfg.NeutrinoCS, cfg.BlockCache,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Map the deprecated neutrino feeurl flag to the general fee
+	// url.
+	if cfg.NeutrinoMode.FeeURL != "" {
+		if cfg.FeeURL != "" {
+			return errors.New("feeurl and " +
+				"neutrino.feeurl are mutually " +
+				"exclusive")
 		}
 
-		// If the getzmqnotifications api is available (was added in
-		// version 0.17.0) we make sure lnd subscribes to the correct
-		// zmq events. We do this to avoid a situation in which we are
-		// not notified of new transactions or blocks.
-		if ver >= 170000 {
-			zmqPubRawBlockURL, err := url.Parse(bitcoindMode.ZMQPubRawBlock)
-			if err != nil {
-				return nil, nil, err
-			}
-			zmqPubRawTxURL, err := url.Parse(bitcoindMode.ZMQPubRawTx)
-			if err != nil {
-				return nil, nil, err
-			}
+		cfg.FeeURL = cfg.NeutrinoMode.FeeURL
+	}
 
-			// Fetch all active zmq notifications from the bitcoind client.
-			resp, err := chainConn.RawRequest("getzmqnotifications", nil)
-			if err != nil {
-				return nil, nil, err
-			}
+	cc.ChainSource = chain.NewNeutrinoClient(
+		cfg.ActiveNetParams.Params, cfg.NeutrinoCS,
+	)
 
-			zmq := []struct {
-				Type    string `json:"type"`
-				Address string `json:"address"`
-			}{}
+	// Get our best block as a health check.
+	cc.HealthCheck = func() error {
+		_, _, err := cc.ChainSource.GetBestBlock()
+		return err
+	}
 
-			if err = json.Unmarshal([]byte(resp), &zmq); err != nil {
-				return nil, nil, err
-			}
+	return nil
+}
 
-			pubRawBlockActive := false
-			pubRawTxActive := false
-
-			for i := range zmq {
-				if zmq[i].Type == "pubrawblock" {
-					url, err := url.Parse(zmq[i].Address)
-					if err != nil {
-						return nil, nil, err
-					}
-					if url.Port() != zmqPubRawBlockURL.Port() {
-						return nil, nil, fmt.Errorf(
-							"unable to subscribe to zmq block events on "+
-								"%s (bitcoind is running on %s)",
-							zmqPubRawBlockURL.Host,
-							url.Host,
-						)
-					}
-					pubRawBlockActive = true
-				}
-				if zmq[i].Type == "pubrawtx" {
-					url, err := url.Parse(zmq[i].Address)
-					if err != nil {
-						return nil, nil, err
-					}
-					if url.Port() != zmqPubRawTxURL.Port() {
-						return nil, nil, fmt.Errorf(
-							"unable to subscribe to zmq tx events on "+
-								"%s (bitcoind is running on %s)",
-							zmqPubRawTxURL.Host,
-							url.Host,
-						)
-					}
-					pubRawTxActive = true
-				}
-			}
+// newBitcoindBackend creates the chain specific interfaces backed by a
+// bitcoind or litecoind full node.
+func newBitcoindBackend(cfg *Config, cc *PartialChainControl,
+	hintCache *chainntnfs.HeightHintCache) error {
 
-			// Return an error if raw tx or raw block notification over
-			// zmq is inactive.
-			if !pubRawBlockActive {
-				return nil, nil, errors.New(
-					"block notification over zmq is inactive on " +
-						"bitcoind",
-				)
-			}
-			if !pubRawTxActive {
-				return nil, nil, errors.New(
-					"tx notification over zmq is inactive on " +
-						"bitcoind",
-				)
-			}
-		}
+	var bitcoindMode *lncfg.Bitcoind
+	switch {
+	case cfg.Bitcoin.Active:
+		bitcoindMode = cfg.BitcoindMode
+	case cfg.Litecoin.Active:
+		bitcoindMode = cfg.LitecoindMode
+	}
 
-		cc.HealthCheck = func() error {
-			_, err := chainConn.RawRequest(cmd, nil)
+	// We'll be speaking directly via RPC and ZMQ to a bitcoind node. If
+	// the specified host for the bitcoind RPC server already has a port
+	// specified, then we use that directly. Otherwise, we assume the
+	// default port according to the selected chain parameters.
+	var bitcoindHost string
+	if strings.Contains(bitcoindMode.RPCHost, ":") {
+		bitcoindHost = bitcoindMode.RPCHost
+	} else {
+		// The RPC ports specified in chainparams.go assume
+		// btcd, which picks a different port so that btcwallet
+		// can use the same RPC port as bitcoind. We convert
+		// this back to the btcwallet/bitcoind port.
+		rpcPort, err := strconv.Atoi(cfg.ActiveNetParams.RPCPort)
+		if err != nil {
 			return err
 		}
-
-	case "btcd", "ltcd":
-		// Otherwise, we'll be speaking directly via RPC to a node.
-		//
-		// So first we'll load btcd/ltcd's TLS cert for the RPC
-		// connection. If a raw cert was specified in the config, then
-		// we'll set that directly. Otherwise, we attempt to read the
-		// cert from the path specified in the config.
-		var btcdMode *lncfg.Btcd
-		switch {
-		case cfg.Bitcoin.Active:
-			btcdMode = cfg.BtcdMode
-		case cfg.Litecoin.Active:
-			btcdMode = cfg.LtcdMode
-		}
-		var rpcCert []byte
-		if btcdMode.RawRPCCert != "" {
-			rpcCert, err = hex.DecodeString(btcdMode.RawRPCCert)
-			if err != nil {
-				return nil, nil, err
-			}
-		} else {
-			certFile, err := os.Open(btcdMode.RPCCert)
-			if err != nil {
-				return nil, nil, err
-			}
-			rpcCert, err = ioutil.ReadAll(certFile)
-			if err != nil {
-				return nil, nil, err
-			}
-			if err := certFile.Close(); err != nil {
-				return nil, nil, err
+		rpcPort -= 2
+		bitcoindHost = fmt.Sprintf("%v:%d",
+			bitcoindMode.RPCHost, rpcPort)
+		if (cfg.Bitcoin.Active &&
+			(cfg.Bitcoin.RegTest || cfg.Bitcoin.SigNet)) ||
+			(cfg.Litecoin.Active && cfg.Litecoin.RegTest) {
+
+			conn, err := net.Dial("tcp", bitcoindHost)
+			if err != nil || conn == nil {
+				switch {
+				case cfg.Bitcoin.Active && cfg.Bitcoin.RegTest:
+					rpcPort = 18443
+				case cfg.Litecoin.Active && cfg.Litecoin.RegTest:
+					rpcPort = 19443
+				case cfg.Bitcoin.Active && cfg.Bitcoin.SigNet:
+					rpcPort = 38332
+				}
+				bitcoindHost = fmt.Sprintf("%v:%d",
+					bitcoindMode.RPCHost,
+					rpcPort)
+			} else {
+				conn.Close()
 			}
 		}
+	}
 
-		// If the specified host for the btcd/ltcd RPC server already
-		// has a port specified, then we use that directly. Otherwise,
-		// we assume the default port according to the selected chain
-		// parameters.
-		var btcdHost string
-		if strings.Contains(btcdMode.RPCHost, ":") {
-			btcdHost = btcdMode.RPCHost
-		} else {
-			btcdHost = fmt.Sprintf("%v:%v", btcdMode.RPCHost,
-				cfg.ActiveNetParams.RPCPort)
-		}
+	// Establish the connection to bitcoind and create the clients
+	// required for our relevant subsystems.
+	bitcoindConn, err := chain.NewBitcoindConn(&chain.BitcoindConfig{
+		ChainParams:        cfg.ActiveNetParams.Params,
+		Host:               bitcoindHost,
+		User:               bitcoindMode.RPCUser,
+		Pass:               bitcoindMode.RPCPass,
+		ZMQBlockHost:       bitcoindMode.ZMQPubRawBlock,
+		ZMQTxHost:          bitcoindMode.ZMQPubRawTx,
+		ZMQReadDeadline:    bitcoindMode.ZMQReadDeadline,
+		Dialer:             cfg.Dialer,
+		PrunedModeMaxPeers: bitcoindMode.PrunedNodeMaxPeers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := bitcoindConn.Start(); err != nil {
+		return fmt.Errorf("unable to connect to "+
+			"bitcoind: %v", err)
+	}
 
-		btcdUser := btcdMode.RPCUser
-		btcdPass := btcdMode.RPCPass
-		rpcConfig := &rpcclient.ConnConfig{
-			Host:                 btcdHost,
-			Endpoint:             "ws",
-			User:                 btcdUser,
-			Pass:                 btcdPass,
-			Certificates:         rpcCert,
-			DisableTLS:           false,
-			DisableConnectOnNew:  true,
-			DisableAutoReconnect: false,
+	cc.ChainNotifier = bitcoindnotify.New(
+		bitcoindConn, cfg.ActiveNetParams.Params, hintCache,
+		hintCache, cfg.BlockCache,
+	)
+	cc.ChainView = chainview.NewBitcoindFilteredChainView(
+		bitcoindConn, cfg.BlockCache,
+	)
+	cc.ChainSource = bitcoindConn.NewBitcoindClient()
+
+	// If we're not in regtest mode, then we'll attempt to use a
+	// proper fee estimator for testnet.
+	rpcConfig := &rpcclient.ConnConfig{
+		Host:                 bitcoindHost,
+		User:                 bitcoindMode.RPCUser,
+		Pass:                 bitcoindMode.RPCPass,
+		DisableConnectOnNew:  true,
+		DisableAutoReconnect: false,
+		DisableTLS:           true,
+		HTTPPostMode:         true,
+	}
+	if cfg.Bitcoin.Active && !cfg.Bitcoin.RegTest {
+		log.Infof("Initializing bitcoind backed fee estimator "+
+			"in %s mode", bitcoindMode.EstimateMode)
+
+		// Finally, we'll re-initialize the fee estimator, as
+		// if we're using bitcoind as a backend, then we can
+		// use live fee estimates, rather than a statically
+		// coded value.
+		fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
+		cc.FeeEstimator, err = chainfee.NewBitcoindEstimator(
+			*rpcConfig, bitcoindMode.EstimateMode,
+			fallBackFeeRate.FeePerKWeight(),
+		)
+		if err != nil {
+			return err
 		}
-		cc.ChainNotifier, err = btcdnotify.New(
-			rpcConfig, cfg.ActiveNetParams.Params, hintCache,
-			hintCache, cfg.BlockCache,
+	} else if cfg.Litecoin.Active && !cfg.Litecoin.RegTest {
+		log.Infof("Initializing litecoind backed fee "+
+			"estimator in %s mode",
+			bitcoindMode.EstimateMode)
+
+		// Finally, we'll re-initialize the fee estimator, as
+		// if we're using litecoind as a backend, then we can
+		// use live fee estimates, rather than a statically
+		// coded value.
+		fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
+		cc.FeeEstimator, err = chainfee.NewBitcoindEstimator(
+			*rpcConfig, bitcoindMode.EstimateMode,
+			fallBackFeeRate.FeePerKWeight(),
 		)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
+	}
 
-		// Finally, we'll create an instance of the default chain view
-		// to be used within the routing layer.
-		cc.ChainView, err = chainview.NewBtcdFilteredChainView(
-			*rpcConfig, cfg.BlockCache,
-		)
+	// We need to use some apis that are not exposed by btcwallet,
+	// for a health check function so we create an ad-hoc bitcoind
+	// connection.
+	chainConn, err := rpcclient.New(rpcConfig, nil)
+	if err != nil {
+		return err
+	}
+
+	// The api we will use for our health check depends on the
+	// bitcoind version.
+	cmd, ver, err := getBitcoindHealthCheckCmd(chainConn)
+	if err != nil {
+		return err
+	}
+
+	// If the getzmqnotifications api is available (was added in
+	// version 0.17.0) we make sure lnd subscribes to the correct
+	// zmq events. We do this to avoid a situation in which we are
+	// not notified of new transactions or blocks.
+	if ver >= 170000 {
+		zmqPubRawBlockURL, err := url.Parse(bitcoindMode.ZMQPubRawBlock)
 		if err != nil {
-			log.Errorf("unable to create chain view: %v", err)
-			return nil, nil, err
+			return err
+		}
+		zmqPubRawTxURL, err := url.Parse(bitcoindMode.ZMQPubRawTx)
+		if err != nil {
+			return err
 		}
 
-		// Create a special websockets rpc client for btcd which will be
-		// used by the wallet for notifications, calls, etc.
-		chainRPC, err := chain.NewRPCClient(
-			cfg.ActiveNetParams.Params, btcdHost, btcdUser,
-			btcdPass, rpcCert, false, 20,
-		)
+		// Fetch all active zmq notifications from the bitcoind client.
+		resp, err := chainConn.RawRequest("getzmqnotifications", nil)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
-		cc.ChainSource = chainRPC
+		zmq := []struct {
+			Type    string `json:"type"`
+			Address string `json:"address"`
+		}{}
 
-		// Use a query for our best block as a health check.
-		cc.HealthCheck = func() error {
-			_, _, err := cc.ChainSource.GetBestBlock()
+		if err = json.Unmarshal([]byte(resp), &zmq); err != nil {
 			return err
 		}
 
-		// If we're not in simnet or regtest mode, then we'll attempt
-		// to use a proper fee estimator for testnet.
-		if !cfg.Bitcoin.SimNet && !cfg.Litecoin.SimNet &&
-			!cfg.Bitcoin.RegTest && !cfg.Litecoin.RegTest {
+		pubRawBlockActive := false
+		pubRawTxActive := false
 
-			log.Info("Initializing btcd backed fee estimator")
-
-			// Finally, we'll re-initialize the fee estimator, as
-			// if we're using btcd as a backend, then we can use
-			// live fee estimates, rather than a statically coded
-			// value.
-			fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
-			cc.FeeEstimator, err = chainfee.NewBtcdEstimator(
-				*rpcConfig, fallBackFeeRate.FeePerKWeight(),
-			)
-			if err != nil {
-				return nil, nil, err
+		for i := range zmq {
+			if zmq[i].Type == "pubrawblock" {
+				url, err := url.Parse(zmq[i].Address)
+				if err != nil {
+					return err
+				}
+				if url.Port() != zmqPubRawBlockURL.Port() {
+					return fmt.Errorf(
+						"unable to subscribe to zmq block events on "+
+							"%s (bitcoind is running on %s)",
+						zmqPubRawBlockURL.Host,
+						url.Host,
+					)
+				}
+				pubRawBlockActive = true
+			}
+			if zmq[i].Type == "pubrawtx" {
+				url, err := url.Parse(zmq[i].Address)
+				if err != nil {
+					return err
+				}
+				if url.Port() != zmqPubRawTxURL.Port() {
+					return fmt.Errorf(
+						"unable to subscribe to zmq tx events on "+
+							"%s (bitcoind is running on %s)",
+						zmqPubRawTxURL.Host,
+						url.Host,
+					)
+				}
+				pubRawTxActive = true
 			}
 		}
 
-	case "nochainbackend":
-		backend := &NoChainBackend{}
-		source := &NoChainSource{
-			BestBlockTime: time.Now(),
+		// Return an error if raw tx or raw block notification over
+		// zmq is inactive.
+		if !pubRawBlockActive {
+			return errors.New(
+				"block notification over zmq is inactive on " +
+					"bitcoind",
+			)
+		}
+		if !pubRawTxActive {
+			return errors.New(
+				"tx notification over zmq is inactive on " +
+					"bitcoind",
+			)
 		}
+	}
+
+	cc.HealthCheck = func() error {
+		_, err := chainConn.RawRequest(cmd, nil)
+		return err
+	}
+
+	return nil
+}
 
-		cc.ChainNotifier = backend
-		cc.ChainView = backend
-		cc.FeeEstimator = backend
+// newBtcdBackend creates the chain specific interfaces backed by a btcd or
+// ltcd full node.
+func newBtcdBackend(cfg *Config, cc *PartialChainControl,
+	hintCache *chainntnfs.HeightHintCache) error {
 
-		cc.ChainSource = source
-		cc.HealthCheck = func() error {
-			return nil
+	// We'll be speaking directly via RPC to a node.
+	//
+	// So first we'll load btcd/ltcd's TLS cert for the RPC
+	// connection. If a raw cert was specified in the config, then
+	// we'll set that directly. Otherwise, we attempt to read the
+	// cert from the path specified in the config.
+	var btcdMode *lncfg.Btcd
+	switch {
+	case cfg.Bitcoin.Active:
+		btcdMode = cfg.BtcdMode
+	case cfg.Litecoin.Active:
+		btcdMode = cfg.LtcdMode
+	}
+
+	var (
+		rpcCert []byte
+		err     error
+	)
+	if btcdMode.RawRPCCert != "" {
+		rpcCert, err = hex.DecodeString(btcdMode.RawRPCCert)
+		if err != nil {
+			return err
+		}
+	} else {
+		certFile, err := os.Open(btcdMode.RPCCert)
+		if err != nil {
+			return err
+		}
+		rpcCert, err = ioutil.ReadAll(certFile)
+		if err != nil {
+			return err
 		}
+		if err := certFile.Close(); err != nil {
+			return err
+		}
+	}
 
-	default:
-		return nil, nil, fmt.Errorf("unknown node type: %s",
-			homeChainConfig.Node)
+	// If the specified host for the btcd/ltcd RPC server already
+	// has a port specified, then we use that directly. Otherwise,
+	// we assume the default port according to the selected chain
+	// parameters.
+	var btcdHost string
+	if strings.Contains(btcdMode.RPCHost, ":") {
+		btcdHost = btcdMode.RPCHost
+	} else {
+		btcdHost = fmt.Sprintf("%v:%v", btcdMode.RPCHost,
+			cfg.ActiveNetParams.RPCPort)
 	}
 
-	switch {
-	// If the fee URL isn't set, and the user is running mainnet, then
-	// we'll return an error to instruct them to set a proper fee
-	// estimator.
-	case cfg.FeeURL == "" && cfg.Bitcoin.MainNet &&
-		homeChainConfig.Node == "neutrino":
+	btcdUser := btcdMode.RPCUser
+	btcdPass := btcdMode.RPCPass
+	rpcConfig := &rpcclient.ConnConfig{
+		Host:                 btcdHost,
+		Endpoint:             "ws",
+		User:                 btcdUser,
+		Pass:                 btcdPass,
+		Certificates:         rpcCert,
+		DisableTLS:           false,
+		DisableConnectOnNew:  true,
+		DisableAutoReconnect: false,
+	}
+	cc.ChainNotifier, err = btcdnotify.New(
+		rpcConfig, cfg.ActiveNetParams.Params, hintCache,
+		hintCache, cfg.BlockCache,
+	)
+	if err != nil {
+		return err
+	}
 
-		return nil, nil, fmt.Errorf("--feeurl parameter required " +
-			"when running neutrino on mainnet")
+	// Finally, we'll create an instance of the default chain view
+	// to be used within the routing layer.
+	cc.ChainView, err = chainview.NewBtcdFilteredChainView(
+		*rpcConfig, cfg.BlockCache,
+	)
+	if err != nil {
+		log.Errorf("unable to create chain view: %v", err)
+		return err
+	}
 
-	// Override default fee estimator if an external service is specified.
-	case cfg.FeeURL != "":
-		// Do not cache fees on regtest to make it easier to execute
-		// manual or automated test cases.
-		cacheFees := !cfg.Bitcoin.RegTest
+	// Create a special websockets rpc client for btcd which will be
+	// used by the wallet for notifications, calls, etc.
+	chainRPC, err := chain.NewRPCClient(
+		cfg.ActiveNetParams.Params, btcdHost, btcdUser,
+		btcdPass, rpcCert, false, 20,
+	)
+	if err != nil {
+		return err
+	}
 
-		log.Infof("Using external fee estimator %v: cached=%v",
-			cfg.FeeURL, cacheFees)
+	cc.ChainSource = chainRPC
 
-		cc.FeeEstimator = chainfee.NewWebAPIEstimator(
-			chainfee.SparseConfFeeSource{
-				URL: cfg.FeeURL,
-			},
-			!cacheFees,
-		)
+	// Use a query for our best block as a health check.
+	cc.HealthCheck = func() error {
+		_, _, err := cc.ChainSource.GetBestBlock()
+		return err
 	}
 
-	ccCleanup := func() {
-		if cc.FeeEstimator != nil {
-			if err := cc.FeeEstimator.Stop(); err != nil {
-				log.Errorf("Failed to stop feeEstimator: %v",
-					err)
-			}
+	// If we're not in simnet or regtest mode, then we'll attempt
+	// to use a proper fee estimator for testnet.
+	if !cfg.Bitcoin.SimNet && !cfg.Litecoin.SimNet &&
+		!cfg.Bitcoin.RegTest && !cfg.Litecoin.RegTest {
+
+		log.Info("Initializing btcd backed fee estimator")
+
+		// Finally, we'll re-initialize the fee estimator, as
+		// if we're using btcd as a backend, then we can use
+		// live fee estimates, rather than a statically coded
+		// value.
+		fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
+		cc.FeeEstimator, err = chainfee.NewBtcdEstimator(
+			*rpcConfig, fallBackFeeRate.FeePerKWeight(),
+		)
+		if err != nil {
+			return err
 		}
 	}
 
-	// Start fee estimator.
-	if err := cc.FeeEstimator.Start(); err != nil {
-		return nil, nil, err
+	return nil
+}
+
+// newNoChainBackend creates chain interfaces that aren't backed by any chain
+// backend whatsoever, which is used when running in pure signing mode.
+func newNoChainBackend(cfg *Config, cc *PartialChainControl,
+	_ *chainntnfs.HeightHintCache) error {
+
+	backend := &NoChainBackend{}
+	source := &NoChainSource{
+		BestBlockTime: time.Now(),
 	}
 
-	// Select the default channel constraints for the primary chain.
-	cc.ChannelConstraints = GenDefaultBtcConstraints()
-	if cfg.PrimaryChain() == LitecoinChain {
-		cc.ChannelConstraints = DefaultLtcChannelConstraints
+	cc.ChainNotifier = backend
+	cc.ChainView = backend
+	cc.FeeEstimator = backend
+
+	cc.ChainSource = source
+	cc.HealthCheck = func() error {
+		return nil
 	}
 
-	return cc, ccCleanup, nil
+	return nil
 }
 
 // NewChainControl attempts to create a ChainControl instance according
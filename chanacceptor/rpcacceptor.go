@@ -9,6 +9,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet/chancloser"
@@ -43,6 +44,11 @@ var (
 	errMaxHtlcTooHigh = fmt.Errorf("htlc limit exceeds spec limit of: %v",
 		input.MaxHTLCNumber/2)
 
+	// errMinAcceptDepthTooHigh is returned if the minimum depth exceeds the
+	// number of confirmations the initiator is willing to wait for.
+	errMinAcceptDepthTooHigh = fmt.Errorf("min accept depth exceeds "+
+		"limit of: %v", chainntnfs.MaxNumConfs)
+
 	// maxErrorLength is the maximum error length we allow the error we
 	// send to our peer to be.
 	maxErrorLength = 500
@@ -385,6 +391,16 @@ func (r *RPCAcceptor) validateAcceptorResponse(dustLimit btcutil.Amount,
 		return false, errChannelRejected, nil, errMaxHtlcTooHigh
 	}
 
+	// The initiator won't wait for more than MaxNumConfs confirmations
+	// and would fail the funding flow, so we catch this early as well.
+	if req.MinAcceptDepth > chainntnfs.MaxNumConfs {
+		log.Errorf("Min accept depth: %v for channel: %v is greater "+
+			"than limit of: %v", req.MinAcceptDepth, channelStr,
+			chainntnfs.MaxNumConfs)
+
+		return false, errChannelRejected, nil, errMinAcceptDepthTooHigh
+	}
+
 	// Ensure that the reserve that has been proposed, if it is set, is at
 	// least the dust limit that was proposed by the remote peer. This is
 	// required by BOLT 2.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet/chancloser"
@@ -20,7 +21,7 @@ func TestValidateAcceptorResponse(t *testing.T) {
 		customError = errors.New("custom error")
 		validAddr   = "bcrt1qwrmq9uca0t3dy9t9wtuq5tm4405r7tfzyqn9pp"
 		addr, _     = chancloser.ParseUpfrontShutdownAddress(
-			validAddr, &chaincfg.TestNet3Params,
+			validAddr, &chaincfg.RegressionNetParams,
 		)
 	)
 
@@ -115,6 +116,16 @@ func TestValidateAcceptorResponse(t *testing.T) {
 			acceptorErr: errChannelRejected,
 			error:       errMaxHtlcTooHigh,
 		},
+		{
+			name: "min accept depth too high",
+			response: &lnrpc.ChannelAcceptResponse{
+				Accept:         true,
+				MinAcceptDepth: 1 + chainntnfs.MaxNumConfs,
+			},
+			accept:      false,
+			acceptorErr: errChannelRejected,
+			error:       errMinAcceptDepthTooHigh,
+		},
 	}
 
 	for _, test := range tests {
@@ -124,7 +135,7 @@ func TestValidateAcceptorResponse(t *testing.T) {
 			// Create an acceptor, everything can be nil because
 			// we just need the params.
 			acceptor := NewRPCAcceptor(
-				nil, nil, 0, &chaincfg.TestNet3Params, nil,
+				nil, nil, 0, &chaincfg.RegressionNetParams, nil,
 			)
 
 			accept, acceptErr, shutdown, err := acceptor.validateAcceptorResponse(
@@ -299,6 +299,11 @@ func (f *ForwardingLog) Query(q ForwardingEventQuery) (ForwardingLogTimeSlice, e
 		resp = ForwardingLogTimeSlice{
 			ForwardingEventQuery: q,
 		}
+
+		// The counters are advanced within the transaction, so they
+		// need to be restored as well if it is retried.
+		recordsToSkip = q.IndexOffset
+		recordOffset = q.IndexOffset
 	})
 	if err != nil && err != ErrNoForwardingEvents {
 		return ForwardingLogTimeSlice{}, err
@@ -1036,10 +1036,6 @@ func (c *ChannelGraph) addChannelEdge(tx kvdb.RwTx, edge *ChannelEdgeInfo) error
 		return ErrEdgeAlreadyExist
 	}
 
-	if c.graphCache != nil {
-		c.graphCache.AddChannel(edge, nil, nil)
-	}
-
 	// Before we insert the channel into the database, we'll ensure that
 	// both nodes already exist in the channel graph. If either node
 	// doesn't, then we'll insert a "shell" node that just includes its
@@ -1057,7 +1053,7 @@ func (c *ChannelGraph) addChannelEdge(tx kvdb.RwTx, edge *ChannelEdgeInfo) error
 				"for: %x", edge.NodeKey1Bytes)
 		}
 	case node1Err != nil:
-		return err
+		return node1Err
 	}
 
 	_, node2Err := fetchLightningNode(nodes, edge.NodeKey2Bytes[:])
@@ -1073,7 +1069,7 @@ func (c *ChannelGraph) addChannelEdge(tx kvdb.RwTx, edge *ChannelEdgeInfo) error
 				"for: %x", edge.NodeKey2Bytes)
 		}
 	case node2Err != nil:
-		return err
+		return node2Err
 	}
 
 	// If the edge hasn't been created yet, then we'll first add it to the
@@ -1102,7 +1098,18 @@ func (c *ChannelGraph) addChannelEdge(tx kvdb.RwTx, edge *ChannelEdgeInfo) error
 	if err := writeOutpoint(&b, &edge.ChannelPoint); err != nil {
 		return err
 	}
-	return chanIndex.Put(b.Bytes(), chanKey[:])
+	if err := chanIndex.Put(b.Bytes(), chanKey[:]); err != nil {
+		return err
+	}
+
+	// Only once the edge has been fully written do we add it to the graph
+	// cache, so a failed insertion doesn't leave behind a channel that
+	// path finding would try to use.
+	if c.graphCache != nil {
+		c.graphCache.AddChannel(edge, nil, nil)
+	}
+
+	return nil
 }
 
 // HasChannelEdge returns true if the database knows of a channel edge with the
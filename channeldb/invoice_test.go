@@ -509,6 +509,95 @@ func TestInvoiceCancelSingleHtlc(t *testing.T) {
 	}
 }
 
+// TestInvoiceReopenAccepted tests that an accepted invoice can only be moved
+// back to the open state if htlcs are canceled as part of the same update.
+func TestInvoiceReopenAccepted(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := MakeTestDB()
+	defer cleanUp()
+	require.NoError(t, err, "unable to make test db")
+
+	preimage := lntypes.Preimage{1}
+	paymentHash := preimage.Hash()
+
+	testInvoice := &Invoice{
+		Htlcs: map[CircuitKey]*InvoiceHTLC{},
+		Terms: ContractTerm{
+			Value:    lnwire.NewMSatFromSatoshis(10000),
+			Features: emptyFeatures,
+		},
+		HodlInvoice: true,
+	}
+
+	_, err = db.AddInvoice(testInvoice, paymentHash)
+	require.NoError(t, err)
+
+	// Accept the invoice with a single htlc.
+	key := CircuitKey{ChanID: lnwire.NewShortChanIDFromInt(1), HtlcID: 4}
+	htlc := HtlcAcceptDesc{
+		Amt:           lnwire.NewMSatFromSatoshis(10000),
+		CustomRecords: make(record.CustomSet),
+	}
+
+	ref := InvoiceRefByHash(paymentHash)
+	invoice, err := db.UpdateInvoice(ref, nil,
+		func(invoice *Invoice) (*InvoiceUpdateDesc, error) {
+			return &InvoiceUpdateDesc{
+				AddHtlcs: map[CircuitKey]*HtlcAcceptDesc{
+					key: &htlc,
+				},
+				State: &InvoiceStateUpdateDesc{
+					NewState: ContractAccepted,
+				},
+			}, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, ContractAccepted, invoice.State)
+
+	// Moving the invoice back to open without canceling any htlcs isn't
+	// allowed.
+	_, err = db.UpdateInvoice(ref, nil,
+		func(invoice *Invoice) (*InvoiceUpdateDesc, error) {
+			return &InvoiceUpdateDesc{
+				State: &InvoiceStateUpdateDesc{
+					NewState: ContractOpen,
+				},
+			}, nil
+		})
+	require.ErrorIs(t, err, ErrInvoiceCannotOpen)
+
+	// Canceling the htlc on its own isn't allowed either, as it would
+	// leave an incomplete htlc set accepted.
+	_, err = db.UpdateInvoice(ref, nil,
+		func(invoice *Invoice) (*InvoiceUpdateDesc, error) {
+			return &InvoiceUpdateDesc{
+				CancelHtlcs: map[CircuitKey]struct{}{
+					key: {},
+				},
+			}, nil
+		})
+	require.Error(t, err)
+
+	// Canceling the htlc and moving the invoice back to open at once is
+	// allowed.
+	invoice, err = db.UpdateInvoice(ref, nil,
+		func(invoice *Invoice) (*InvoiceUpdateDesc, error) {
+			return &InvoiceUpdateDesc{
+				CancelHtlcs: map[CircuitKey]struct{}{
+					key: {},
+				},
+				State: &InvoiceStateUpdateDesc{
+					NewState: ContractOpen,
+				},
+			}, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, ContractOpen, invoice.State)
+	require.Equal(t, HtlcStateCanceled, invoice.Htlcs[key].State)
+	require.Zero(t, invoice.AmtPaid)
+}
+
 // TestInvoiceCancelSingleHtlcAMP tests that it's possible to cancel a single
 // invoice of an AMP HTLC across multiple set IDs, and also have that update
 // the amount paid and other related fields as well.
@@ -1498,6 +1587,67 @@ func TestCustomRecords(t *testing.T) {
 	)
 }
 
+// TestInvoiceCustomRecords tests that custom records attached by the invoice
+// creator are persisted with the invoice and kept across invoice updates.
+func TestInvoiceCustomRecords(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := MakeTestDB()
+	defer cleanUp()
+	require.NoError(t, err, "unable to make test db")
+
+	records := record.CustomSet{
+		100000: []byte{},
+		100001: []byte("order-42"),
+	}
+
+	testInvoice, err := randInvoice(10000)
+	require.NoError(t, err)
+	testInvoice.CustomRecords = records
+
+	paymentHash := testInvoice.Terms.PaymentPreimage.Hash()
+	_, err = db.AddInvoice(testInvoice, paymentHash)
+	require.NoError(t, err)
+
+	ref := InvoiceRefByHash(paymentHash)
+	dbInvoice, err := db.LookupInvoice(ref)
+	require.NoError(t, err)
+	require.Equal(t, records, dbInvoice.CustomRecords)
+
+	// Settle the invoice and assert that the records are still present.
+	_, err = db.UpdateInvoice(ref, nil, getUpdateInvoice(10000))
+	require.NoError(t, err)
+
+	dbInvoice, err = db.LookupInvoice(ref)
+	require.NoError(t, err)
+	require.Equal(t, ContractSettled, dbInvoice.State)
+	require.Equal(t, records, dbInvoice.CustomRecords)
+
+	// Records outside of the custom range must be rejected.
+	invalidInvoice, err := randInvoice(10000)
+	require.NoError(t, err)
+	invalidInvoice.CustomRecords = record.CustomSet{
+		100: []byte{1},
+	}
+
+	_, err = db.AddInvoice(
+		invalidInvoice, invalidInvoice.Terms.PaymentPreimage.Hash(),
+	)
+	require.Error(t, err)
+
+	// As must records that exceed the maximum total size.
+	largeInvoice, err := randInvoice(10000)
+	require.NoError(t, err)
+	largeInvoice.CustomRecords = record.CustomSet{
+		100000: make([]byte, MaxCustomRecordsSize+1),
+	}
+
+	_, err = db.AddInvoice(
+		largeInvoice, largeInvoice.Terms.PaymentPreimage.Hash(),
+	)
+	require.Error(t, err)
+}
+
 // TestInvoiceHtlcAMPFields asserts that the set id and preimage fields are
 // properly recorded when updating an invoice.
 func TestInvoiceHtlcAMPFields(t *testing.T) {
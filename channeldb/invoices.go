@@ -174,6 +174,10 @@ const (
 	// lengths are final.
 	MaxPaymentRequestSize = 4096
 
+	// MaxCustomRecordsSize is the maximum total size of the values of the
+	// custom records that an invoice creator may attach to an invoice.
+	MaxCustomRecordsSize = 4096
+
 	// A set of tlv type definitions used to serialize invoice htlcs to the
 	// database.
 	//
@@ -595,6 +599,11 @@ type Invoice struct {
 	// HodlInvoice indicates whether the invoice should be held in the
 	// Accepted state or be settled right away.
 	HodlInvoice bool
+
+	// CustomRecords contains custom key/value pairs that were attached to
+	// the invoice by its creator. They are stored alongside the invoice
+	// and are not part of the payment request.
+	CustomRecords record.CustomSet
 }
 
 // HTLCSet returns the set of HTLCs belonging to setID and in the provided
@@ -879,6 +888,20 @@ func validateInvoice(i *Invoice, paymentHash lntypes.Hash) error {
 		return err
 	}
 
+	if err := i.CustomRecords.Validate(); err != nil {
+		return err
+	}
+
+	var customRecordsSize int
+	for _, value := range i.CustomRecords {
+		customRecordsSize += len(value)
+	}
+	if customRecordsSize > MaxCustomRecordsSize {
+		return fmt.Errorf("max total size of custom records is %v, "+
+			"size provided was %v", MaxCustomRecordsSize,
+			customRecordsSize)
+	}
+
 	// AMP invoices and hodl invoices are allowed to have no preimage
 	// specified.
 	isAMP := i.Terms.Features.HasFeature(
@@ -1610,7 +1633,7 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		hodlInvoice = 1
 	}
 
-	tlvStream, err := tlv.NewStream(
+	records := []tlv.Record{
 		// Memo and payreq.
 		tlv.MakePrimitiveRecord(memoType, &i.Memo),
 		tlv.MakePrimitiveRecord(payReqType, &i.PaymentRequest),
@@ -1641,7 +1664,16 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 			i.AMPState.recordSize,
 			ampStateEncoder, ampStateDecoder,
 		),
-	)
+	}
+
+	// Custom records set by the invoice creator are stored in-line in the
+	// invoice body. As they are all in the custom type range, they can't
+	// collide with the records above.
+	customRecords := tlv.MapToRecords(i.CustomRecords)
+	records = append(records, customRecords...)
+	tlv.SortRecords(records)
+
+	tlvStream, err := tlv.NewStream(records...)
 	if err != nil {
 		return err
 	}
@@ -2029,10 +2061,16 @@ func deserializeInvoice(r io.Reader) (Invoice, error) {
 	}
 
 	lr := io.LimitReader(r, bodyLen)
-	if err = tlvStream.Decode(lr); err != nil {
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(lr)
+	if err != nil {
 		return i, err
 	}
 
+	customRecords := hop.NewCustomRecords(parsedTypes)
+	if len(customRecords) > 0 {
+		i.CustomRecords = customRecords
+	}
+
 	preimage := lntypes.Preimage(preimageBytes)
 	if preimage != unknownPreimage {
 		i.Terms.PaymentPreimage = &preimage
@@ -2437,6 +2475,13 @@ func copyInvoice(src *Invoice) *Invoice {
 
 	dest.Terms.Features = src.Terms.Features.Clone()
 
+	if src.CustomRecords != nil {
+		dest.CustomRecords = make(record.CustomSet)
+		for k, v := range src.CustomRecords {
+			dest.CustomRecords[k] = copySlice(v)
+		}
+	}
+
 	if src.Terms.PaymentPreimage != nil {
 		preimage := *src.Terms.PaymentPreimage
 		dest.Terms.PaymentPreimage = &preimage
@@ -2719,6 +2764,7 @@ func (d *DB) updateInvoice(hash *lntypes.Hash, refSetID *SetID, invoices,
 
 	// Process cancel actions from update descriptor.
 	cancelHtlcs := update.CancelHtlcs
+	htlcsCanceled := len(cancelHtlcs) > 0
 	for key, htlc := range invoice.Htlcs {
 		htlc := htlc
 
@@ -2766,7 +2812,7 @@ func (d *DB) updateInvoice(hash *lntypes.Hash, refSetID *SetID, invoices,
 	// HTLCs.
 	if update.State != nil {
 		newState, err := updateInvoiceState(
-			&invoice, hash, *update.State,
+			&invoice, hash, *update.State, htlcsCanceled,
 		)
 		if err != nil {
 			return nil, err
@@ -2929,13 +2975,22 @@ func (d *DB) updateInvoice(hash *lntypes.Hash, refSetID *SetID, invoices,
 
 // updateInvoiceState validates and processes an invoice state update. The new
 // state to transition to is returned, so the caller is able to select exactly
-// how the invoice state is updated.
+// how the invoice state is updated. The htlcsCanceled flag indicates whether
+// htlcs of the invoice are canceled as part of the same update.
 func updateInvoiceState(invoice *Invoice, hash *lntypes.Hash,
-	update InvoiceStateUpdateDesc) (*ContractState, error) {
+	update InvoiceStateUpdateDesc, htlcsCanceled bool) (*ContractState,
+	error) {
 
-	// Returning to open is never allowed from any state.
+	// Returning to open is only allowed for an accepted invoice that has
+	// htlcs canceled, as its htlc set is no longer complete afterwards.
 	if update.NewState == ContractOpen {
-		return nil, ErrInvoiceCannotOpen
+		if invoice.State != ContractAccepted || !htlcsCanceled ||
+			update.SetID != nil {
+
+			return nil, ErrInvoiceCannotOpen
+		}
+
+		return &update.NewState, nil
 	}
 
 	switch invoice.State {
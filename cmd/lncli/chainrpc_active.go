@@ -0,0 +1,266 @@
+//go:build chainrpc
+// +build chainrpc
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/urfave/cli"
+)
+
+// chainCommands will return the set of commands to enable for chainrpc
+// builds.
+func chainCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:        "chain",
+			Category:    "On-chain",
+			Usage:       "Interact with the bitcoin blockchain.",
+			Description: "",
+			Subcommands: []cli.Command{
+				getBestBlockCommand,
+				getBlockHashCommand,
+				getBlockCommand,
+				getBlockHeaderCommand,
+			},
+		},
+	}
+}
+
+func getChainClient(ctx *cli.Context) (chainrpc.ChainKitClient, func()) {
+	conn := getClientConn(ctx, false)
+	cleanUp := func() {
+		conn.Close()
+	}
+	return chainrpc.NewChainKitClient(conn), cleanUp
+}
+
+// parseBlockHash parses the block hash given either as the first argument or
+// through the --hash flag of the command.
+func parseBlockHash(ctx *cli.Context) (*chainhash.Hash, error) {
+	var hashStr string
+	switch {
+	case ctx.IsSet("hash"):
+		hashStr = ctx.String("hash")
+
+	case ctx.Args().Present():
+		hashStr = ctx.Args().First()
+
+	default:
+		return nil, fmt.Errorf("block hash argument missing")
+	}
+
+	return chainhash.NewHashFromStr(hashStr)
+}
+
+var getBestBlockCommand = cli.Command{
+	Name:  "getbestblock",
+	Usage: "Get the hash and height of the best block.",
+	Description: "Returns the latest block hash and height of the valid " +
+		"most-work chain.",
+	Action: actionDecorator(getBestBlock),
+}
+
+func getBestBlock(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	client, cleanUp := getChainClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.GetBestBlock(ctxc, &chainrpc.GetBestBlockRequest{})
+	if err != nil {
+		return err
+	}
+
+	blockHash, err := chainhash.NewHash(resp.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		BlockHash   string `json:"block_hash"`
+		BlockHeight int32  `json:"block_height"`
+	}{
+		BlockHash:   blockHash.String(),
+		BlockHeight: resp.BlockHeight,
+	})
+
+	return nil
+}
+
+var getBlockHashCommand = cli.Command{
+	Name:      "getblockhash",
+	Usage:     "Get the hash of the block at the given height.",
+	ArgsUsage: "height",
+	Description: "Returns the hash of the block in the best chain at the " +
+		"given height.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "height",
+			Usage: "the height of the block",
+		},
+	},
+	Action: actionDecorator(getBlockHash),
+}
+
+func getBlockHash(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	var (
+		height int64
+		err    error
+	)
+	switch {
+	case ctx.IsSet("height"):
+		height = ctx.Int64("height")
+
+	case ctx.Args().Present():
+		height, err = strconv.ParseInt(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse height: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("height argument missing")
+	}
+
+	client, cleanUp := getChainClient(ctx)
+	defer cleanUp()
+
+	req := &chainrpc.GetBlockHashRequest{BlockHeight: height}
+	resp, err := client.GetBlockHash(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	blockHash, err := chainhash.NewHash(resp.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		BlockHash string `json:"block_hash"`
+	}{
+		BlockHash: blockHash.String(),
+	})
+
+	return nil
+}
+
+var getBlockCommand = cli.Command{
+	Name:      "getblock",
+	Usage:     "Get a block.",
+	ArgsUsage: "hash",
+	Description: `
+	Returns the raw block with the given hash, serialized as hex. If
+	--verbose is set, the hashes of the block's transactions are returned
+	as well.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "hash",
+			Usage: "the hash of the block",
+		},
+		cli.BoolFlag{
+			Name:  "verbose",
+			Usage: "also list the transaction hashes of the block",
+		},
+	},
+	Action: actionDecorator(getBlock),
+}
+
+func getBlock(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	blockHash, err := parseBlockHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, cleanUp := getChainClient(ctx)
+	defer cleanUp()
+
+	req := &chainrpc.GetBlockRequest{BlockHash: blockHash[:]}
+	resp, err := client.GetBlock(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool("verbose") {
+		printJSON(struct {
+			RawBlock string `json:"raw_block"`
+		}{
+			RawBlock: hex.EncodeToString(resp.RawBlock),
+		})
+
+		return nil
+	}
+
+	var block wire.MsgBlock
+	err = block.Deserialize(bytes.NewReader(resp.RawBlock))
+	if err != nil {
+		return fmt.Errorf("unable to decode block: %v", err)
+	}
+
+	txids := make([]string, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		txids = append(txids, tx.TxHash().String())
+	}
+
+	printJSON(struct {
+		RawBlock string   `json:"raw_block"`
+		Txids    []string `json:"txids"`
+	}{
+		RawBlock: hex.EncodeToString(resp.RawBlock),
+		Txids:    txids,
+	})
+
+	return nil
+}
+
+var getBlockHeaderCommand = cli.Command{
+	Name:        "getblockheader",
+	Usage:       "Get a block header.",
+	ArgsUsage:   "hash",
+	Description: "Returns the raw header of the block with the given hash.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "hash",
+			Usage: "the hash of the block",
+		},
+	},
+	Action: actionDecorator(getBlockHeader),
+}
+
+func getBlockHeader(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	blockHash, err := parseBlockHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, cleanUp := getChainClient(ctx)
+	defer cleanUp()
+
+	req := &chainrpc.GetBlockHeaderRequest{BlockHash: blockHash[:]}
+	resp, err := client.GetBlockHeader(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		RawBlockHeader string `json:"raw_block_header"`
+	}{
+		RawBlockHeader: hex.EncodeToString(resp.RawBlockHeader),
+	})
+
+	return nil
+}
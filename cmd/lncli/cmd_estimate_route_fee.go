@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var estimateRouteFeeCommand = cli.Command{
+	Name:     "estimateroutefee",
+	Category: "Payments",
+	Usage:    "Estimate the routing fee and time lock to a destination.",
+	Description: `
+	Query for a route to the destination that can carry the given amount
+	and return the fee in milli-satoshis as well as the total time lock of
+	that route. The result can be used to quote the cost of a payment
+	before actually sending it.`,
+	ArgsUsage: "dest amt",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "dest",
+			Usage: "the 33-byte hex-encoded public key of the " +
+				"destination node",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to send expressed in satoshis",
+		},
+		cli.Uint64Flag{
+			Name: "final_cltv_delta",
+			Usage: "(optional) the cltv delta required by the " +
+				"destination, if not set the default of the " +
+				"node is used",
+		},
+	},
+	Action: actionDecorator(estimateRouteFee),
+}
+
+func estimateRouteFee(ctx *cli.Context) error {
+	ctxc := getContext()
+	args := ctx.Args()
+
+	var (
+		destStr string
+		amt     int64
+		err     error
+	)
+
+	switch {
+	case ctx.IsSet("dest"):
+		destStr = ctx.String("dest")
+	case args.Present():
+		destStr = args.First()
+		args = args.Tail()
+	default:
+		return errors.New("dest argument missing")
+	}
+
+	dest, err := hex.DecodeString(destStr)
+	if err != nil {
+		return fmt.Errorf("unable to decode dest: %v", err)
+	}
+
+	switch {
+	case ctx.IsSet("amt"):
+		amt = ctx.Int64("amt")
+	case args.Present():
+		amt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt: %v", err)
+		}
+	default:
+		return errors.New("amt argument missing")
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.RouteFeeRequest{
+		Dest:           dest,
+		AmtSat:         amt,
+		FinalCltvDelta: uint32(ctx.Uint64("final_cltv_delta")),
+	}
+
+	resp, err := client.EstimateRouteFee(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
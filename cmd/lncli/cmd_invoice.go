@@ -71,6 +71,15 @@ var addInvoiceCommand = cli.Command{
 			Usage: "creates an AMP invoice. If true, preimage " +
 				"should not be set.",
 		},
+		cli.StringFlag{
+			Name: "data",
+			Usage: "attach custom data to the invoice that is " +
+				"stored with it and returned on lookup. The " +
+				"required format is: <record_id>=<hex_value>," +
+				"<record_id>=<hex_value>,.. For example: " +
+				"--data 3438382=0a21ff. Custom record ids " +
+				"start from 65536.",
+		},
 	},
 	Action: actionDecorator(addInvoice),
 }
@@ -115,6 +124,11 @@ func addInvoice(ctx *cli.Context) error {
 		return fmt.Errorf("unable to parse description_hash: %v", err)
 	}
 
+	customRecords, err := parseCustomRecords(ctx.String("data"))
+	if err != nil {
+		return err
+	}
+
 	invoice := &lnrpc.Invoice{
 		Memo:            ctx.String("memo"),
 		RPreimage:       preimage,
@@ -125,6 +139,7 @@ func addInvoice(ctx *cli.Context) error {
 		Expiry:          ctx.Int64("expiry"),
 		Private:         ctx.Bool("private"),
 		IsAmp:           ctx.Bool("amp"),
+		CustomRecords:   customRecords,
 	}
 
 	resp, err := client.AddInvoice(ctxc, invoice)
@@ -74,6 +74,28 @@ var setCfgCommand = cli.Command{
 			Usage: "the amount of time to wait after a failure " +
 				"before raising failure amount",
 		},
+		cli.StringFlag{
+			Name: "estimator",
+			Usage: "the probability estimator to use for " +
+				"pathfinding, either 'apriori' or 'bimodal'",
+		},
+		cli.Uint64Flag{
+			Name: "bimodalscale",
+			Usage: "the scale in msat over which channels " +
+				"statistically have some liquidity left " +
+				"(bimodal estimator)",
+		},
+		cli.Float64Flag{
+			Name: "bimodalnodeweight",
+			Usage: "the weight of results on other channels of " +
+				"a node, expressed as value in [0;1] " +
+				"(bimodal estimator)",
+		},
+		cli.DurationFlag{
+			Name: "bimodaldecaytime",
+			Usage: "the time scale over which previous results " +
+				"are forgotten (bimodal estimator)",
+		},
 	},
 	Action: actionDecorator(setCfg),
 }
@@ -123,6 +145,30 @@ func setCfg(ctx *cli.Context) error {
 		).Seconds())
 	}
 
+	if ctx.IsSet("estimator") {
+		haveValue = true
+		resp.Config.Estimator = ctx.String("estimator")
+	}
+
+	if ctx.IsSet("bimodalscale") {
+		haveValue = true
+		resp.Config.BimodalScaleMsat = ctx.Uint64("bimodalscale")
+	}
+
+	if ctx.IsSet("bimodalnodeweight") {
+		haveValue = true
+		resp.Config.BimodalNodeWeight = float32(
+			ctx.Float64("bimodalnodeweight"),
+		)
+	}
+
+	if ctx.IsSet("bimodaldecaytime") {
+		haveValue = true
+		resp.Config.BimodalDecayTimeSeconds = uint64(ctx.Duration(
+			"bimodaldecaytime",
+		).Seconds())
+	}
+
 	if !haveValue {
 		return cli.ShowCommandHelp(ctx, "setmccfg")
 	}
@@ -81,6 +81,11 @@ var openChannelCommand = cli.Command{
 	amount to the remote node as part of the channel opening. Once the channel is open,
 	a channelPoint (txid:vout) of the funding output is returned.
 
+	If the --fundmax flag is set instead of local-amt, the wallet commits as
+	much of its balance to the channel as possible, up to the maximum channel
+	size, keeping the reserve needed to fee bump anchor channels. Specific
+	wallet utxos can be selected for funding via repeated --utxo flags.
+
 	If the remote peer supports the option upfront shutdown feature bit (query
 	listpeers to see their supported feature bits), an address to enforce
 	payout of funds on cooperative close can optionally be provided. Note that
@@ -104,6 +109,34 @@ var openChannelCommand = cli.Command{
 			Name:  "local_amt",
 			Usage: "the number of satoshis the wallet should commit to the channel",
 		},
+		cli.BoolFlag{
+			Name: "fundmax",
+			Usage: "if set, the wallet will attempt to commit " +
+				"the maximum possible local amount to the " +
+				"channel. This must not be set at the same " +
+				"time as local_amt",
+		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "a utxo specified as outpoint(tx:idx) which " +
+				"will be used to fund a channel. This flag " +
+				"can be repeatedly used to fund a channel " +
+				"with a selection of utxos",
+		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) the name of the wallet account " +
+				"the channel is funded from and the change " +
+				"is sent to. If not set, the default account " +
+				"is used",
+		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "(optional) the strategy to use for selecting " +
+				"the coins that fund the channel. Options " +
+				"are 'largest' and 'random'. If not set, the " +
+				"strategy from the global config is used",
+		},
 		cli.IntFlag{
 			Name: "push_amt",
 			Usage: "the number of satoshis to give the remote side " +
@@ -204,6 +237,13 @@ var openChannelCommand = cli.Command{
 			Usage: "(optional) the maximum value in msat that " +
 				"can be pending within the channel at any given time",
 		},
+		cli.Uint64Flag{
+			Name: "remote_reserve_sats",
+			Usage: "(optional) the channel reserve in satoshis " +
+				"that we require the remote peer to keep. If " +
+				"this is not set, we will scale the value " +
+				"according to the channel size",
+		},
 		cli.StringFlag{
 			Name: "channel_type",
 			Usage: fmt.Sprintf("(optional) the type of channel to "+
@@ -249,6 +289,7 @@ func openChannel(ctx *cli.Context) error {
 		CloseAddress:               ctx.String("close_address"),
 		RemoteMaxValueInFlightMsat: ctx.Uint64("remote_max_value_in_flight_msat"),
 		MaxLocalCsv:                uint32(ctx.Uint64("max_local_csv")),
+		RemoteChanReserveSat:       ctx.Uint64("remote_reserve_sats"),
 	}
 
 	switch {
@@ -294,7 +335,16 @@ func openChannel(ctx *cli.Context) error {
 		}
 	}
 
+	// The fundmax flag replaces the local amount, which therefore can't be
+	// given as a flag or an argument.
+	req.FundMax = ctx.Bool("fundmax")
+
 	switch {
+	case req.FundMax && ctx.IsSet("local_amt"):
+		return fmt.Errorf("local amount cannot be set if attempting " +
+			"to commit the maximum amount out of the wallet")
+	case req.FundMax:
+		break
 	case ctx.IsSet("local_amt"):
 		req.LocalFundingAmount = int64(ctx.Int("local_amt"))
 	case args.Present():
@@ -316,6 +366,21 @@ func openChannel(ctx *cli.Context) error {
 		}
 	}
 
+	for _, utxo := range ctx.StringSlice("utxo") {
+		outpoint, err := NewProtoOutPoint(utxo)
+		if err != nil {
+			return fmt.Errorf("unable to decode utxo %v: %v", utxo,
+				err)
+		}
+		req.Outpoints = append(req.Outpoints, outpoint)
+	}
+	req.Account = ctx.String("account")
+
+	req.CoinSelectionStrategy, err = parseCoinSelectionStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	req.Private = ctx.Bool("private")
 
 	// Parse the channel type and map it to its RPC representation.
@@ -401,6 +401,38 @@ func sendPayment(ctx *cli.Context) error {
 	return sendPaymentRequest(ctx, req)
 }
 
+// parseCustomRecords parses a set of custom records in the format
+// <record_id>=<hex_value>,<record_id>=<hex_value>,.. as used by the data flag.
+func parseCustomRecords(data string) (map[uint64][]byte, error) {
+	customRecords := make(map[uint64][]byte)
+	if data == "" {
+		return customRecords, nil
+	}
+
+	records := strings.Split(data, ",")
+	for _, r := range records {
+		kv := strings.Split(r, "=")
+		if len(kv) != 2 {
+			return nil, errors.New("invalid data format: " +
+				"multiple equal signs in record")
+		}
+
+		recordID, err := strconv.ParseUint(kv[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data format: %v", err)
+		}
+
+		hexValue, err := hex.DecodeString(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid data format: %v", err)
+		}
+
+		customRecords[recordID] = hexValue
+	}
+
+	return customRecords, nil
+}
+
 func sendPaymentRequest(ctx *cli.Context,
 	req *routerrpc.SendPaymentRequest) error {
 
@@ -456,30 +488,12 @@ func sendPaymentRequest(ctx *cli.Context,
 	}
 
 	// Parse custom data records.
-	data := ctx.String(dataFlag.Name)
-	if data != "" {
-		records := strings.Split(data, ",")
-		for _, r := range records {
-			kv := strings.Split(r, "=")
-			if len(kv) != 2 {
-				return errors.New("invalid data format: " +
-					"multiple equal signs in record")
-			}
-
-			recordID, err := strconv.ParseUint(kv[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid data format: %v",
-					err)
-			}
-
-			hexValue, err := hex.DecodeString(kv[1])
-			if err != nil {
-				return fmt.Errorf("invalid data format: %v",
-					err)
-			}
-
-			req.DestCustomRecords[recordID] = hexValue
-		}
+	customRecords, err := parseCustomRecords(ctx.String(dataFlag.Name))
+	if err != nil {
+		return err
+	}
+	for recordID, value := range customRecords {
+		req.DestCustomRecords[recordID] = value
 	}
 
 	var feeLimit int64
@@ -1020,6 +1034,7 @@ var queryRoutesCommand = cli.Command{
 				"that must be taken to the first hop",
 		},
 		cltvLimitFlag,
+		dataFlag,
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -1074,6 +1089,13 @@ func queryRoutes(ctx *cli.Context) error {
 		OutgoingChanId:    ctx.Uint64("outgoing_chanid"),
 	}
 
+	req.DestCustomRecords, err = parseCustomRecords(
+		ctx.String(dataFlag.Name),
+	)
+	if err != nil {
+		return err
+	}
+
 	route, err := client.QueryRoutes(ctxc, req)
 	if err != nil {
 		return err
@@ -1332,6 +1354,7 @@ var buildRouteCommand = cli.Command{
 				"use for the first hop of the payment",
 			Value: 0,
 		},
+		dataFlag,
 	},
 }
 
@@ -1366,12 +1389,18 @@ func buildRoute(ctx *cli.Context) error {
 		}
 	}
 
+	customRecords, err := parseCustomRecords(ctx.String(dataFlag.Name))
+	if err != nil {
+		return err
+	}
+
 	// Call BuildRoute rpc.
 	req := &routerrpc.BuildRouteRequest{
-		AmtMsat:        amtMsat,
-		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
-		HopPubkeys:     rpcHops,
-		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+		AmtMsat:           amtMsat,
+		FinalCltvDelta:    int32(ctx.Int64("final_cltv_delta")),
+		HopPubkeys:        rpcHops,
+		OutgoingChanId:    ctx.Uint64("outgoing_chan_id"),
+		DestCustomRecords: customRecords,
 	}
 
 	route, err := client.BuildRoute(ctxc, req)
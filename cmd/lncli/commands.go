@@ -235,6 +235,35 @@ var txLabelFlag = cli.StringFlag{
 	Usage: "(optional) a label for the transaction",
 }
 
+var coinSelectionStrategyFlag = cli.StringFlag{
+	Name: "coin_selection_strategy",
+	Usage: "(optional) the strategy to use for selecting coins. Options " +
+		"are 'largest' and 'random'. If not set, the strategy from " +
+		"the global config is used",
+}
+
+// parseCoinSelectionStrategy parses the coin selection strategy flag into its
+// RPC counterpart.
+func parseCoinSelectionStrategy(
+	ctx *cli.Context) (lnrpc.CoinSelectionStrategy, error) {
+
+	switch strategy := ctx.String(coinSelectionStrategyFlag.Name); strategy {
+	case "":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG,
+			nil
+
+	case "largest":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_LARGEST, nil
+
+	case "random":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM, nil
+
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy %v",
+			strategy)
+	}
+}
+
 var sendCoinsCommand = cli.Command{
 	Name:      "sendcoins",
 	Category:  "On-chain",
@@ -290,6 +319,7 @@ var sendCoinsCommand = cli.Command{
 			Value: defaultUtxoMinConf,
 		},
 		txLabelFlag,
+		coinSelectionStrategyFlag,
 	},
 	Action: actionDecorator(sendCoins),
 }
@@ -351,19 +381,25 @@ func sendCoins(ctx *cli.Context) error {
 			"sweep all coins out of the wallet")
 	}
 
+	coinSelectionStrategy, err := parseCoinSelectionStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
 	minConfs := int32(ctx.Uint64("min_confs"))
 	req := &lnrpc.SendCoinsRequest{
-		Addr:             addr,
-		Amount:           amt,
-		TargetConf:       int32(ctx.Int64("conf_target")),
-		SatPerVbyte:      ctx.Uint64(feeRateFlag),
-		SendAll:          ctx.Bool("sweepall"),
-		Label:            ctx.String(txLabelFlag.Name),
-		MinConfs:         minConfs,
-		SpendUnconfirmed: minConfs == 0,
+		Addr:                  addr,
+		Amount:                amt,
+		TargetConf:            int32(ctx.Int64("conf_target")),
+		SatPerVbyte:           ctx.Uint64(feeRateFlag),
+		SendAll:               ctx.Bool("sweepall"),
+		Label:                 ctx.String(txLabelFlag.Name),
+		MinConfs:              minConfs,
+		SpendUnconfirmed:      minConfs == 0,
+		CoinSelectionStrategy: coinSelectionStrategy,
 	}
 	txid, err := client.SendCoins(ctxc, req)
 	if err != nil {
@@ -533,6 +569,7 @@ var sendManyCommand = cli.Command{
 			Value: defaultUtxoMinConf,
 		},
 		txLabelFlag,
+		coinSelectionStrategyFlag,
 	},
 	Action: actionDecorator(sendMany),
 }
@@ -562,17 +599,23 @@ func sendMany(ctx *cli.Context) error {
 		return err
 	}
 
+	coinSelectionStrategy, err := parseCoinSelectionStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
 	minConfs := int32(ctx.Uint64("min_confs"))
 	txid, err := client.SendMany(ctxc, &lnrpc.SendManyRequest{
-		AddrToAmount:     amountToAddr,
-		TargetConf:       int32(ctx.Int64("conf_target")),
-		SatPerVbyte:      ctx.Uint64(feeRateFlag),
-		Label:            ctx.String(txLabelFlag.Name),
-		MinConfs:         minConfs,
-		SpendUnconfirmed: minConfs == 0,
+		AddrToAmount:          amountToAddr,
+		TargetConf:            int32(ctx.Int64("conf_target")),
+		SatPerVbyte:           ctx.Uint64(feeRateFlag),
+		Label:                 ctx.String(txLabelFlag.Name),
+		MinConfs:              minConfs,
+		SpendUnconfirmed:      minConfs == 0,
+		CoinSelectionStrategy: coinSelectionStrategy,
 	})
 	if err != nil {
 		return err
@@ -704,7 +747,9 @@ var closeChannelCommand = cli.Command{
 	In the case of a cooperative closure, one can manually set the fee to
 	be used for the closing transaction via either the --conf_target or
 	--sat_per_vbyte arguments. This will be the starting value used during
-	fee negotiation. This is optional.
+	fee negotiation. This is optional. If we're the initiator of the
+	channel, the highest fee we're willing to pay during negotiation can be
+	capped via the --max_fee_rate argument.
 
 	In the case of a cooperative closure, one can manually set the address
 	to deliver funds to upon closure. This is optional, and may only be used
@@ -765,6 +810,13 @@ var closeChannelCommand = cli.Command{
 				"be used if an upfront shutdown address is not " +
 				"already set",
 		},
+		cli.Uint64Flag{
+			Name: "max_fee_rate",
+			Usage: "(optional) maximum fee rate in sat/vbyte " +
+				"accepted during the negotiation (default is " +
+				"3 times the initial fee rate); only " +
+				"respected if we're the channel initiator",
+		},
 	},
 	Action: actionDecorator(closeChannel),
 }
@@ -801,6 +853,7 @@ func closeChannel(ctx *cli.Context) error {
 		TargetConf:      int32(ctx.Int64("conf_target")),
 		SatPerVbyte:     ctx.Uint64(feeRateFlag),
 		DeliveryAddress: ctx.String("delivery_addr"),
+		MaxFeePerVbyte:  ctx.Uint64("max_fee_rate"),
 	}
 
 	// After parsing the request, we'll spin up a goroutine that will
@@ -1522,6 +1575,11 @@ var describeGraphCommand = cli.Command{
 				"graph. Unannounced channels are both private channels, and " +
 				"public channels that are not yet announced to the network.",
 		},
+		cli.BoolFlag{
+			Name: "dot",
+			Usage: "If set, the graph will be printed in the Graphviz " +
+				"DOT format instead of JSON.",
+		},
 	},
 	Action: actionDecorator(describeGraph),
 }
@@ -1540,10 +1598,40 @@ func describeGraph(ctx *cli.Context) error {
 		return err
 	}
 
+	if ctx.Bool("dot") {
+		fmt.Print(graphToDOT(graph))
+		return nil
+	}
+
 	printRespJSON(graph)
 	return nil
 }
 
+// graphToDOT renders the passed channel graph in the Graphviz DOT format. Each
+// node is labeled with its alias, and each channel is an undirected edge
+// labeled with its short channel ID and capacity.
+func graphToDOT(graph *lnrpc.ChannelGraph) string {
+	var b strings.Builder
+
+	b.WriteString("graph lightning {\n")
+	for _, node := range graph.Nodes {
+		alias := node.Alias
+		if alias == "" {
+			alias = node.PubKey
+		}
+
+		fmt.Fprintf(&b, "\t%q [label=%q];\n", node.PubKey, alias)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "\t%q -- %q [label=\"%d (%d sat)\"];\n",
+			edge.Node1Pub, edge.Node2Pub, edge.ChannelId,
+			edge.Capacity)
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
 var getNodeMetricsCommand = cli.Command{
 	Name:        "getnodemetrics",
 	Category:    "Graph",
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,3 +69,29 @@ func TestParseChanPoint(t *testing.T) {
 		}
 	}
 }
+
+// TestGraphToDOT tests that a channel graph is rendered in the expected DOT
+// format.
+func TestGraphToDOT(t *testing.T) {
+	graph := &lnrpc.ChannelGraph{
+		Nodes: []*lnrpc.LightningNode{
+			{PubKey: "02aa", Alias: "alice"},
+			{PubKey: "03bb"},
+		},
+		Edges: []*lnrpc.ChannelEdge{
+			{
+				ChannelId: 123,
+				Node1Pub:  "02aa",
+				Node2Pub:  "03bb",
+				Capacity:  100000,
+			},
+		},
+	}
+
+	expected := "graph lightning {\n" +
+		"\t\"02aa\" [label=\"alice\"];\n" +
+		"\t\"03bb\" [label=\"03bb\"];\n" +
+		"\t\"02aa\" -- \"03bb\" [label=\"123 (100000 sat)\"];\n" +
+		"}\n"
+	require.Equal(t, expected, graphToDOT(graph))
+}
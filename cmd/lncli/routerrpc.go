@@ -10,6 +10,7 @@ func routerCommands() []cli.Command {
 		queryProbCommand,
 		resetMissionControlCommand,
 		buildRouteCommand,
+		estimateRouteFeeCommand,
 		getCfgCommand,
 		setCfgCommand,
 		updateChanStatusCommand,
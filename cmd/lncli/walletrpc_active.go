@@ -15,7 +15,9 @@ import (
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/urfave/cli"
 )
 
@@ -28,6 +30,7 @@ var (
 			"(PSBTs).",
 		Subcommands: []cli.Command{
 			fundPsbtCommand,
+			signPsbtCommand,
 			finalizePsbtCommand,
 		},
 	}
@@ -61,9 +64,12 @@ func walletCommands() []cli.Command {
 				listSweepsCommand,
 				labelTxCommand,
 				publishTxCommand,
+				sendOutputsCommand,
 				releaseOutputCommand,
 				leaseOutputCommand,
 				listLeasesCommand,
+				listWalletUnspentCommand,
+				consolidateUtxosCommand,
 				psbtCommand,
 				accountsCommand,
 			},
@@ -332,6 +338,7 @@ func bumpCloseFee(ctx *cli.Context) error {
 		commitSet[commitments.RemotePendingTxid] = struct{}{}
 	}
 
+	var numBumped int
 	for _, sweep := range sweeps.PendingSweeps {
 		// Only bump anchor sweeps.
 		if sweep.WitnessType != walletrpc.WitnessType_COMMITMENT_ANCHOR {
@@ -360,6 +367,16 @@ func bumpCloseFee(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
+
+		numBumped++
+	}
+
+	// Let the user know if there was nothing to bump, which happens if
+	// the channel doesn't have anchors or the anchors aren't being swept
+	// by the sweeper.
+	if numBumped == 0 {
+		return fmt.Errorf("no pending anchor sweeps found for channel "+
+			"%v", channelPoint)
 	}
 
 	return nil
@@ -544,6 +561,204 @@ func publishTransaction(ctx *cli.Context) error {
 	return nil
 }
 
+var sendOutputsCommand = cli.Command{
+	Name:      "sendoutputs",
+	Usage:     "Create, sign and publish a transaction to raw outputs.",
+	ArgsUsage: "--outputs=O --sat_per_vbyte=S [--label=L]",
+	Description: `
+	Funds a transaction that creates exactly the given outputs from the
+	wallet's coins, signs it and publishes it to the network. A change
+	output is added if necessary.
+
+	The 'outputs' flag decodes hex encoded output scripts and the amount
+	to send to each of them:
+	    --outputs='[{"pk_script": "ExampleScriptHex", "value": Sats}]'
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "outputs",
+			Usage: "a JSON list of the output scripts and the " +
+				"amounts to send to them",
+		},
+		cli.Uint64Flag{
+			Name: "sat_per_vbyte",
+			Usage: "a manual fee expressed in sat/vbyte that " +
+				"should be used when creating the transaction",
+		},
+		cli.Int64Flag{
+			Name: "min_confs",
+			Usage: "the minimum number of confirmations each " +
+				"input must have",
+			Value: defaultUtxoMinConf,
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "(optional) transaction label",
+		},
+		coinSelectionStrategyFlag,
+	},
+	Action: actionDecorator(sendOutputs),
+}
+
+// rawOutput is the JSON representation of an output to create with the
+// sendoutputs command.
+type rawOutput struct {
+	PkScript string `json:"pk_script"`
+	Value    int64  `json:"value"`
+}
+
+func sendOutputs(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 0 || !ctx.IsSet("outputs") ||
+		!ctx.IsSet("sat_per_vbyte") {
+
+		return cli.ShowCommandHelp(ctx, "sendoutputs")
+	}
+
+	var rawOutputs []rawOutput
+	err := json.Unmarshal([]byte(ctx.String("outputs")), &rawOutputs)
+	if err != nil {
+		return fmt.Errorf("error parsing outputs JSON: %v", err)
+	}
+
+	outputs := make([]*signrpc.TxOut, 0, len(rawOutputs))
+	for _, output := range rawOutputs {
+		pkScript, err := hex.DecodeString(output.PkScript)
+		if err != nil {
+			return fmt.Errorf("error parsing output script %v: %v",
+				output.PkScript, err)
+		}
+
+		outputs = append(outputs, &signrpc.TxOut{
+			PkScript: pkScript,
+			Value:    output.Value,
+		})
+	}
+
+	coinSelectionStrategy, err := parseCoinSelectionStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
+	minConfs := int32(ctx.Int64("min_confs"))
+	feeRate := chainfee.SatPerKVByte(ctx.Uint64("sat_per_vbyte") * 1000)
+	req := &walletrpc.SendOutputsRequest{
+		SatPerKw:              int64(feeRate.FeePerKWeight()),
+		Outputs:               outputs,
+		Label:                 ctx.String("label"),
+		MinConfs:              minConfs,
+		SpendUnconfirmed:      minConfs == 0,
+		CoinSelectionStrategy: coinSelectionStrategy,
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := walletClient.SendOutputs(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(resp.RawTx)); err != nil {
+		return err
+	}
+
+	printJSON(&struct {
+		TXID  string `json:"txid"`
+		RawTx string `json:"raw_tx"`
+	}{
+		TXID:  tx.TxHash().String(),
+		RawTx: hex.EncodeToString(resp.RawTx),
+	})
+
+	return nil
+}
+
+var consolidateUtxosCommand = cli.Command{
+	Name:  "consolidate",
+	Usage: "Consolidate small wallet utxos into a single output.",
+	Description: `
+	Creates, signs and publishes a transaction that consolidates the small
+	utxos of the default wallet account into a single new output of the
+	wallet. Only confirmed utxos with a value of at most max_utxo_value
+	that are worth spending at the chosen fee rate are consolidated. If
+	less than min_utxos of them qualify, no transaction is created.
+
+	A fee preference can be provided, either through the conf_target or
+	sat_per_vbyte parameters. If neither is set, a target of 6 blocks is
+	used. The defaults of max_utxo_value and min_utxos are taken from the
+	consolidation section of lnd's config.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks that the consolidation " +
+				"transaction should confirm in, will be used " +
+				"for fee estimation",
+		},
+		cli.Uint64Flag{
+			Name: "sat_per_vbyte",
+			Usage: "a manual fee expressed in sat/vbyte that " +
+				"should be used for the consolidation",
+		},
+		cli.Int64Flag{
+			Name: "max_utxo_value",
+			Usage: "the maximum value in satoshis of the utxos " +
+				"to consolidate",
+		},
+		cli.Uint64Flag{
+			Name: "min_utxos",
+			Usage: "the minimum number of utxos that need to " +
+				"qualify for the consolidation",
+		},
+	},
+	Action: actionDecorator(consolidateUtxos),
+}
+
+func consolidateUtxos(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 0 {
+		return cli.ShowCommandHelp(ctx, "consolidate")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := walletClient.ConsolidateUtxos(
+		ctxc, &walletrpc.ConsolidateUtxosRequest{
+			TargetConf:      uint32(ctx.Uint64("conf_target")),
+			SatPerVbyte:     ctx.Uint64("sat_per_vbyte"),
+			MaxUtxoValueSat: ctx.Int64("max_utxo_value"),
+			MinUtxos:        uint32(ctx.Uint64("min_utxos")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(resp.RawTx)); err != nil {
+		return err
+	}
+
+	printJSON(&struct {
+		TXID     string `json:"txid"`
+		NumUtxos int    `json:"num_utxos"`
+	}{
+		TXID:     tx.TxHash().String(),
+		NumUtxos: len(tx.TxIn),
+	})
+
+	return nil
+}
+
 // utxoLease contains JSON annotations for a lease on an unspent output.
 type utxoLease struct {
 	ID         string   `json:"id"`
@@ -623,6 +838,7 @@ var fundPsbtCommand = cli.Command{
 			Usage: "(optional) the name of the account to use to " +
 				"create/fund the PSBT",
 		},
+		coinSelectionStrategyFlag,
 	},
 	Action: actionDecorator(fundPsbt),
 }
@@ -636,8 +852,14 @@ func fundPsbt(ctx *cli.Context) error {
 		return cli.ShowCommandHelp(ctx, "fund")
 	}
 
+	coinSelectionStrategy, err := parseCoinSelectionStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &walletrpc.FundPsbtRequest{
-		Account: ctx.String("account"),
+		Account:               ctx.String("account"),
+		CoinSelectionStrategy: coinSelectionStrategy,
 	}
 
 	// Parse template flags.
@@ -764,6 +986,82 @@ func marshallLocks(lockedUtxos []*walletrpc.UtxoLease) []*utxoLease {
 	return jsonLocks
 }
 
+// signPsbtResponse is a struct that contains JSON annotations for nice result
+// serialization.
+type signPsbtResponse struct {
+	Psbt string `json:"psbt"`
+}
+
+var signPsbtCommand = cli.Command{
+	Name:      "sign",
+	Usage:     "Sign a Partially Signed Bitcoin Transaction (PSBT).",
+	ArgsUsage: "funded_psbt",
+	Description: `
+	The sign command expects a partial transaction with all inputs and
+	outputs fully declared and tries to sign all unsigned inputs that have
+	all required fields (UTXO information, BIP32 derivation information,
+	witness or sig scripts) set. Inputs that can't be signed by the wallet
+	are skipped.
+
+	Unlike finalize, this doesn't require lnd to be the last signer, so the
+	signed PSBT can be passed on to the next signer, for example a
+	hardware wallet or an external coordinator. The inputs are not
+	finalized.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funded_psbt",
+			Usage: "the base64 encoded PSBT to sign",
+		},
+	},
+	Action: actionDecorator(signPsbt),
+}
+
+func signPsbt(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() > 1 || ctx.NumFlags() > 1 {
+		return cli.ShowCommandHelp(ctx, "sign")
+	}
+
+	var (
+		args       = ctx.Args()
+		psbtBase64 string
+	)
+	switch {
+	case ctx.IsSet("funded_psbt"):
+		psbtBase64 = ctx.String("funded_psbt")
+	case args.Present():
+		psbtBase64 = args.First()
+	default:
+		return fmt.Errorf("funded_psbt argument missing")
+	}
+
+	psbtBytes, err := base64.StdEncoding.DecodeString(psbtBase64)
+	if err != nil {
+		return err
+	}
+	req := &walletrpc.SignPsbtRequest{
+		FundedPsbt: psbtBytes,
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.SignPsbt(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(&signPsbtResponse{
+		Psbt: base64.StdEncoding.EncodeToString(response.SignedPsbt),
+	})
+
+	return nil
+}
+
 // finalizePsbtResponse is a struct that contains JSON annotations for nice
 // result serialization.
 type finalizePsbtResponse struct {
@@ -1025,6 +1323,75 @@ func listLeases(ctx *cli.Context) error {
 	return nil
 }
 
+var listWalletUnspentCommand = cli.Command{
+	Name:  "listunspent",
+	Usage: "List the wallet's utxos, optionally including leased ones.",
+	Description: `
+	Lists the unspent outputs of the wallet with at least min_confs and at
+	most max_confs confirmations. Use --min_confs=0 to include unconfirmed
+	coins and omit --max_confs to list all coins with at least min_confs
+	confirmations. Outputs that are currently leased are only included if
+	--include_leased is set.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "min_confs",
+			Usage: "the minimum number of confirmations for a utxo",
+		},
+		cli.Int64Flag{
+			Name:  "max_confs",
+			Usage: "the maximum number of confirmations for a utxo",
+		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) only list the utxos of the given " +
+				"account",
+		},
+		cli.BoolFlag{
+			Name:  "include_leased",
+			Usage: "also list the utxos that are currently leased",
+		},
+	},
+	Action: actionDecorator(listWalletUnspent),
+}
+
+func listWalletUnspent(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we got any arguments.
+	if ctx.NArg() > 0 {
+		return cli.ShowCommandHelp(ctx, "listunspent")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	req := &walletrpc.ListUnspentRequest{
+		MinConfs:      int32(ctx.Int64("min_confs")),
+		MaxConfs:      int32(ctx.Int64("max_confs")),
+		Account:       ctx.String("account"),
+		IncludeLeased: ctx.Bool("include_leased"),
+	}
+	resp, err := walletClient.ListUnspent(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	var listUnspentResp = struct {
+		Utxos []*Utxo `json:"utxos"`
+	}{
+		Utxos: make([]*Utxo, 0, len(resp.Utxos)),
+	}
+	for _, protoUtxo := range resp.Utxos {
+		utxo := NewUtxoFromProto(protoUtxo)
+		listUnspentResp.Utxos = append(listUnspentResp.Utxos, utxo)
+	}
+
+	printJSON(listUnspentResp)
+
+	return nil
+}
+
 var listAccountsCommand = cli.Command{
 	Name:  "list",
 	Usage: "Retrieve information of existing on-chain wallet accounts.",
@@ -1182,9 +1549,16 @@ var importPubKeyCommand = cli.Command{
 	The address type must be one of the following: np2wkh, p2wkh.
 
 	NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	they happen after the import. Rescans to detect past events will be
-	supported later on.
+	they happen after the import, unless --rescan_start_height is set to
+	rescan the chain from that height in the background.
 	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "rescan_start_height",
+			Usage: "(optional) the block height to rescan the " +
+				"chain from to detect past events of the key",
+		},
+	},
 	Action: actionDecorator(importPubKey),
 }
 
@@ -1193,7 +1567,7 @@ func importPubKey(ctx *cli.Context) error {
 
 	// Display the command's help message if we do not have the expected
 	// number of arguments/flags.
-	if ctx.NArg() != 2 || ctx.NumFlags() > 0 {
+	if ctx.NArg() != 2 || ctx.NumFlags() > 1 {
 		return cli.ShowCommandHelp(ctx, "import-pubkey")
 	}
 
@@ -1210,8 +1584,9 @@ func importPubKey(ctx *cli.Context) error {
 	defer cleanUp()
 
 	req := &walletrpc.ImportPublicKeyRequest{
-		PublicKey:   pubKeyBytes,
-		AddressType: addrType,
+		PublicKey:         pubKeyBytes,
+		AddressType:       addrType,
+		RescanStartHeight: uint32(ctx.Uint64("rescan_start_height")),
 	}
 	resp, err := walletClient.ImportPublicKey(ctxc, req)
 	if err != nil {
@@ -38,7 +38,9 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/tor"
 )
@@ -168,6 +170,10 @@ const (
 	// TODO(halseth): find a more scientific choice of value.
 	defaultMaxLocalCSVDelay = 10000
 
+	// defaultReservationTimeout is the default amount of time a pending
+	// funding flow may sit idle before its reservation is canceled.
+	defaultReservationTimeout = 10 * time.Minute
+
 	// defaultChannelCommitInterval is the default maximum time between
 	// receiving a channel state update and signing a new commitment.
 	defaultChannelCommitInterval = 50 * time.Millisecond
@@ -237,6 +243,11 @@ var (
 	bitcoindEstimateModes       = [2]string{"ECONOMICAL", defaultBitcoindEstimateMode}
 
 	defaultPrunedNodeMaxPeers = 4
+
+	defaultZMQReadDeadline = 5 * time.Second
+
+	defaultNeutrinoMaxPeers    = 8
+	defaultNeutrinoBanDuration = time.Hour * 48
 )
 
 // Config defines the configuration options for lnd.
@@ -348,6 +359,8 @@ type Config struct {
 	Color                         string        `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize                   int64         `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
 	MaxChanSize                   int64         `long:"maxchansize" description:"The largest channel size (in satoshis) that we should accept. Incoming channels larger than this will be rejected"`
+	MinOutboundChanSize           int64         `long:"minoutboundchansize" description:"The smallest channel size (in satoshis) that we should open. Outgoing channels smaller than this will be rejected. If unset, only the protocol minimum applies"`
+	MaxOutboundChanSize           int64         `long:"maxoutboundchansize" description:"The largest channel size (in satoshis) that we should open. Outgoing channels larger than this will be rejected. If unset, only the protocol maximum applies"`
 	CoopCloseTargetConfs          uint32        `long:"coop-close-target-confs" description:"The target number of blocks that a cooperative channel close transaction should confirm in. This is used to estimate the fee to use as the lower bound during fee negotiation for the channel closure."`
 
 	ChannelCommitInterval time.Duration `long:"channel-commit-interval" description:"The maximum time that is allowed to pass between receiving a channel state update and signing the next commitment. Setting this to a longer duration allows for more efficient channel operations at the cost of latency."`
@@ -363,8 +376,19 @@ type Config struct {
 
 	IgnoreHistoricalGossipFilters bool `long:"ignore-historical-gossip-filters" description:"If true, will not reply with historical data that matches the range specified by a remote peer's gossip_timestamp_filter. Doing so will result in lower memory and bandwidth requirements."`
 
+	ReservationTimeout time.Duration `long:"funding-reservation-timeout" description:"The amount of time a pending channel funding flow may sit idle, waiting for the remote peer, before its reservation is canceled and any coins locked for it are released."`
+
+	MaxFundingConfBlocks uint32 `long:"funding-conf-timeout-blocks" description:"The number of blocks to wait for the funding transaction of a channel opened by a remote peer to confirm before forgetting the channel."`
+
 	RejectPush bool `long:"rejectpush" description:"If true, lnd will not accept channel opening requests with non-zero push amounts. This should prevent accidental pushes to merchant nodes."`
 
+	RejectUnknownPeers bool `long:"rejectunknownpeers" description:"If true, lnd will only accept channel opening requests from peers it already has an open channel with, or that are listed with --allowedpeer."`
+
+	AllowedPeersRaw []string `long:"allowedpeer" description:"The hex-encoded pubkey of a peer that may always open channels to us when --rejectunknownpeers is set. Can be specified multiple times."`
+
+	// AllowedPeers is the parsed set of AllowedPeersRaw.
+	AllowedPeers map[route.Vertex]struct{}
+
 	RejectHTLC bool `long:"rejecthtlc" description:"If true, lnd will not forward any HTLCs that are meant as onward payments. This option will still allow lnd to send HTLCs and receive HTLCs but lnd won't be used as a hop."`
 
 	// RequireInterceptor determines whether the HTLC interceptor is
@@ -397,12 +421,16 @@ type Config struct {
 
 	DustThreshold uint64 `long:"dust-threshold" description:"Sets the dust sum threshold in satoshis for a channel after which dust HTLC's will be failed."`
 
+	MaxMailboxAdds int `long:"max-mailbox-adds" description:"The maximum number of HTLC adds that may be queued for a channel whose peer isn't keeping up, after which further forwards over the channel will be failed. Setting this value to 0 disables the limit."`
+
 	Invoices *lncfg.Invoices `group:"invoices" namespace:"invoices"`
 
 	Routing *lncfg.Routing `group:"routing" namespace:"routing"`
 
 	Gossip *lncfg.Gossip `group:"gossip" namespace:"gossip"`
 
+	Consolidation *lncfg.Consolidation `group:"consolidation" namespace:"consolidation"`
+
 	Workers *lncfg.Workers `group:"workers" namespace:"workers"`
 
 	Caches *lncfg.Caches `group:"caches" namespace:"caches"`
@@ -481,6 +509,7 @@ func DefaultConfig() Config {
 			RPCHost:            defaultRPCHost,
 			EstimateMode:       defaultBitcoindEstimateMode,
 			PrunedNodeMaxPeers: defaultPrunedNodeMaxPeers,
+			ZMQReadDeadline:    defaultZMQReadDeadline,
 		},
 		Litecoin: &lncfg.Chain{
 			MinHTLCIn:     chainreg.DefaultLitecoinMinHTLCInMSat,
@@ -501,8 +530,12 @@ func DefaultConfig() Config {
 			RPCHost:            defaultRPCHost,
 			EstimateMode:       defaultBitcoindEstimateMode,
 			PrunedNodeMaxPeers: defaultPrunedNodeMaxPeers,
+			ZMQReadDeadline:    defaultZMQReadDeadline,
 		},
 		NeutrinoMode: &lncfg.Neutrino{
+			MaxPeers:         defaultNeutrinoMaxPeers,
+			BanDuration:      defaultNeutrinoBanDuration,
+			BanThreshold:     neutrino.BanThreshold,
 			UserAgentName:    neutrino.UserAgentName,
 			UserAgentVersion: neutrino.UserAgentVersion,
 		},
@@ -557,6 +590,10 @@ func DefaultConfig() Config {
 			RejectCacheSize:  channeldb.DefaultRejectCacheSize,
 			ChannelCacheSize: channeldb.DefaultChannelCacheSize,
 		},
+		Routing: &lncfg.Routing{
+			ChannelPruneExpiry: routing.DefaultChannelPruneExpiry,
+			GraphPruneInterval: routing.DefaultGraphPruneInterval,
+		},
 		Prometheus: lncfg.DefaultPrometheus(),
 		Watchtower: &lncfg.Watchtower{
 			TowerDir: defaultTowerDir,
@@ -597,16 +634,25 @@ func DefaultConfig() Config {
 			},
 		},
 		Gossip: &lncfg.Gossip{
-			MaxChannelUpdateBurst: discovery.DefaultMaxChannelUpdateBurst,
-			ChannelUpdateInterval: discovery.DefaultChannelUpdateInterval,
+			MaxChannelUpdateBurst:     discovery.DefaultMaxChannelUpdateBurst,
+			ChannelUpdateInterval:     discovery.DefaultChannelUpdateInterval,
+			PeerChannelUpdateInterval: discovery.DefaultPeerChannelUpdateInterval,
 		},
 		Invoices: &lncfg.Invoices{
 			HoldExpiryDelta: lncfg.DefaultHoldInvoiceExpiryDelta,
 		},
+		Consolidation: &lncfg.Consolidation{
+			Interval:     lncfg.DefaultConsolidationInterval,
+			ConfTarget:   lncfg.DefaultConsolidationConfTarget,
+			MaxFeeRate:   lncfg.DefaultConsolidationMaxFeeRate,
+			MaxUtxoValue: lncfg.DefaultConsolidationMaxUtxoValue,
+			MinUtxos:     lncfg.DefaultConsolidationMinUtxos,
+		},
 		MaxOutgoingCltvExpiry:   htlcswitch.DefaultMaxOutgoingCltvExpiry,
 		MaxChannelFeeAllocation: htlcswitch.DefaultMaxLinkFeeAllocation,
 		MaxCommitFeeRateAnchors: lnwallet.DefaultAnchorsCommitMaxFeeRateSatPerVByte,
 		DustThreshold:           uint64(htlcswitch.DefaultDustThreshold.ToSatoshis()),
+		MaxMailboxAdds:          htlcswitch.DefaultMaxMailboxAdds,
 		LogWriter:               build.NewRotatingLogWriter(),
 		DB:                      lncfg.DefaultDB(),
 		Cluster:                 lncfg.DefaultCluster(),
@@ -614,6 +660,8 @@ func DefaultConfig() Config {
 		registeredChains:        chainreg.NewChainRegistry(),
 		ActiveNetParams:         chainreg.BitcoinTestNetParams,
 		ChannelCommitInterval:   defaultChannelCommitInterval,
+		ReservationTimeout:      defaultReservationTimeout,
+		MaxFundingConfBlocks:    funding.DefaultMaxWaitNumBlocksFundingConf,
 		PendingCommitInterval:   defaultPendingCommitInterval,
 		ChannelCommitBatchSize:  defaultChannelCommitBatchSize,
 		CoinSelectionStrategy:   defaultCoinSelectionStrategy,
@@ -922,6 +970,25 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		)
 	}
 
+	// Likewise, ensure that the optional limits for the channels that we
+	// open ourselves make sense.
+	if cfg.MinOutboundChanSize < 0 || cfg.MaxOutboundChanSize < 0 {
+		return nil, mkErr("invalid channel size parameters: "+
+			"outbound channel sizes must not be negative, got "+
+			"min %v and max %v", cfg.MinOutboundChanSize,
+			cfg.MaxOutboundChanSize,
+		)
+	}
+	if cfg.MaxOutboundChanSize != 0 &&
+		cfg.MaxOutboundChanSize < cfg.MinOutboundChanSize {
+
+		return nil, mkErr("invalid channel size parameters: "+
+			"max outbound channel size %v, must be no less than "+
+			"min outbound chan size %v", cfg.MaxOutboundChanSize,
+			cfg.MinOutboundChanSize,
+		)
+	}
+
 	// Don't allow superfluous --maxchansize greater than
 	// BOLT 02 soft-limit for non-wumbo channel
 	if !cfg.ProtocolOptions.Wumbo() &&
@@ -1261,16 +1328,35 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 					"credentials for bitcoind: %v", err)
 			}
 		case "neutrino":
-			// No need to get RPC parameters.
+			// No need to get RPC parameters, but we'll make sure
+			// the peer limits of the light client are sane.
+			if cfg.NeutrinoMode.MaxPeers <= 0 {
+				return nil, mkErr("neutrino.maxpeers must be " +
+					"positive")
+			}
+			if cfg.NeutrinoMode.BanDuration < time.Second {
+				return nil, mkErr("neutrino.banduration must " +
+					"be at least 1 second")
+			}
+			if cfg.NeutrinoMode.BanThreshold == 0 {
+				return nil, mkErr("neutrino.banthreshold " +
+					"must be positive")
+			}
 
 		case "nochainbackend":
 			// Nothing to configure, we're running without any chain
 			// backend whatsoever (pure signing mode).
 
 		default:
-			str := "only btcd, bitcoind, and neutrino mode " +
-				"supported for bitcoin at this time"
-			return nil, mkErr(str)
+			// Any other backend must have been registered with
+			// the chain registry, and its driver is responsible
+			// for validating the config it needs.
+			if !chainreg.IsRegisteredBackend(cfg.Bitcoin.Node) {
+				str := "only btcd, bitcoind, and neutrino " +
+					"mode supported for bitcoin at this " +
+					"time"
+				return nil, mkErr(str)
+			}
 		}
 
 		cfg.Bitcoin.ChainDir = filepath.Join(
@@ -1584,6 +1670,17 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			maxRemoteHtlcs)
 	}
 
+	// Make sure pending funding flows and unconfirmed channels eventually
+	// time out.
+	if cfg.ReservationTimeout <= 0 {
+		return nil, mkErr("funding-reservation-timeout (%v) must be "+
+			"positive", cfg.ReservationTimeout)
+	}
+	if cfg.MaxFundingConfBlocks == 0 {
+		return nil, mkErr("funding-conf-timeout-blocks must be " +
+			"positive")
+	}
+
 	// Clamp the ChannelCommitInterval so that commitment updates can still
 	// happen in a reasonable timeframe.
 	if cfg.ChannelCommitInterval > maxChannelCommitInterval {
@@ -1600,10 +1697,26 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			maxPendingCommitInterval)
 	}
 
+	// Parse the pubkeys of the peers that may open channels to us even
+	// though we don't have a channel with them yet.
+	cfg.AllowedPeers = make(map[route.Vertex]struct{})
+	for _, pubkeyStr := range cfg.AllowedPeersRaw {
+		vertex, err := route.NewVertexFromStr(pubkeyStr)
+		if err != nil {
+			return nil, mkErr("invalid allowedpeer %v: %v",
+				pubkeyStr, err)
+		}
+		cfg.AllowedPeers[vertex] = struct{}{}
+	}
+
 	if err := cfg.Gossip.Parse(); err != nil {
 		return nil, mkErr("error parsing gossip syncer: %v", err)
 	}
 
+	if err := cfg.Invoices.Parse(); err != nil {
+		return nil, mkErr("error parsing invoices config: %v", err)
+	}
+
 	// Log a warning if our expiry delta is not greater than our incoming
 	// broadcast delta. We do not fail here because this value may be set
 	// to zero to intentionally keep lnd's behavior unchanged from when we
@@ -1626,6 +1739,9 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		cfg.HealthChecks,
 		cfg.RPCMiddleware,
 		cfg.RemoteSigner,
+		cfg.Routing,
+		cfg.Consolidation,
+		cfg.SubRPCServers.RouterRPC,
 	)
 	if err != nil {
 		return nil, err
@@ -1639,6 +1755,12 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		return nil, mkErr("unable to parse node color: %v", err)
 	}
 
+	// Likewise, make sure the node alias fits into a node announcement so
+	// we catch an invalid alias before the wallet is unlocked.
+	if _, err := lnwire.NewNodeAlias(cfg.Alias); err != nil {
+		return nil, mkErr("invalid node alias: %v", err)
+	}
+
 	// All good, return the sanitized result.
 	return &cfg, nil
 }
@@ -1749,6 +1871,12 @@ func parseRPCParams(cConfig *lncfg.Chain, nodeConfig interface{},
 		}
 
 	case *lncfg.Bitcoind:
+		// A read deadline is required so that we notice a stalled ZMQ
+		// subscription.
+		if conf.ZMQReadDeadline <= 0 {
+			return fmt.Errorf("zmqreaddeadline must be positive")
+		}
+
 		// Ensure that if the ZMQ options are set, that they are not
 		// equal.
 		if conf.ZMQPubRawBlock != "" && conf.ZMQPubRawTx != "" {
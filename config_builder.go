@@ -31,6 +31,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwallet/rpcwallet"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/rpcperms"
@@ -591,6 +592,18 @@ func (d *DefaultWalletImpl) BuildWalletConfig(ctx context.Context,
 	return partialChainControl, walletConfig, cleanUp, nil
 }
 
+// fundingCoinSelectionStrategy returns the coin selection strategy for channel
+// funding that matches the on-chain wallet's coin selection strategy.
+func fundingCoinSelectionStrategy(
+	s wallet.CoinSelectionStrategy) chanfunding.CoinSelectionStrategy {
+
+	if s == wallet.CoinSelectionRandom {
+		return chanfunding.RandomCoinSelection{}
+	}
+
+	return chanfunding.LargestFirstCoinSelection{}
+}
+
 // BuildChainControl is responsible for creating a fully populated chain
 // control instance from a wallet.
 //
@@ -624,6 +637,9 @@ func (d *DefaultWalletImpl) BuildChainControl(
 		ChainIO:            walletController,
 		DefaultConstraints: partialChainControl.ChannelConstraints,
 		NetParams:          *walletConfig.NetParams,
+		CoinSelectionStrategy: fundingCoinSelectionStrategy(
+			walletConfig.CoinSelectionStrategy,
+		),
 	}
 
 	// We've created the wallet configuration now, so we can finish
@@ -712,6 +728,9 @@ func (d *RPCSignerWalletImpl) BuildChainControl(
 		ChainIO:            walletController,
 		DefaultConstraints: partialChainControl.ChannelConstraints,
 		NetParams:          *walletConfig.NetParams,
+		CoinSelectionStrategy: fundingCoinSelectionStrategy(
+			walletConfig.CoinSelectionStrategy,
+		),
 	}
 
 	// We've created the wallet configuration now, so we can finish
@@ -1261,8 +1280,9 @@ func initNeutrinoBackend(cfg *Config, chainDir string,
 		PersistToDisk:      cfg.NeutrinoMode.PersistFilters,
 	}
 
-	neutrino.MaxPeers = 8
-	neutrino.BanDuration = time.Hour * 48
+	neutrino.MaxPeers = cfg.NeutrinoMode.MaxPeers
+	neutrino.BanDuration = cfg.NeutrinoMode.BanDuration
+	neutrino.BanThreshold = cfg.NeutrinoMode.BanThreshold
 	neutrino.UserAgentName = cfg.NeutrinoMode.UserAgentName
 	neutrino.UserAgentVersion = cfg.NeutrinoMode.UserAgentVersion
 
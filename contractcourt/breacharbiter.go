@@ -569,12 +569,14 @@ func convertToSecondLevelRevoke(bo *breachedOutput, breachInfo *retributionInfo,
 
 // updateBreachInfo mutates the passed breachInfo by removing or converting any
 // outputs among the spends. It also counts the total and revoked funds swept
-// by our justice spends.
+// by our justice spends, and returns a resolver report for each output that
+// reached its terminal state.
 func updateBreachInfo(breachInfo *retributionInfo, spends []spend) (
-	btcutil.Amount, btcutil.Amount) {
+	btcutil.Amount, btcutil.Amount, []*channeldb.ResolverReport) {
 
 	inputs := breachInfo.breachedOutputs
 	doneOutputs := make(map[int]struct{})
+	var reports []*channeldb.ResolverReport
 
 	var totalFunds, revokedFunds btcutil.Amount
 	for _, s := range spends {
@@ -641,6 +643,15 @@ func updateBreachInfo(breachInfo *retributionInfo, spends []spend) (
 			breachedOutput.witnessType,
 			breachedOutput.outpoint, breachInfo.chanPoint)
 
+		spendTxID := *s.detail.SpenderTxHash
+		reports = append(reports, &channeldb.ResolverReport{
+			OutPoint:        breachedOutput.outpoint,
+			Amount:          breachedOutput.Amount(),
+			ResolverType:    channeldb.ResolverTypeBreach,
+			ResolverOutcome: channeldb.ResolverOutcomeClaimed,
+			SpendTxID:       &spendTxID,
+		})
+
 		doneOutputs[s.index] = struct{}{}
 	}
 
@@ -658,7 +669,27 @@ func updateBreachInfo(breachInfo *retributionInfo, spends []spend) (
 	// Update our remaining set of outputs before continuing with
 	// another attempt at publication.
 	breachInfo.breachedOutputs = inputs[:nextIndex]
-	return totalFunds, revokedFunds
+	return totalFunds, revokedFunds, reports
+}
+
+// putResolverReports persists the resolver reports of the outputs swept from
+// the breached commitment. Failing to do so is only logged, since it doesn't
+// affect the sweeping of the remaining outputs.
+func (b *BreachArbiter) putResolverReports(breachInfo *retributionInfo,
+	reports []*channeldb.ResolverReport) {
+
+	db := b.cfg.DB.GetParentDB()
+	for _, report := range reports {
+		err := db.PutResolverReport(
+			nil, breachInfo.chainHash, &breachInfo.chanPoint,
+			report,
+		)
+		if err != nil {
+			brarLog.Errorf("Unable to store resolver report for "+
+				"%v of ChannelPoint(%v): %v", report.OutPoint,
+				breachInfo.chanPoint, err)
+		}
+	}
 }
 
 // exactRetribution is a goroutine which is executed once a contract breach has
@@ -759,10 +790,14 @@ Loop:
 		select {
 		case spends := <-spendChan:
 			// Update the breach info with the new spends.
-			t, r := updateBreachInfo(breachInfo, spends)
+			t, r, reports := updateBreachInfo(breachInfo, spends)
 			totalFunds += t
 			revokedFunds += r
 
+			// Record the outputs we swept so that they are
+			// reported once the channel is closed.
+			b.putResolverReports(breachInfo, reports)
+
 			brarLog.Infof("%v spends from breach tx for "+
 				"ChannelPoint(%v) has been detected, %v "+
 				"revoked funds (%v total) have been claimed",
@@ -1318,14 +1353,22 @@ func (b *BreachArbiter) createJusticeTx(
 		return nil, err
 	}
 
+	// The split variants are only a fallback in case the spendAll tx
+	// doesn't confirm, so failing to create one of them, e.g. because the
+	// HTLC outputs alone don't cover the fee, must not prevent us from
+	// publishing the spendAll tx.
 	txs.spendCommitOuts, err = b.createSweepTx(commitInputs)
 	if err != nil {
-		return nil, err
+		brarLog.Warnf("Unable to create justice tx spending "+
+			"commitment outputs: %v", err)
+		txs.spendCommitOuts = nil
 	}
 
 	txs.spendHTLCs, err = b.createSweepTx(htlcInputs)
 	if err != nil {
-		return nil, err
+		brarLog.Warnf("Unable to create justice tx spending HTLC "+
+			"outputs: %v", err)
+		txs.spendHTLCs = nil
 	}
 
 	return txs, nil
@@ -1416,6 +1459,14 @@ func (b *BreachArbiter) sweepSpendableOutputsTxn(txWeight int64,
 	// TODO(roasbeef): already start to siphon their funds into fees
 	sweepAmt := int64(totalAmt - txFee)
 
+	// If the fee eats up our funds, or leaves us with a dust output, the
+	// transaction wouldn't be relayed, so there's no point in creating it.
+	dustLimit := lnwallet.DustLimitForSize(input.P2WPKHSize)
+	if sweepAmt < int64(dustLimit) {
+		return nil, fmt.Errorf("sweep amount of %v is below dust "+
+			"limit of %v", btcutil.Amount(sweepAmt), dustLimit)
+	}
+
 	// With the fee calculated, we can now create the transaction using the
 	// information gathered above and the provided retribution information.
 	txn := wire.NewMsgTx(2)
@@ -1271,6 +1271,63 @@ func TestBreachCreateJusticeTx(t *testing.T) {
 	require.Len(t, justiceTxs.spendHTLCs.TxIn, 3)
 }
 
+// TestBreachCreateJusticeTxDustHtlcs tests that we still create the justice tx
+// sweeping all outputs if the HTLC outputs alone can't pay for their own
+// sweep.
+func TestBreachCreateJusticeTxDustHtlcs(t *testing.T) {
+	brar, _, _, _, _, cleanUpChans, cleanUpArb := initBreachedState(t)
+	defer cleanUpChans()
+	defer cleanUpArb()
+
+	aliceKeyPriv, _ := btcec.PrivKeyFromBytes(
+		channels.AlicesPrivKey,
+	)
+	alicePubKey := aliceKeyPriv.PubKey()
+
+	commitSignDesc := breachedOutputs[0].signDesc
+	commitSignDesc.KeyDesc.PubKey = alicePubKey
+	commitSignDesc.DoubleTweak = aliceKeyPriv
+	commitSignDesc.Output = &wire.TxOut{
+		PkScript: commitSignDesc.Output.PkScript,
+		Value:    1_000_000,
+	}
+
+	// The HTLC outputs are too small to pay for a transaction sweeping
+	// only them.
+	htlcSignDesc := commitSignDesc
+	htlcSignDesc.Output = &wire.TxOut{
+		PkScript: commitSignDesc.Output.PkScript,
+		Value:    1_000,
+	}
+
+	outputTypes := []struct {
+		witnessType input.StandardWitnessType
+		signDesc    *input.SignDescriptor
+	}{
+		{input.CommitmentRevoke, &commitSignDesc},
+		{input.HtlcAcceptedRevoke, &htlcSignDesc},
+		{input.HtlcOfferedRevoke, &htlcSignDesc},
+	}
+
+	outputs := make([]breachedOutput, len(outputTypes))
+	for i, output := range outputTypes {
+		op := breachedOutputs[0].outpoint
+		op.Index = uint32(i)
+		outputs[i] = makeBreachedOutput(
+			&op, output.witnessType, nil, output.signDesc, 1,
+		)
+	}
+
+	justiceTxs, err := brar.createJusticeTx(outputs)
+	require.NoError(t, err)
+
+	// We expect the spendAll and spendCommitOuts txs to be created, but
+	// not the one sweeping only the HTLC outputs.
+	require.Len(t, justiceTxs.spendAll.TxIn, len(outputs))
+	require.Len(t, justiceTxs.spendCommitOuts.TxIn, 1)
+	require.Nil(t, justiceTxs.spendHTLCs)
+}
+
 type publAssertion func(*testing.T, map[wire.OutPoint]struct{},
 	chan *wire.MsgTx, chainhash.Hash) *wire.MsgTx
 
@@ -1806,6 +1863,32 @@ func testBreachSpends(t *testing.T, test breachTest) {
 
 	// Assert that the channel is fully resolved.
 	assertBrarCleanup(t, brar, alice.ChanPoint, alice.State().Db)
+
+	// Finally, the commitment outputs swept by the spend delivered above
+	// should have been recorded as resolved by the breach.
+	reports, err := alice.State().Db.GetParentDB().FetchChannelReports(
+		retribution.ChainHash, chanPoint,
+	)
+	require.NoError(t, err)
+
+	commitSpendTxID := spendTxs.commitSpendTx.TxHash()
+	reported := make(map[wire.OutPoint]*channeldb.ResolverReport)
+	for _, report := range reports {
+		require.Equal(
+			t, channeldb.ResolverTypeBreach, report.ResolverType,
+		)
+		require.Equal(
+			t, channeldb.ResolverOutcomeClaimed,
+			report.ResolverOutcome,
+		)
+		reported[report.OutPoint] = report
+	}
+
+	for _, op := range []wire.OutPoint{localOutpoint, remoteOutpoint} {
+		report, ok := reported[op]
+		require.True(t, ok, "no report for %v", op)
+		require.Equal(t, commitSpendTxID, *report.SpendTxID)
+	}
 }
 
 // TestBreachDelayedJusticeConfirmation tests that the breach arbiter will
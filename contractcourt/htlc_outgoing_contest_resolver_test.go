@@ -40,6 +40,25 @@ func TestHtlcOutgoingResolverTimeout(t *testing.T) {
 	ctx.waitForResult(true)
 }
 
+// TestHtlcOutgoingResolverStop tests that stopping an outgoing contest resolver
+// that is still waiting for the htlc to expire signals a shutdown rather than
+// a failure to resolve.
+func TestHtlcOutgoingResolverStop(t *testing.T) {
+	t.Parallel()
+	defer timeout(t)()
+
+	ctx := newOutgoingResolverTestContext(t)
+	ctx.resolve()
+
+	ctx.resolver.Stop()
+
+	result := <-ctx.resolverResultChan
+	if result.err != errResolverShuttingDown {
+		t.Fatalf("expected %v, got %v", errResolverShuttingDown,
+			result.err)
+	}
+}
+
 // TestHtlcOutgoingResolverRemoteClaim tests resolution of an offered htlc that
 // is claimed by the remote party.
 func TestHtlcOutgoingResolverRemoteClaim(t *testing.T) {
@@ -529,13 +529,15 @@ func (h *htlcSuccessResolver) initReport() {
 		)
 	}
 
+	// The maturity height is left unset, as the CSV delay of the
+	// second-level output is relative to a confirmation height we don't
+	// know yet. It's populated once the success transaction confirms.
 	h.currentReport = ContractReport{
-		Outpoint:       h.htlcResolution.ClaimOutpoint,
-		Type:           ReportOutputIncomingHtlc,
-		Amount:         finalAmt,
-		MaturityHeight: h.htlcResolution.CsvDelay,
-		LimboBalance:   finalAmt,
-		Stage:          1,
+		Outpoint:     h.htlcResolution.ClaimOutpoint,
+		Type:         ReportOutputIncomingHtlc,
+		Amount:       finalAmt,
+		LimboBalance: finalAmt,
+		Stage:        1,
 	}
 }
 
@@ -612,3 +612,46 @@ func runFromCheckpoint(t *testing.T, ctx *htlcResolverTestContext,
 
 	return checkpointedState
 }
+
+// TestHtlcSuccessInitialReport tests that the initial report of a resolver for
+// an htlc on our commitment, that isn't handled by the nursery, doesn't use
+// the relative csv delay of the second-level output as an absolute maturity
+// height.
+func TestHtlcSuccessInitialReport(t *testing.T) {
+	t.Parallel()
+
+	successTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			{
+				Value: 123,
+			},
+		},
+	}
+	resolution := lnwallet.IncomingHtlcResolution{
+		SignedSuccessTx: successTx,
+		SignDetails:     &input.SignDetails{},
+		CsvDelay:        144,
+		ClaimOutpoint:   wire.OutPoint{Index: 3},
+		SweepSignDesc:   testSignDesc,
+	}
+
+	resolver := newSuccessResolver(
+		resolution, testInitialBlockHeight, channeldb.HTLC{
+			Amt: testHtlcAmt,
+		}, ResolverConfig{},
+	)
+
+	report := resolver.report()
+	if report.MaturityHeight != 0 {
+		t.Fatalf("expected unset maturity height, got %v",
+			report.MaturityHeight)
+	}
+	if report.Stage != 1 {
+		t.Fatalf("expected stage 1, got %v", report.Stage)
+	}
+	if report.LimboBalance != 123 {
+		t.Fatalf("expected limbo balance of 123, got %v",
+			report.LimboBalance)
+	}
+}
@@ -41,6 +41,12 @@ const (
 	// channel and direction.
 	DefaultChannelUpdateInterval = time.Minute
 
+	// DefaultPeerChannelUpdateInterval is the default interval we'll use
+	// to determine how often we should allow a new update for a channel
+	// we already know of from a specific peer, if per-peer rate limiting
+	// is enabled.
+	DefaultPeerChannelUpdateInterval = time.Millisecond * 10
+
 	// maxPrematureUpdates tracks the max amount of premature channel
 	// updates that we'll hold onto.
 	maxPrematureUpdates = 100
@@ -49,6 +55,25 @@ const (
 	// we'll maintain. This is the global size across all peers. We'll
 	// allocate ~3 MB max to the cache.
 	maxRejectedUpdates = 10_000
+
+	// initialRoutingDumpInterval is the minimum amount of time between two
+	// initial routing dumps we send to the same peer.
+	initialRoutingDumpInterval = 6 * time.Hour
+
+	// maxConcurrentRoutingDumps is the maximum number of initial routing
+	// dumps we send at the same time. Dumps requested while this many are
+	// in progress are skipped.
+	maxConcurrentRoutingDumps = 2
+
+	// routingDumpBatchSize is the number of messages we aim to read from
+	// the graph at once while sending an initial routing dump.
+	routingDumpBatchSize = 500
+
+	// routingDumpWindow is the initial length of the update horizon we
+	// read from the graph at once while sending an initial routing dump.
+	// It's adjusted while the dump is sent to keep the batches close to
+	// routingDumpBatchSize messages.
+	routingDumpWindow = 24 * time.Hour
 )
 
 var (
@@ -277,6 +302,16 @@ type Config struct {
 	// how often we should allow a new update for a specific channel and
 	// direction.
 	ChannelUpdateInterval time.Duration
+
+	// MaxPeerChannelUpdateBurst specifies the maximum number of updates
+	// for channels we already know of that we'll accept from a single peer
+	// over an interval. A value of zero disables per-peer rate limiting.
+	MaxPeerChannelUpdateBurst int
+
+	// PeerChannelUpdateInterval specifies the interval we'll use to
+	// determine how often we should allow a new update for a channel we
+	// already know of from a specific peer.
+	PeerChannelUpdateInterval time.Duration
 }
 
 // cachedNetworkMsg is a wrapper around a network message that can be used with
@@ -419,6 +454,27 @@ type AuthenticatedGossiper struct {
 	// AuthenticatedGossiper lock.
 	chanUpdateRateLimiter map[uint64][2]*rate.Limiter
 
+	// peerUpdateRateLimiter contains a rate limiter for each peer we've
+	// received channel updates from. We'll use these to bound the total
+	// number of updates a single peer can make us process, regardless of
+	// the channel they're for.
+	//
+	// NOTE: This map must be synchronized with the main
+	// AuthenticatedGossiper lock.
+	peerUpdateRateLimiter map[route.Vertex]*rate.Limiter
+
+	// lastRoutingDump tracks the last time we sent an initial routing
+	// dump to each peer, so that a reconnecting peer can't make us send
+	// our whole graph over and over.
+	//
+	// NOTE: This map must be synchronized with the main
+	// AuthenticatedGossiper lock.
+	lastRoutingDump map[route.Vertex]time.Time
+
+	// routingDumpSema bounds the number of initial routing dumps we send
+	// concurrently.
+	routingDumpSema chan struct{}
+
 	sync.Mutex
 }
 
@@ -437,6 +493,11 @@ func New(cfg Config, selfKeyDesc *keychain.KeyDescriptor) *AuthenticatedGossiper
 		channelMtx:              multimutex.NewMutex(),
 		recentRejects:           lru.NewCache(maxRejectedUpdates),
 		chanUpdateRateLimiter:   make(map[uint64][2]*rate.Limiter),
+		peerUpdateRateLimiter:   make(map[route.Vertex]*rate.Limiter),
+		lastRoutingDump:         make(map[route.Vertex]time.Time),
+		routingDumpSema: make(
+			chan struct{}, maxConcurrentRoutingDumps,
+		),
 	}
 
 	gossiper.syncMgr = newSyncManager(&SyncManagerCfg{
@@ -1326,11 +1387,210 @@ func (d *AuthenticatedGossiper) InitSyncState(syncPeer lnpeer.Peer) {
 	d.syncMgr.InitSyncState(syncPeer)
 }
 
+// SendInitialRoutingDump is called by outside sub-systems when a connection is
+// established to a peer that doesn't understand gossip queries, but requested
+// an initial routing sync through the initial_routing_sync feature bit. We'll
+// send it all known announcements from our graph in the background.
+//
+// As the dump is expensive, we send at most one per peer within
+// initialRoutingDumpInterval, and no more than maxConcurrentRoutingDumps at a
+// time. The graph is read in batches, which are subject to the same rate
+// limiting as our replies to gossip queries.
+func (d *AuthenticatedGossiper) SendInitialRoutingDump(peer lnpeer.Peer) {
+	pubKey := route.Vertex(peer.PubKey())
+
+	select {
+	case d.routingDumpSema <- struct{}{}:
+	default:
+		log.Infof("Skipping initial routing dump for peer=%x, too "+
+			"many dumps in progress", pubKey[:])
+		return
+	}
+
+	now := time.Now()
+
+	d.Lock()
+	lastDump, ok := d.lastRoutingDump[pubKey]
+	if ok && now.Sub(lastDump) < initialRoutingDumpInterval {
+		d.Unlock()
+		<-d.routingDumpSema
+
+		log.Infof("Skipping initial routing dump for peer=%x, last "+
+			"dump was sent at %v", pubKey[:], lastDump)
+		return
+	}
+
+	// Forget about the peers we can send a dump to again, so the map
+	// doesn't grow unbounded.
+	for vertex, dumpTime := range d.lastRoutingDump {
+		if now.Sub(dumpTime) >= initialRoutingDumpInterval {
+			delete(d.lastRoutingDump, vertex)
+		}
+	}
+	d.lastRoutingDump[pubKey] = now
+	d.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer func() {
+			<-d.routingDumpSema
+		}()
+
+		log.Infof("Sending initial routing dump to peer=%x", pubKey[:])
+
+		numMsgs, err := d.sendRoutingDump(peer, now)
+		switch {
+		case err == lnpeer.ErrPeerExiting,
+			err == ErrGossiperShuttingDown:
+
+			return
+
+		case err != nil:
+			log.Errorf("Unable to send initial routing dump to "+
+				"peer=%x: %v", pubKey[:], err)
+			return
+		}
+
+		log.Infof("Sent initial routing dump of %v messages to "+
+			"peer=%x", numMsgs, pubKey[:])
+	}()
+}
+
+// sendRoutingDump sends all announcements with an update timestamp before the
+// given end time to the peer, and returns the number of messages sent. Rather
+// than loading the whole graph into memory, the update horizon is walked in
+// windows that are sized to hold roughly routingDumpBatchSize messages.
+func (d *AuthenticatedGossiper) sendRoutingDump(peer lnpeer.Peer,
+	endTime time.Time) (int, error) {
+
+	// We rate limit the batches just like the replies to gossip queries
+	// of a single peer.
+	rateLimiter := rate.NewLimiter(
+		rate.Every(DefaultDelayedQueryReplyInterval),
+		DefaultMaxUndelayedQueryReplies,
+	)
+
+	// The horizon of the graph queries excludes the end time, so we'll
+	// extend it by a second to include the updates of the current second.
+	endTime = endTime.Add(time.Second)
+
+	// A channel announcement is returned for each window its policies
+	// were updated in, so we'll keep track of the channels we've sent
+	// already.
+	sentChans := make(map[lnwire.ShortChannelID]struct{})
+
+	var (
+		numMsgs   int
+		window    = routingDumpWindow
+		startTime = time.Unix(0, 0)
+	)
+	for startTime.Before(endTime) {
+		windowEnd := startTime.Add(window)
+		if windowEnd.After(endTime) {
+			windowEnd = endTime
+		}
+
+		msgs, err := d.cfg.ChanSeries.UpdatesInHorizon(
+			d.cfg.ChainHash, startTime, windowEnd,
+		)
+		if err != nil {
+			return numMsgs, err
+		}
+		startTime = windowEnd
+
+		// Grow the window while we only find sparse updates, and
+		// shrink it once the batches grow too large.
+		switch {
+		case len(msgs) < routingDumpBatchSize/2:
+			window *= 2
+
+		case len(msgs) > routingDumpBatchSize*2 && window > time.Second:
+			window /= 2
+		}
+
+		if len(msgs) == 0 {
+			continue
+		}
+
+		if delay := rateLimiter.Reserve().Delay(); delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-d.quit:
+				return numMsgs, ErrGossiperShuttingDown
+			}
+		}
+
+		skipUpdates := false
+		for _, msg := range msgs {
+			select {
+			case <-d.quit:
+				return numMsgs, ErrGossiperShuttingDown
+			default:
+			}
+
+			// The channel updates of a channel directly follow its
+			// announcement, so we'll skip them along with any
+			// announcement we've already sent.
+			switch msg := msg.(type) {
+			case *lnwire.ChannelAnnouncement:
+				_, skipUpdates = sentChans[msg.ShortChannelID]
+				if skipUpdates {
+					continue
+				}
+				sentChans[msg.ShortChannelID] = struct{}{}
+
+			case *lnwire.ChannelUpdate:
+				if skipUpdates {
+					continue
+				}
+
+			default:
+				skipUpdates = false
+			}
+
+			if err := peer.SendMessageLazy(true, msg); err != nil {
+				return numMsgs, err
+			}
+			numMsgs++
+		}
+	}
+
+	return numMsgs, nil
+}
+
 // PruneSyncState is called by outside sub-systems once a peer that we were
 // previously connected to has been disconnected. In this case we can stop the
 // existing GossipSyncer assigned to the peer and free up resources.
 func (d *AuthenticatedGossiper) PruneSyncState(peer route.Vertex) {
 	d.syncMgr.PruneSyncState(peer)
+
+	d.Lock()
+	delete(d.peerUpdateRateLimiter, peer)
+	d.Unlock()
+}
+
+// allowPeerChannelUpdate returns whether we should process another update for
+// a channel we already know of from the given peer, or whether it exceeded its
+// allowed rate of updates.
+func (d *AuthenticatedGossiper) allowPeerChannelUpdate(peer route.Vertex) bool {
+	if d.cfg.MaxPeerChannelUpdateBurst == 0 {
+		return true
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	rl, ok := d.peerUpdateRateLimiter[peer]
+	if !ok {
+		rl = rate.NewLimiter(
+			rate.Every(d.cfg.PeerChannelUpdateInterval),
+			d.cfg.MaxPeerChannelUpdateBurst,
+		)
+		d.peerUpdateRateLimiter[peer] = rl
+	}
+
+	return rl.Allow()
 }
 
 // isRecentlyRejectedMsg returns true if we recently rejected a message, and
@@ -2187,6 +2447,29 @@ func (d *AuthenticatedGossiper) handleChanAnnouncement(nMsg *networkMsg,
 			return nil, false
 		}
 
+		// BOLT 7 forbids routing through channels that advertise
+		// required features we don't understand, so we won't add them
+		// to our graph at all.
+		features := lnwire.NewFeatureVector(
+			ann.Features, lnwire.Features,
+		)
+		unknown := features.UnknownRequiredFeatures()
+		if len(unknown) > 0 {
+			err := fmt.Errorf("ignoring ChannelAnnouncement for "+
+				"chan_id=%v with unknown required features: %v",
+				ann.ShortChannelID, unknown)
+
+			key := newRejectCacheKey(
+				ann.ShortChannelID.ToUint64(),
+				sourceToPub(nMsg.source),
+			)
+			_, _ = d.recentRejects.Put(key, &cachedReject{})
+
+			log.Error(err)
+			nMsg.err <- err
+			return nil, false
+		}
+
 		// If the proof checks out, then we'll save the proof itself to
 		// the database so we can fetch it later when gossiping with
 		// other nodes.
@@ -2551,6 +2834,17 @@ func (d *AuthenticatedGossiper) handleChanUpdate(nMsg *networkMsg,
 				return nil, false
 			}
 		} else {
+			// Before looking at the channel itself, we'll make
+			// sure the peer isn't flooding us with updates across
+			// many different channels.
+			peer := route.Vertex(sourceToPub(nMsg.source))
+			if !d.allowPeerChannelUpdate(peer) {
+				log.Debugf("Rate limiting update for channel "+
+					"%v from peer %v", shortChanID, peer)
+				nMsg.err <- nil
+				return nil, false
+			}
+
 			// If it's not, we'll allow an update per minute with a
 			// maximum burst of 10. If we haven't seen an update
 			// for this channel before, we'll need to initialize a
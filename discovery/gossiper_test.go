@@ -663,48 +663,59 @@ func createChannelAnnouncement(blockHeight uint32, key1, key2 *btcec.PrivateKey,
 	extraBytes ...[]byte) (*lnwire.ChannelAnnouncement, error) {
 
 	a := createAnnouncementWithoutProof(blockHeight, key1.PubKey(), key2.PubKey(), extraBytes...)
+	if err := signChannelAnnouncement(a, key1, key2); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// signChannelAnnouncement populates all four signatures of the given channel
+// announcement using the passed node keys and the test bitcoin keys.
+func signChannelAnnouncement(a *lnwire.ChannelAnnouncement,
+	key1, key2 *btcec.PrivateKey) error {
 
 	signer := mock.SingleSigner{Privkey: key1}
 	sig, err := netann.SignAnnouncement(&signer, testKeyLoc, a)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	a.NodeSig1, err = lnwire.NewSigFromSignature(sig)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	signer = mock.SingleSigner{Privkey: key2}
 	sig, err = netann.SignAnnouncement(&signer, testKeyLoc, a)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	a.NodeSig2, err = lnwire.NewSigFromSignature(sig)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	signer = mock.SingleSigner{Privkey: bitcoinKeyPriv1}
 	sig, err = netann.SignAnnouncement(&signer, testKeyLoc, a)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	a.BitcoinSig1, err = lnwire.NewSigFromSignature(sig)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	signer = mock.SingleSigner{Privkey: bitcoinKeyPriv2}
 	sig, err = netann.SignAnnouncement(&signer, testKeyLoc, a)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	a.BitcoinSig2, err = lnwire.NewSigFromSignature(sig)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return a, nil
+	return nil
 }
 
 type testCtx struct {
@@ -4271,3 +4282,247 @@ func TestRejectCacheChannelAnn(t *testing.T) {
 		t.Fatal("did not process remote announcement")
 	}
 }
+
+// TestRejectUnknownRequiredChanFeatures asserts that remote channel
+// announcements advertising required features we don't know of are rejected
+// and never added to the graph.
+func TestRejectUnknownRequiredChanFeatures(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	require.NoError(t, err, "can't create context")
+	defer cleanup()
+
+	// We'll craft a channel announcement that sets an even feature bit
+	// that isn't known to us, and sign it so that it passes signature
+	// validation.
+	ca := createAnnouncementWithoutProof(
+		0, remoteKeyPriv1.PubKey(), remoteKeyPriv2.PubKey(),
+	)
+	ca.Features = lnwire.NewRawFeatureVector(lnwire.FeatureBit(100))
+	err = signChannelAnnouncement(ca, remoteKeyPriv1, remoteKeyPriv2)
+	require.NoError(t, err, "unable to sign channel announcement")
+
+	remotePeer := &mockPeer{remoteKeyPriv1.PubKey(), nil, nil}
+	select {
+	case err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, remotePeer):
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unknown required features")
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not process remote announcement")
+	}
+
+	// The channel shouldn't have been added to the graph nor should it
+	// be broadcast to our peers.
+	require.Empty(t, ctx.router.infos)
+	select {
+	case <-ctx.broadcastedMessage:
+		t.Fatal("announcement was broadcast")
+	case <-time.After(2 * trickleDelay):
+	}
+}
+
+// TestSendInitialRoutingDump asserts that all announcements within our graph
+// are sent to a peer that requested an initial routing dump, and that the
+// same peer can't request another dump right away.
+func TestSendInitialRoutingDump(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	require.NoError(t, err, "can't create context")
+	defer cleanup()
+
+	chanSeries := newMockChannelGraphTimeSeries(lnwire.ShortChannelID{})
+	ctx.gossiper.cfg.ChanSeries = chanSeries
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	require.NoError(t, err, "can't create channel announcement")
+	ua, err := createUpdateAnnouncement(0, 0, remoteKeyPriv1, testTimestamp)
+	require.NoError(t, err, "can't create update announcement")
+	dump := []lnwire.Message{ca, ua}
+
+	// The graph is queried in windows, so we'll return the announcements
+	// for the window that contains their timestamp. The window after it
+	// returns them again to make sure they aren't sent twice.
+	var (
+		queries   []horizonQuery
+		dumpStart = time.Now()
+		done      = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+
+		updateTime := time.Unix(int64(testTimestamp), 0)
+		sentOnce := false
+		for {
+			var query horizonQuery
+			select {
+			case query = <-chanSeries.horizonReq:
+			case <-time.After(5 * time.Second):
+				return
+			}
+			queries = append(queries, query)
+
+			var resp []lnwire.Message
+			switch {
+			case !query.start.After(updateTime) &&
+				query.end.After(updateTime):
+
+				resp = dump
+				sentOnce = true
+
+			case sentOnce:
+				resp = dump
+				sentOnce = false
+			}
+			chanSeries.horizonResp <- resp
+
+			// The last window ends after the dump was started.
+			if query.end.After(dumpStart) {
+				return
+			}
+		}
+	}()
+
+	peer := &mockPeer{
+		remoteKeyPriv1.PubKey(), make(chan lnwire.Message, 10), nil,
+	}
+	ctx.gossiper.SendInitialRoutingDump(peer)
+
+	// Each of the returned messages should be sent to the peer in order.
+	for _, expMsg := range dump {
+		select {
+		case msg := <-peer.sentMsgs:
+			require.Equal(t, expMsg, msg)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected %v to be sent", expMsg.MsgType())
+		}
+	}
+
+	// Once the dump is complete, the queried windows should cover the
+	// entire horizon of our graph without any gaps.
+	<-done
+	require.NotEmpty(t, queries)
+	require.Equal(t, int64(0), queries[0].start.Unix())
+	for i := 1; i < len(queries); i++ {
+		require.Equal(t, queries[i-1].end, queries[i].start)
+	}
+	require.True(t, queries[len(queries)-1].end.After(dumpStart))
+
+	// The announcements shouldn't have been sent again.
+	select {
+	case msg := <-peer.sentMsgs:
+		t.Fatalf("unexpected message sent: %v", msg.MsgType())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Another dump for the same peer shouldn't query the graph at all.
+	ctx.gossiper.SendInitialRoutingDump(peer)
+
+	select {
+	case <-chanSeries.horizonReq:
+		t.Fatal("graph queried for repeated routing dump")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestRateLimitPeerChannelUpdates asserts that updates for known channels are
+// rate limited per peer, regardless of the channel they're for.
+func TestRateLimitPeerChannelUpdates(t *testing.T) {
+	t.Parallel()
+
+	// Create our test harness.
+	const blockHeight = 100
+	ctx, cleanup, err := createTestCtx(blockHeight)
+	require.NoError(t, err, "can't create context")
+	defer cleanup()
+	ctx.gossiper.cfg.RebroadcastInterval = time.Hour
+	ctx.gossiper.cfg.MaxChannelUpdateBurst = 10
+	ctx.gossiper.cfg.ChannelUpdateInterval = time.Hour
+	ctx.gossiper.cfg.MaxPeerChannelUpdateBurst = 2
+	ctx.gossiper.cfg.PeerChannelUpdateInterval = time.Hour
+
+	// We'll start by making the gossiper aware of a channel along with
+	// both of its edges.
+	batch, err := createRemoteAnnouncements(blockHeight)
+	require.NoError(t, err)
+
+	nodePeer1 := &mockPeer{remoteKeyPriv1.PubKey(), nil, nil}
+	nodePeer2 := &mockPeer{remoteKeyPriv2.PubKey(), nil, nil}
+
+	processAnn := func(msg lnwire.Message, peer lnpeer.Peer) {
+		t.Helper()
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(msg, peer):
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("remote announcement not processed")
+		}
+	}
+	processAnn(batch.chanAnn, nodePeer1)
+	processAnn(batch.chanUpdAnn1, nodePeer1)
+	processAnn(batch.chanUpdAnn2, nodePeer2)
+
+	timeout := time.After(2 * trickleDelay)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ctx.broadcastedMessage:
+		case <-timeout:
+			t.Fatal("expected announcement to be broadcast")
+		}
+	}
+
+	// assertRateLimit processes the update from the given peer and asserts
+	// whether it was rate limited or not.
+	assertRateLimit := func(update *lnwire.ChannelUpdate, peer lnpeer.Peer,
+		shouldRateLimit bool) {
+
+		t.Helper()
+
+		processAnn(update, peer)
+
+		select {
+		case <-ctx.broadcastedMessage:
+			if shouldRateLimit {
+				t.Fatal("unexpected channel update broadcast")
+			}
+		case <-time.After(2 * trickleDelay):
+			if !shouldRateLimit {
+				t.Fatal("expected channel update broadcast")
+			}
+		}
+	}
+
+	// The first peer is allowed to relay two updates before it's rate
+	// limited.
+	update1 := *batch.chanUpdAnn1
+	for i := 0; i < ctx.gossiper.cfg.MaxPeerChannelUpdateBurst; i++ {
+		update1.Timestamp++
+		update1.BaseFee++
+		require.NoError(t, signUpdate(remoteKeyPriv1, &update1))
+		assertRateLimit(&update1, nodePeer1, false)
+	}
+
+	update1.Timestamp++
+	update1.BaseFee++
+	require.NoError(t, signUpdate(remoteKeyPriv1, &update1))
+	assertRateLimit(&update1, nodePeer1, true)
+
+	// An update for the other direction is rate limited as well when it
+	// comes from the same peer, but not if another peer relays it.
+	update2 := *batch.chanUpdAnn2
+	update2.Timestamp++
+	update2.BaseFee++
+	require.NoError(t, signUpdate(remoteKeyPriv2, &update2))
+	assertRateLimit(&update2, nodePeer1, true)
+	assertRateLimit(&update2, nodePeer2, false)
+
+	// Once the first peer disconnects, its rate limiter is reset.
+	ctx.gossiper.PruneSyncState(route.NewVertex(nodePeer1.IdentityKey()))
+
+	update1.Timestamp++
+	update1.BaseFee++
+	require.NoError(t, signUpdate(remoteKeyPriv1, &update1))
+	assertRateLimit(&update1, nodePeer1, false)
+}
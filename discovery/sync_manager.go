@@ -626,16 +626,27 @@ func (m *SyncManager) transitionPassiveSyncer(s *GossipSyncer) error {
 }
 
 // forceHistoricalSync chooses a syncer with a remote peer at random and forces
-// a historical sync with it.
+// a historical sync with it. Pinned syncers are only chosen if no active or
+// inactive syncer is eligible, so that routine historical syncs are spread
+// across all of our peers.
 func (m *SyncManager) forceHistoricalSync() *GossipSyncer {
 	m.syncersMu.Lock()
 	defer m.syncersMu.Unlock()
 
+	historicalSync := func(s *GossipSyncer) error {
+		return s.historicalSync()
+	}
+
 	// We'll sample from both sets of active and inactive syncers in the
 	// event that we don't have any inactive syncers.
-	return chooseRandomSyncer(m.gossipSyncers(), func(s *GossipSyncer) error {
-		return s.historicalSync()
-	})
+	s := chooseRandomSyncer(m.gossipSyncers(), historicalSync)
+	if s != nil {
+		return s
+	}
+
+	// If none of them is eligible, we'll fall back to our pinned syncers
+	// so that we still repair gaps in our graph.
+	return chooseRandomSyncer(m.pinnedActiveSyncers, historicalSync)
 }
 
 // chooseRandomSyncer iterates through the set of syncers given and returns the
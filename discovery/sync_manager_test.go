@@ -397,6 +397,52 @@ func TestSyncManagerForceHistoricalSync(t *testing.T) {
 	})
 }
 
+// TestSyncManagerForceHistoricalSyncPinned ensures that routine historical
+// syncs are only performed with a pinned syncer when no other syncer is
+// available.
+func TestSyncManagerForceHistoricalSyncPinned(t *testing.T) {
+	t.Parallel()
+
+	pinnedPubkey := randPubKey(t)
+	pinnedSyncers := PinnedSyncers{
+		route.NewVertex(pinnedPubkey): struct{}{},
+	}
+
+	syncMgr := newPinnedTestSyncManager(1, pinnedSyncers)
+	syncMgr.Start()
+	defer syncMgr.Stop()
+
+	// The pinned peer will perform an initial historical sync, which
+	// we'll let complete.
+	pinnedPeer := peerWithPubkey(pinnedPubkey, syncMgr.quit)
+	require.NoError(t, syncMgr.InitSyncState(pinnedPeer))
+	pinnedSyncer := assertSyncerExistence(t, syncMgr, pinnedPeer)
+	assertTransitionToChansSynced(t, pinnedSyncer, pinnedPeer)
+	assertActiveGossipTimestampRange(t, pinnedPeer)
+	assertSyncerStatus(t, pinnedSyncer, chansSynced, PinnedSync)
+
+	// As the pinned syncer is the only one we have, a tick of the
+	// historical sync ticker should select it.
+	syncMgr.cfg.HistoricalSyncTicker.(*ticker.Force).Force <- time.Time{}
+	assertTransitionToChansSynced(t, pinnedSyncer, pinnedPeer)
+
+	// Once a regular peer is connected and synced, it should be chosen
+	// for the next routine historical sync instead.
+	peer := randPeer(t, syncMgr.quit)
+	require.NoError(t, syncMgr.InitSyncState(peer))
+	s := assertSyncerExistence(t, syncMgr, peer)
+	assertTransitionToChansSynced(t, s, peer)
+	assertActiveGossipTimestampRange(t, peer)
+	assertSyncerStatus(t, s, chansSynced, ActiveSync)
+
+	syncMgr.cfg.HistoricalSyncTicker.(*ticker.Force).Force <- time.Time{}
+	assertMsgSent(t, peer, &lnwire.QueryChannelRange{
+		FirstBlockHeight: 0,
+		NumBlocks:        latestKnownHeight,
+	})
+	assertNoMsgSent(t, pinnedPeer)
+}
+
 // TestSyncManagerGraphSyncedAfterHistoricalSyncReplacement ensures that the
 // sync manager properly marks the graph as synced given that our initial
 // historical sync has stalled, but a replacement has fully completed.
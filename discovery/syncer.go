@@ -1182,6 +1182,14 @@ func (g *GossipSyncer) replyShortChanIDs(query *lnwire.QueryShortChanIDs) error
 // state machine. Once applied, we'll ensure that we don't forward any messages
 // to the peer that aren't within the time range of the filter.
 func (g *GossipSyncer) ApplyGossipFilter(filter *lnwire.GossipTimestampRange) error {
+	// A filter for a chain other than our own doesn't apply to any of the
+	// gossip we'd send, so we'll ignore it as the spec recommends.
+	if filter.ChainHash != g.cfg.chainHash {
+		log.Warnf("GossipSyncer(%x): ignoring gossip filter for "+
+			"unknown chain %v", g.cfg.peerPub[:], filter.ChainHash)
+		return nil
+	}
+
 	g.Lock()
 
 	g.remoteUpdateHorizon = filter
@@ -1247,7 +1255,10 @@ func (g *GossipSyncer) ApplyGossipFilter(filter *lnwire.GossipTimestampRange) er
 func (g *GossipSyncer) FilterGossipMsgs(msgs ...msgWithSenders) {
 	// If the peer doesn't have an update horizon set, then we won't send
 	// it any new update messages.
-	if g.remoteUpdateHorizon == nil {
+	g.Lock()
+	remoteUpdateHorizon := g.remoteUpdateHorizon
+	g.Unlock()
+	if remoteUpdateHorizon == nil {
 		return
 	}
 
@@ -1282,12 +1293,10 @@ func (g *GossipSyncer) FilterGossipMsgs(msgs ...msgWithSenders) {
 
 	// We'll construct a helper function that we'll us below to determine
 	// if a given messages passes the gossip msg filter.
-	g.Lock()
-	startTime := time.Unix(int64(g.remoteUpdateHorizon.FirstTimestamp), 0)
+	startTime := time.Unix(int64(remoteUpdateHorizon.FirstTimestamp), 0)
 	endTime := startTime.Add(
-		time.Duration(g.remoteUpdateHorizon.TimestampRange) * time.Second,
+		time.Duration(remoteUpdateHorizon.TimestampRange) * time.Second,
 	)
-	g.Unlock()
 
 	passesFilter := func(timeStamp uint32) bool {
 		t := time.Unix(int64(timeStamp), 0)
@@ -443,6 +443,33 @@ func TestGossipSyncerApplyNoHistoricalGossipFilter(t *testing.T) {
 	}
 }
 
+// TestGossipSyncerApplyGossipFilterUnknownChain tests that a gossip filter for
+// a chain other than our own is ignored.
+func TestGossipSyncerApplyGossipFilterUnknownChain(t *testing.T) {
+	t.Parallel()
+
+	_, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), defaultEncoding,
+		defaultChunkSize,
+	)
+
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		ChainHash:      chainhash.Hash{0x01},
+		FirstTimestamp: unixStamp(25000),
+		TimestampRange: uint32(1000),
+	}
+	require.NoError(t, syncer.ApplyGossipFilter(remoteHorizon))
+
+	// The filter should neither have been stored, nor should it have
+	// caused us to query for any messages to send.
+	require.Nil(t, syncer.remoteUpdateHorizon)
+	select {
+	case <-chanSeries.horizonReq:
+		t.Fatalf("chan series should not have been queried")
+	case <-time.After(time.Second):
+	}
+}
+
 // TestGossipSyncerApplyGossipFilter tests that once a gossip filter is applied
 // for the remote peer, then we send the peer all known messages which are
 // within their desired time horizon.
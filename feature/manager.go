@@ -27,6 +27,9 @@ type Config struct {
 	// NoScriptEnforcementLease unsets any bits signaling support for script
 	// enforced leases.
 	NoScriptEnforcementLease bool
+
+	// NoScidAlias unsets any bits signaling support for scid aliases.
+	NoScidAlias bool
 }
 
 // Manager is responsible for generating feature vectors for different requested
@@ -115,6 +118,10 @@ func newManager(cfg Config, desc setDesc) (*Manager, error) {
 			raw.Unset(lnwire.ScriptEnforcedLeaseOptional)
 			raw.Unset(lnwire.ScriptEnforcedLeaseRequired)
 		}
+		if cfg.NoScidAlias {
+			raw.Unset(lnwire.ScidAliasOptional)
+			raw.Unset(lnwire.ScidAliasRequired)
+		}
 
 		// Ensure that all of our feature sets properly set any
 		// dependent features.
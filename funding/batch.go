@@ -204,6 +204,7 @@ func (b *Batcher) BatchFund(ctx context.Context,
 
 	// Parse and validate each individual channel.
 	b.channels = make([]*batchChannel, 0, len(req.Channels))
+	pendingChanIDs := make(map[[32]byte]struct{}, len(req.Channels))
 	for idx, rpcChannel := range req.Channels {
 		// If the user specifies a channel ID, it must be exactly 32
 		// bytes long.
@@ -229,6 +230,14 @@ func (b *Batcher) BatchFund(ctx context.Context,
 				err)
 		}
 
+		// Each channel is tracked by its temp chan ID throughout the
+		// funding flow, so they must be unique within the batch.
+		if _, ok := pendingChanIDs[pendingChanID]; ok {
+			return nil, fmt.Errorf("duplicate temp chan ID %x",
+				pendingChanID[:])
+		}
+		pendingChanIDs[pendingChanID] = struct{}{}
+
 		fundingReq, err := b.cfg.RequestParser(&lnrpc.OpenChannelRequest{
 			SatPerVbyte:        uint64(req.SatPerVbyte),
 			NodePubkey:         rpcChannel.NodePubkey,
@@ -35,6 +35,8 @@ var (
 		Hash:  [32]byte{1, 2, 3},
 		Index: 2,
 	}
+
+	testPendingChanID = [32]byte{4, 5, 6}
 )
 
 type fundingIntent struct {
@@ -340,6 +342,18 @@ func TestBatchFund(t *testing.T) {
 			LocalFundingAmount: 4321,
 		}},
 		expectedErr: "error publishing final batch transaction",
+	}, {
+		name: "duplicate temp chan ID",
+		channels: []*lnrpc.BatchOpenChannel{{
+			NodePubkey:         testPubKey1Bytes,
+			LocalFundingAmount: 1234,
+			PendingChanId:      testPendingChanID[:],
+		}, {
+			NodePubkey:         testPubKey2Bytes,
+			LocalFundingAmount: 4321,
+			PendingChanId:      testPendingChanID[:],
+		}},
+		expectedErr: "duplicate temp chan ID",
 	}}
 
 	for _, tc := range testCases {
@@ -362,7 +376,7 @@ func TestBatchFund(t *testing.T) {
 				context.Background(), req,
 			)
 
-			if tc.failUpdate1 || tc.failUpdate2 || tc.failPublish {
+			if tc.expectedErr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.expectedErr)
 			} else {
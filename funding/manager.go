@@ -16,6 +16,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/aliasmgr"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainreg"
 	"github.com/lightningnetwork/lnd/chanacceptor"
@@ -32,6 +33,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"golang.org/x/crypto/salsa20"
 )
 
@@ -96,10 +98,11 @@ const (
 	// TODO(roasbeef): tune.
 	msgBufferSize = 50
 
-	// maxWaitNumBlocksFundingConf is the maximum number of blocks to wait
-	// for the funding transaction to be confirmed before forgetting
-	// channels that aren't initiated by us. 2016 blocks is ~2 weeks.
-	maxWaitNumBlocksFundingConf = 2016
+	// DefaultMaxWaitNumBlocksFundingConf is the default maximum number of
+	// blocks to wait for the funding transaction to be confirmed before
+	// forgetting channels that aren't initiated by us. 2016 blocks is ~2
+	// weeks.
+	DefaultMaxWaitNumBlocksFundingConf = 2016
 )
 
 var (
@@ -115,6 +118,11 @@ var (
 	ErrConfirmationTimeout = errors.New("timeout waiting for funding " +
 		"confirmation")
 
+	// ErrChannelAbandoned is an error returned when we are waiting for a
+	// funding transaction to confirm, but the channel is abandoned.
+	ErrChannelAbandoned = errors.New("channel was abandoned while " +
+		"waiting for funding confirmation")
+
 	// errUpfrontShutdownScriptNotSupported is returned if an upfront shutdown
 	// script is set for a peer that does not support the feature bit.
 	errUpfrontShutdownScriptNotSupported = errors.New("peer does not support" +
@@ -144,6 +152,10 @@ type reservationWithCtx struct {
 	remoteMaxValue lnwire.MilliSatoshi
 	remoteMaxHtlcs uint16
 
+	// remoteChanReserve is the channel reserve we require the remote
+	// party to keep.
+	remoteChanReserve btcutil.Amount
+
 	// maxLocalCsv is the maximum csv we will accept from the remote.
 	maxLocalCsv uint16
 
@@ -151,6 +163,11 @@ type reservationWithCtx struct {
 	// the channel.
 	channelType *lnwire.ChannelType
 
+	// alias is the alias that we assigned to the channel when the
+	// reservation was created. It's nil if option_scid_alias isn't used
+	// for the channel.
+	alias *lnwire.ShortChannelID
+
 	updateMtx   sync.RWMutex
 	lastUpdated time.Time
 
@@ -197,6 +214,27 @@ type InitFundingMsg struct {
 	// LocalFundingAmt is the size of the channel.
 	LocalFundingAmt btcutil.Amount
 
+	// FundUpToMaxAmt is set if the channel should be funded with as much
+	// of the wallet balance as possible, up to this amount. The size of
+	// the channel is then decided by the wallet's coin selection.
+	FundUpToMaxAmt btcutil.Amount
+
+	// MinFundAmt is the minimum size of the channel when FundUpToMaxAmt
+	// is set.
+	MinFundAmt btcutil.Amount
+
+	// Outpoints is an optional set of wallet outpoints that must be used
+	// to fund the channel.
+	Outpoints []wire.OutPoint
+
+	// Account is the name of the wallet account the channel is funded
+	// from. If empty, the default account is used.
+	Account string
+
+	// CoinSelectionStrategy optionally overrides the wallet's default coin
+	// selection strategy for the funding transaction.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
 	// PushAmt is the amount pushed to the counterparty.
 	PushAmt lnwire.MilliSatoshi
 
@@ -233,6 +271,10 @@ type InitFundingMsg struct {
 	// peer.
 	MaxLocalCsv uint16
 
+	// RemoteChanReserve is the channel reserve we will require the remote
+	// peer to keep. If zero, it is derived from the channel capacity.
+	RemoteChanReserve btcutil.Amount
+
 	// ChanFunder is an optional channel funder that allows the caller to
 	// control exactly how the channel funding is carried out. If not
 	// specified, then the default chanfunding.WalletAssembler will be
@@ -416,6 +458,12 @@ type Config struct {
 	// a reservation is considered a zombie.
 	ReservationTimeout time.Duration
 
+	// MaxWaitNumBlocksFundingConf is the maximum number of blocks to wait
+	// for the funding transaction of a channel that wasn't initiated by us
+	// to confirm before we forget about the channel. If zero,
+	// DefaultMaxWaitNumBlocksFundingConf is used.
+	MaxWaitNumBlocksFundingConf uint32
+
 	// MinChanSize is the smallest channel size that we'll accept as an
 	// inbound channel. We have such a parameter, as otherwise, nodes could
 	// flood us with very small channels that would never really be usable
@@ -427,6 +475,16 @@ type Config struct {
 	// WUMBO you would like your channel.
 	MaxChanSize btcutil.Amount
 
+	// MinOutboundChanSize is the smallest channel size that we'll open
+	// ourselves. A value of zero means no limit beyond the protocol
+	// minimum.
+	MinOutboundChanSize btcutil.Amount
+
+	// MaxOutboundChanSize is the largest channel size that we'll open
+	// ourselves. A value of zero means no limit beyond the protocol
+	// maximum.
+	MaxOutboundChanSize btcutil.Amount
+
 	// MaxPendingChannels is the maximum number of pending channels we
 	// allow for each peer.
 	MaxPendingChannels int
@@ -435,6 +493,16 @@ type Config struct {
 	// incoming channels having a non-zero push amount.
 	RejectPush bool
 
+	// RejectUnknownPeers is set true if the fundingmanager should reject
+	// any incoming channels from peers that we don't have an open channel
+	// with and that aren't part of AllowedPeers.
+	RejectUnknownPeers bool
+
+	// AllowedPeers is the set of peers we accept incoming channels from
+	// even if we have no open channel with them and RejectUnknownPeers is
+	// set.
+	AllowedPeers map[route.Vertex]struct{}
+
 	// MaxLocalCSVDelay is the maximum csv delay we will allow for our
 	// commit output. Channels that exceed this value will be failed.
 	MaxLocalCSVDelay uint16
@@ -463,6 +531,10 @@ type Config struct {
 	// MaxAnchorsCommitFeeRate is the max commitment fee rate we'll use as
 	// the initiator for channels of the anchor type.
 	MaxAnchorsCommitFeeRate chainfee.SatPerKWeight
+
+	// AliasManager assigns and stores the short channel id aliases of
+	// channels that negotiated option_scid_alias.
+	AliasManager *aliasmgr.Manager
 }
 
 // Manager acts as an orchestrator/bridge between the wallet's
@@ -506,6 +578,10 @@ type Manager struct {
 	// goroutine safe.
 	resMtx sync.RWMutex
 
+	// aliasMtx ensures that only a single alias is assigned to a channel,
+	// as both sending and receiving FundingLocked may request one.
+	aliasMtx sync.Mutex
+
 	// fundingMsgs is a channel that relays fundingMsg structs from
 	// external sub-systems using the ProcessFundingMsg call.
 	fundingMsgs chan *fundingMsg
@@ -527,6 +603,12 @@ type Manager struct {
 	handleFundingLockedMtx      sync.RWMutex
 	handleFundingLockedBarriers map[lnwire.ChannelID]struct{}
 
+	// abandonSignals maps the funding outpoint of each channel we are
+	// waiting to confirm to a channel that is closed once the channel is
+	// abandoned, canceling the wait.
+	abandonMtx     sync.Mutex
+	abandonSignals map[wire.OutPoint]chan struct{}
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -570,6 +652,11 @@ func (c channelOpeningState) String() string {
 // NewFundingManager creates and initializes a new instance of the
 // fundingManager.
 func NewFundingManager(cfg Config) (*Manager, error) {
+	if cfg.MaxWaitNumBlocksFundingConf == 0 {
+		cfg.MaxWaitNumBlocksFundingConf =
+			DefaultMaxWaitNumBlocksFundingConf
+	}
+
 	return &Manager{
 		cfg:                         &cfg,
 		chanIDKey:                   cfg.TempChanIDSeed,
@@ -580,6 +667,7 @@ func NewFundingManager(cfg Config) (*Manager, error) {
 		fundingRequests:             make(chan *InitFundingMsg, msgBufferSize),
 		localDiscoverySignals:       make(map[lnwire.ChannelID]chan struct{}),
 		handleFundingLockedBarriers: make(map[lnwire.ChannelID]struct{}),
+		abandonSignals:              make(map[wire.OutPoint]chan struct{}),
 		quit:                        make(chan struct{}),
 	}, nil
 }
@@ -867,7 +955,12 @@ func (f *Manager) advanceFundingState(channel *channeldb.OpenChannel,
 	// transaction to confirm.
 	if channel.IsPending {
 		err := f.advancePendingChannelState(channel, pendingChanID)
-		if err != nil {
+		if err == ErrChannelAbandoned {
+			log.Infof("ChannelPoint(%v) was abandoned, no longer "+
+				"waiting for funding confirmation",
+				channel.FundingOutpoint)
+			return
+		} else if err != nil {
 			log.Errorf("Unable to advance pending state of "+
 				"ChannelPoint(%v): %v",
 				channel.FundingOutpoint, err)
@@ -1058,7 +1151,7 @@ func (f *Manager) advancePendingChannelState(
 	if err == ErrConfirmationTimeout {
 		// We'll get a timeout if the number of blocks mined
 		// since the channel was initiated reaches
-		// maxWaitNumBlocksFundingConf and we are not the
+		// MaxWaitNumBlocksFundingConf and we are not the
 		// channel initiator.
 		ch := channel
 		localBalance := ch.LocalCommitment.LocalBalance.ToSatoshis()
@@ -1110,6 +1203,8 @@ func (f *Manager) advancePendingChannelState(
 		}()
 
 		return timeoutErr
+	} else if err == ErrChannelAbandoned {
+		return err
 	} else if err != nil {
 		return fmt.Errorf("error waiting for funding "+
 			"confirmation for ChannelPoint(%v): %v",
@@ -1240,6 +1335,21 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 		return
 	}
 
+	// As the initiator pays the commitment fee, we can't know in advance
+	// what fee rate it finds acceptable. We'll only make sure that the
+	// commitment transaction can be relayed at all, otherwise we wouldn't
+	// be able to broadcast it if the channel needs to be force closed.
+	commitFeePerKw := chainfee.SatPerKWeight(msg.FeePerKiloWeight)
+	if commitFeePerKw < chainfee.FeePerKwFloor {
+		f.failFundingFlow(
+			peer, msg.PendingChannelID,
+			lnwallet.ErrCommitFeeTooLow(
+				commitFeePerKw, chainfee.FeePerKwFloor,
+			),
+		)
+		return
+	}
+
 	// If request specifies non-zero push amount and 'rejectpush' is set,
 	// signal an error.
 	if f.cfg.RejectPush && msg.PushAmount > 0 {
@@ -1250,6 +1360,16 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 		return
 	}
 
+	// If 'rejectunknownpeers' is set, we only accept the channel if we
+	// already have an open channel with the peer or it has been allowed
+	// explicitly.
+	if f.cfg.RejectUnknownPeers && !f.isKnownPeer(peerPubKey, channels) {
+		f.failFundingFlow(
+			peer, msg.PendingChannelID, lnwallet.ErrUnknownPeer(),
+		)
+		return
+	}
+
 	// Send the OpenChannel request to the ChannelAcceptor to determine whether
 	// this node will accept the channel.
 	chanReq := &chanacceptor.ChannelAcceptRequest{
@@ -1310,7 +1430,7 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 		NodeAddr:         peer.Address(),
 		LocalFundingAmt:  0,
 		RemoteFundingAmt: amt,
-		CommitFeePerKw:   chainfee.SatPerKWeight(msg.FeePerKiloWeight),
+		CommitFeePerKw:   commitFeePerKw,
 		FundingFeePerKw:  0,
 		PushMSat:         msg.PushAmount,
 		Flags:            msg.ChannelFlags,
@@ -1450,6 +1570,18 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 		minHtlc = acceptorResp.MinHtlcIn
 	}
 
+	// If option_scid_alias is used for the channel, we'll assign its
+	// alias right away, so it's known before the channel confirms.
+	alias, err := f.pendingAlias(peer, msg.ChannelFlags)
+	if err != nil {
+		log.Errorf("Unable to assign alias: %v", err)
+		if err := reservation.Cancel(); err != nil {
+			log.Errorf("unable to cancel reservation: %v", err)
+		}
+		f.failFundingFlow(peer, msg.PendingChannelID, err)
+		return
+	}
+
 	// Once the reservation has been created successfully, we add it to
 	// this peer's map of pending reservations to track this particular
 	// reservation until either abort or completion.
@@ -1466,6 +1598,7 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 		remoteMaxHtlcs: maxHtlcs,
 		maxLocalCsv:    f.cfg.MaxLocalCSVDelay,
 		channelType:    msg.ChannelType,
+		alias:          alias,
 		err:            make(chan error, 1),
 		peer:           peer,
 	}
@@ -1549,6 +1682,27 @@ func (f *Manager) handleFundingOpen(peer lnpeer.Peer,
 	}
 }
 
+// isKnownPeer returns true if the peer is part of our allowed peers, or if one
+// of the passed channels we have with it is open.
+func (f *Manager) isKnownPeer(peerKey *btcec.PublicKey,
+	channels []*channeldb.OpenChannel) bool {
+
+	if _, ok := f.cfg.AllowedPeers[route.NewVertex(peerKey)]; ok {
+		return true
+	}
+
+	// Channels that are still pending don't count, otherwise a peer that
+	// got a channel accepted once could keep opening new ones before any
+	// of them confirms.
+	for _, c := range channels {
+		if !c.IsPending {
+			return true
+		}
+	}
+
+	return false
+}
+
 // handleFundingAccept processes a response to the workflow initiation sent by
 // the remote peer. This message then queues a message with the funding
 // outpoint, and a commitment signature to the remote peer.
@@ -1670,13 +1824,11 @@ func (f *Manager) handleFundingAccept(peer lnpeer.Peer,
 		return
 	}
 
-	// As they've accepted our channel constraints, we'll regenerate them
-	// here so we can properly commit their accepted constraints to the
-	// reservation. Also make sure that we re-generate the ChannelReserve
-	// with our dust limit or we can get stuck channels.
-	chanReserve := f.cfg.RequiredRemoteChanReserve(
-		resCtx.chanAmt, resCtx.reservation.OurContribution().DustLimit,
-	)
+	// As they've accepted our channel constraints, we'll commit them to
+	// the reservation. The ChannelReserve was generated with our dust
+	// limit when the funding flow was initiated, as we'd otherwise get
+	// stuck channels.
+	chanReserve := resCtx.remoteChanReserve
 
 	// The remote node has responded with their portion of the channel
 	// contribution. At this point, we can process their contribution which
@@ -1955,6 +2107,10 @@ func (f *Manager) handleFundingCreated(peer lnpeer.Peer,
 	// from the set of active reservations.
 	f.deleteReservationCtx(peerKey, msg.PendingChannelID)
 
+	// Now that the channel id is known, we'll store the alias we assigned
+	// to the channel until its confirmed short channel id is known.
+	f.storePendingAlias(completeChan, resCtx.alias)
+
 	// If something goes wrong before the funding transaction is confirmed,
 	// we use this convenience method to delete the pending OpenChannel
 	// from the database.
@@ -2122,6 +2278,10 @@ func (f *Manager) handleFundingSigned(peer lnpeer.Peer,
 	// delete it from our set of active reservations.
 	f.deleteReservationCtx(peerKey, pendingChanID)
 
+	// Now that the channel id is known, we'll store the alias we assigned
+	// to the channel until its confirmed short channel id is known.
+	f.storePendingAlias(completeChan, resCtx.alias)
+
 	// Broadcast the finalized funding transaction to the network, but only
 	// if we actually have the funding transaction.
 	if completeChan.ChanType.HasFundingTx() {
@@ -2220,7 +2380,7 @@ type confirmedChannel struct {
 
 // waitForFundingWithTimeout is a wrapper around waitForFundingConfirmation and
 // waitForTimeout that will return ErrConfirmationTimeout if we are not the
-// channel initiator and the maxWaitNumBlocksFundingConf has passed from the
+// channel initiator and the MaxWaitNumBlocksFundingConf has passed from the
 // funding broadcast height. In case of confirmation, the short channel ID of
 // the channel and the funding transaction will be returned.
 func (f *Manager) waitForFundingWithTimeout(
@@ -2230,6 +2390,23 @@ func (f *Manager) waitForFundingWithTimeout(
 	timeoutChan := make(chan error, 1)
 	cancelChan := make(chan struct{})
 
+	// Register a signal that lets us stop waiting in case the channel is
+	// abandoned before the funding transaction confirms.
+	abandonChan := make(chan struct{})
+	chanPoint := ch.FundingOutpoint
+
+	f.abandonMtx.Lock()
+	f.abandonSignals[chanPoint] = abandonChan
+	f.abandonMtx.Unlock()
+
+	defer func() {
+		f.abandonMtx.Lock()
+		if f.abandonSignals[chanPoint] == abandonChan {
+			delete(f.abandonSignals, chanPoint)
+		}
+		f.abandonMtx.Unlock()
+	}()
+
 	f.wg.Add(1)
 	go f.waitForFundingConfirmation(ch, cancelChan, confChan)
 
@@ -2249,6 +2426,9 @@ func (f *Manager) waitForFundingWithTimeout(
 		}
 		return nil, ErrConfirmationTimeout
 
+	case <-abandonChan:
+		return nil, ErrChannelAbandoned
+
 	case <-f.quit:
 		// The fundingManager is shutting down, and will resume wait on
 		// startup.
@@ -2370,7 +2550,7 @@ func (f *Manager) waitForFundingConfirmation(
 	}
 }
 
-// waitForTimeout will close the timeout channel if maxWaitNumBlocksFundingConf
+// waitForTimeout will close the timeout channel if MaxWaitNumBlocksFundingConf
 // has passed from the broadcast height of the given channel. In case of error,
 // the error is sent on timeoutChan. The wait can be canceled by closing the
 // cancelChan.
@@ -2392,7 +2572,8 @@ func (f *Manager) waitForTimeout(completeChan *channeldb.OpenChannel,
 	defer epochClient.Cancel()
 
 	// On block maxHeight we will cancel the funding confirmation wait.
-	maxHeight := completeChan.FundingBroadcastHeight + maxWaitNumBlocksFundingConf
+	maxWait := f.cfg.MaxWaitNumBlocksFundingConf
+	maxHeight := completeChan.FundingBroadcastHeight + maxWait
 	for {
 		select {
 		case epoch, ok := <-epochClient.Epochs:
@@ -2407,8 +2588,7 @@ func (f *Manager) waitForTimeout(completeChan *channeldb.OpenChannel,
 			if uint32(epoch.Height) >= maxHeight {
 				log.Warnf("Waited for %v blocks without "+
 					"seeing funding transaction confirmed,"+
-					" cancelling.",
-					maxWaitNumBlocksFundingConf)
+					" cancelling.", maxWait)
 
 				// Notify the caller of the timeout.
 				close(timeoutChan)
@@ -2492,7 +2672,10 @@ func (f *Manager) handleFundingConfirmation(
 	// transaction label with our short channel ID, which is known now that
 	// our funding transaction has confirmed. We do not label transactions
 	// we did not publish, because our wallet has no knowledge of them.
-	if completeChan.IsInitiator && completeChan.ChanType.HasFundingTx() {
+	// Labels the user set via LabelTransaction are left untouched.
+	if completeChan.IsInitiator && completeChan.ChanType.HasFundingTx() &&
+		!f.hasCustomLabel(completeChan) {
+
 		shortChanID := completeChan.ShortChanID()
 		label := labels.MakeLabel(
 			labels.LabelTypeChannelOpen, &shortChanID,
@@ -2566,6 +2749,14 @@ func (f *Manager) sendFundingLocked(
 		log.Infof("Peer(%x) is online, sending FundingLocked "+
 			"for ChannelID(%v)", peerKey, chanID)
 
+		// If both sides negotiated option_scid_alias, we'll let our
+		// peer know which alias to use when referring to the channel.
+		alias, err := f.localAlias(peer, completeChan, *shortChanID)
+		if err != nil {
+			return err
+		}
+		fundingLockedMsg.AliasScid = alias
+
 		if err := peer.SendMessage(true, fundingLockedMsg); err == nil {
 			// Sending succeeded, we can break out and continue the
 			// funding flow.
@@ -2579,6 +2770,104 @@ func (f *Manager) sendFundingLocked(
 	return nil
 }
 
+// pendingAlias requests a new alias for a channel that is being funded with
+// the given peer. Aliases are only assigned to unannounced channels, as the
+// real short channel id of those isn't meant to be known outside of the
+// channel. If option_scid_alias wasn't negotiated with the peer, nil is
+// returned.
+func (f *Manager) pendingAlias(peer lnpeer.Peer,
+	flags lnwire.FundingFlag) (*lnwire.ShortChannelID, error) {
+
+	if f.cfg.AliasManager == nil || !scidAliasNegotiated(peer) ||
+		flags&lnwire.FFAnnounceChannel != 0 {
+
+		return nil, nil
+	}
+
+	alias, err := f.cfg.AliasManager.RequestAlias()
+	if err != nil {
+		return nil, fmt.Errorf("unable to request alias: %v", err)
+	}
+
+	return &alias, nil
+}
+
+// storePendingAlias stores the alias that was assigned to the channel when its
+// reservation was created, so it can be moved over to the confirmed short
+// channel id once the channel confirms.
+func (f *Manager) storePendingAlias(completeChan *channeldb.OpenChannel,
+	alias *lnwire.ShortChannelID) {
+
+	if alias == nil {
+		return
+	}
+
+	chanID := lnwire.NewChanIDFromOutPoint(&completeChan.FundingOutpoint)
+	err := f.cfg.AliasManager.AddPendingAlias(chanID, *alias)
+	if err != nil {
+		log.Errorf("Unable to store alias %v for ChannelID(%v): %v",
+			alias, chanID, err)
+		return
+	}
+
+	log.Debugf("Assigned alias %v to pending ChannelID(%v)", alias, chanID)
+}
+
+// localAlias returns the alias that we assigned to the channel with the given
+// confirmed short channel id. The pending alias that was assigned when the
+// channel's reservation was created is used if there is one, otherwise a new
+// alias is assigned. If option_scid_alias wasn't negotiated with the peer or
+// the channel is announced, nil is returned.
+func (f *Manager) localAlias(peer lnpeer.Peer,
+	channel *channeldb.OpenChannel,
+	base lnwire.ShortChannelID) (*lnwire.ShortChannelID, error) {
+
+	if f.cfg.AliasManager == nil || !scidAliasNegotiated(peer) ||
+		channel.ChannelFlags&lnwire.FFAnnounceChannel != 0 {
+
+		return nil, nil
+	}
+
+	f.aliasMtx.Lock()
+	defer f.aliasMtx.Unlock()
+
+	if aliases := f.cfg.AliasManager.GetAliases(base); len(aliases) > 0 {
+		return &aliases[0], nil
+	}
+
+	chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+	alias, err := f.cfg.AliasManager.ConfirmPendingAlias(chanID, base)
+	switch {
+	case err == nil:
+		return &alias, nil
+
+	case err != aliasmgr.ErrAliasNotFound:
+		return nil, fmt.Errorf("unable to confirm alias: %v", err)
+	}
+
+	// The channel was funded before we assigned aliases at reservation
+	// time, so we'll assign a new one now.
+	alias, err = f.cfg.AliasManager.RequestAlias()
+	if err != nil {
+		return nil, fmt.Errorf("unable to request alias: %v", err)
+	}
+
+	if err := f.cfg.AliasManager.AddLocalAlias(alias, base); err != nil {
+		return nil, fmt.Errorf("unable to store alias: %v", err)
+	}
+
+	log.Debugf("Assigned alias %v to ChannelID(%v)", alias, base)
+
+	return &alias, nil
+}
+
+// scidAliasNegotiated returns true if both we and the peer signaled support
+// for option_scid_alias.
+func scidAliasNegotiated(peer lnpeer.Peer) bool {
+	return peer.LocalFeatures().HasFeature(lnwire.ScidAliasOptional) &&
+		peer.RemoteFeatures().HasFeature(lnwire.ScidAliasOptional)
+}
+
 // addToRouterGraph sends a ChannelAnnouncement and a ChannelUpdate to the
 // gossiper so that the channel is added to the Router's internal graph.
 // These announcement messages are NOT broadcasted to the greater network,
@@ -2790,6 +3079,30 @@ func (f *Manager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 	return nil
 }
 
+// hasCustomLabel returns true if the funding transaction of the given channel
+// has a label in our wallet other than the default one we set when publishing
+// it, meaning the user relabeled it.
+func (f *Manager) hasCustomLabel(channel *channeldb.OpenChannel) bool {
+	height := int32(channel.ShortChanID().BlockHeight)
+	txns, err := f.cfg.Wallet.ListTransactionDetails(height, height, "")
+	if err != nil {
+		log.Errorf("Unable to fetch funding transaction of "+
+			"ChannelPoint(%v): %v", channel.FundingOutpoint, err)
+		return false
+	}
+
+	defaultLabel := labels.MakeLabel(labels.LabelTypeChannelOpen, nil)
+	for _, tx := range txns {
+		if tx.Hash != channel.FundingOutpoint.Hash {
+			continue
+		}
+
+		return tx.Label != "" && tx.Label != defaultLabel
+	}
+
+	return false
+}
+
 // handleFundingLocked finalizes the channel funding process and enables the
 // channel to enter normal operating mode.
 func (f *Manager) handleFundingLocked(peer lnpeer.Peer,
@@ -2865,6 +3178,27 @@ func (f *Manager) handleFundingLocked(peer lnpeer.Peer,
 		return
 	}
 
+	// If option_scid_alias was negotiated, we'll store the alias that the
+	// peer wants us to use for this channel, and make sure that our own
+	// alias is in place before the link is added to the switch, so HTLCs
+	// sent to it can be forwarded.
+	if msg.AliasScid != nil && scidAliasNegotiated(peer) &&
+		f.cfg.AliasManager != nil {
+
+		err := f.cfg.AliasManager.PutPeerAlias(chanID, *msg.AliasScid)
+		if err != nil {
+			log.Errorf("Unable to store peer alias for "+
+				"ChannelID(%v): %v", chanID, err)
+			return
+		}
+	}
+	_, err = f.localAlias(peer, channel, channel.ShortChanID())
+	if err != nil {
+		log.Errorf("Unable to assign alias to ChannelID(%v): %v",
+			chanID, err)
+		return
+	}
+
 	// The funding locked message contains the next commitment point we'll
 	// need to create the next commitment state for the remote party. So
 	// we'll insert that into the channel now before passing it along to
@@ -3195,6 +3529,8 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 	var (
 		peerKey        = msg.Peer.IdentityKey()
 		localAmt       = msg.LocalFundingAmt
+		maxFundAmt     = msg.FundUpToMaxAmt
+		minFundAmt     = msg.MinFundAmt
 		minHtlcIn      = msg.MinHtlcIn
 		remoteCsvDelay = msg.RemoteCsvDelay
 		maxValue       = msg.MaxValueInFlight
@@ -3208,10 +3544,43 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		maxCSV = f.cfg.MaxLocalCSVDelay
 	}
 
+	// Make sure the channel respects the size limits we configured for
+	// the channels that we open ourselves. If fees are subtracted from
+	// the funding amount, the final capacity will be slightly lower. When
+	// funding up to a maximum amount, the limits are instead applied to
+	// the range the wallet may choose the channel size from.
+	maxOutbound := f.cfg.MaxOutboundChanSize
+	switch {
+	case maxFundAmt > 0:
+		if maxOutbound != 0 && maxFundAmt > maxOutbound {
+			maxFundAmt = maxOutbound
+		}
+		if minFundAmt < f.cfg.MinOutboundChanSize {
+			minFundAmt = f.cfg.MinOutboundChanSize
+		}
+		if minFundAmt > maxFundAmt {
+			msg.Err <- lnwallet.ErrMinFundAmtExceedsMax(
+				minFundAmt, maxFundAmt,
+			)
+			return
+		}
+
+	case maxOutbound != 0 && localAmt > maxOutbound:
+		msg.Err <- lnwallet.ErrChanTooLarge(localAmt, maxOutbound)
+		return
+
+	case localAmt < f.cfg.MinOutboundChanSize:
+		msg.Err <- lnwallet.ErrChanTooSmall(
+			localAmt, f.cfg.MinOutboundChanSize,
+		)
+		return
+	}
+
 	log.Infof("Initiating fundingRequest(local_amt=%v "+
-		"(subtract_fees=%v), push_amt=%v, chain_hash=%v, peer=%x, "+
-		"min_confs=%v)", localAmt, msg.SubtractFees, msg.PushAmt,
-		msg.ChainHash, peerKey.SerializeCompressed(), msg.MinConfs)
+		"(subtract_fees=%v, fund_up_to=%v), push_amt=%v, "+
+		"chain_hash=%v, peer=%x, min_confs=%v)", localAmt,
+		msg.SubtractFees, maxFundAmt, msg.PushAmt, msg.ChainHash,
+		peerKey.SerializeCompressed(), msg.MinConfs)
 
 	// We set the channel flags to indicate whether we want this channel to
 	// be announced to the network.
@@ -3304,6 +3673,10 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		NodeAddr:         msg.Peer.Address(),
 		SubtractFees:     msg.SubtractFees,
 		LocalFundingAmt:  localAmt,
+		FundUpToMaxAmt:   maxFundAmt,
+		MinFundAmt:       minFundAmt,
+		Outpoints:        msg.Outpoints,
+		Account:          msg.Account,
 		RemoteFundingAmt: 0,
 		CommitFeePerKw:   commitFeePerKw,
 		FundingFeePerKw:  msg.FundingFeePerKw,
@@ -3312,6 +3685,8 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		MinConfs:         msg.MinConfs,
 		CommitType:       commitType,
 		ChanFunder:       msg.ChanFunder,
+
+		CoinSelectionStrategy: msg.CoinSelectionStrategy,
 	}
 
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
@@ -3329,6 +3704,40 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 	// SubtractFees=true.
 	capacity := reservation.Capacity()
 
+	// When funding up to a maximum amount, the capacity wasn't known when
+	// the request was validated, so we check the parameters that depend
+	// on it now.
+	if maxFundAmt > 0 {
+		capacityMSat := lnwire.NewMSatFromSatoshis(capacity)
+
+		var err error
+		switch {
+		case msg.PushAmt >= capacityMSat:
+			err = fmt.Errorf("amount pushed to remote peer for "+
+				"initial state must be below the channel "+
+				"capacity %v", capacity)
+
+		case maxValue > capacityMSat:
+			err = fmt.Errorf("remote max value in flight %v "+
+				"cannot be greater than the channel capacity "+
+				"%v", maxValue, capacity)
+
+		case msg.RemoteChanReserve >= capacity/5:
+			err = fmt.Errorf("remote channel reserve %v must be "+
+				"less than 20%% of the channel capacity %v",
+				msg.RemoteChanReserve, capacity)
+		}
+		if err != nil {
+			if err := reservation.Cancel(); err != nil {
+				log.Errorf("unable to cancel reservation: %v",
+					err)
+			}
+
+			msg.Err <- err
+			return
+		}
+	}
+
 	log.Infof("Target commit tx sat/kw for pendingID(%x): %v", chanID,
 		int64(commitFeePerKw))
 
@@ -3353,6 +3762,47 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		maxHtlcs = f.cfg.RequiredRemoteMaxHTLCs(capacity)
 	}
 
+	// Fetch our dust limit which is part of the default channel
+	// constraints, and log it.
+	ourDustLimit := reservation.OurContribution().DustLimit
+
+	log.Infof("Dust limit for pendingID(%x): %v", chanID, ourDustLimit)
+
+	// If no channel reserve was specified, we'll use the current value of
+	// the channel and our default policy to determine the reserve we
+	// require from the remote party.
+	chanReserve := msg.RemoteChanReserve
+	if chanReserve == 0 {
+		chanReserve = f.cfg.RequiredRemoteChanReserve(
+			capacity, ourDustLimit,
+		)
+	}
+
+	// The remote party would reject a reserve below our dust limit, as
+	// their balance could otherwise end up in an output that can't be
+	// relayed.
+	if chanReserve < ourDustLimit {
+		if err := reservation.Cancel(); err != nil {
+			log.Errorf("unable to cancel reservation: %v", err)
+		}
+
+		msg.Err <- fmt.Errorf("channel reserve %v is below our dust "+
+			"limit %v", chanReserve, ourDustLimit)
+		return
+	}
+
+	// If option_scid_alias is used for the channel, we'll assign its
+	// alias right away, so it's known before the channel confirms.
+	alias, err := f.pendingAlias(msg.Peer, channelFlags)
+	if err != nil {
+		if err := reservation.Cancel(); err != nil {
+			log.Errorf("unable to cancel reservation: %v", err)
+		}
+
+		msg.Err <- err
+		return
+	}
+
 	// If a pending channel map for this peer isn't already created, then
 	// we create one, ultimately allowing us to track this pending
 	// reservation within the target peer.
@@ -3363,17 +3813,19 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 	}
 
 	resCtx := &reservationWithCtx{
-		chanAmt:        capacity,
-		remoteCsvDelay: remoteCsvDelay,
-		remoteMinHtlc:  minHtlcIn,
-		remoteMaxValue: maxValue,
-		remoteMaxHtlcs: maxHtlcs,
-		maxLocalCsv:    maxCSV,
-		channelType:    msg.ChannelType,
-		reservation:    reservation,
-		peer:           msg.Peer,
-		updates:        msg.Updates,
-		err:            msg.Err,
+		chanAmt:           capacity,
+		remoteCsvDelay:    remoteCsvDelay,
+		remoteMinHtlc:     minHtlcIn,
+		remoteMaxValue:    maxValue,
+		remoteMaxHtlcs:    maxHtlcs,
+		remoteChanReserve: chanReserve,
+		maxLocalCsv:       maxCSV,
+		channelType:       msg.ChannelType,
+		alias:             alias,
+		reservation:       reservation,
+		peer:              msg.Peer,
+		updates:           msg.Updates,
+		err:               msg.Err,
 	}
 	f.activeReservations[peerIDKey][chanID] = resCtx
 	f.resMtx.Unlock()
@@ -3385,17 +3837,6 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 	// request to the remote peer, kicking off the funding workflow.
 	ourContribution := reservation.OurContribution()
 
-	// Fetch our dust limit which is part of the default channel
-	// constraints, and log it.
-	ourDustLimit := ourContribution.DustLimit
-
-	log.Infof("Dust limit for pendingID(%x): %v", chanID, ourDustLimit)
-
-	// Finally, we'll use the current value of the channels and our default
-	// policy to determine of required commitment constraints for the
-	// remote party.
-	chanReserve := f.cfg.RequiredRemoteChanReserve(capacity, ourDustLimit)
-
 	// When opening a script enforced channel lease, include the required
 	// expiry TLV record in our proposal.
 	var leaseExpiry *lnwire.LeaseExpiry
@@ -3678,6 +4119,26 @@ func (f *Manager) getChannelOpeningState(chanPoint *wire.OutPoint) (
 	return state, &shortChanID, nil
 }
 
+// AbandonChannel stops any funding flow that is still in progress for the
+// channel with the given funding outpoint and removes its opening state from
+// the database. It is used when the channel is abandoned, so that we don't
+// continue to open a channel that no longer exists.
+func (f *Manager) AbandonChannel(chanPoint *wire.OutPoint) error {
+	f.abandonMtx.Lock()
+	if abandonChan, ok := f.abandonSignals[*chanPoint]; ok {
+		close(abandonChan)
+		delete(f.abandonSignals, *chanPoint)
+	}
+	f.abandonMtx.Unlock()
+
+	err := f.deleteChannelOpeningState(chanPoint)
+	if err != nil && err != channeldb.ErrChannelNotFound {
+		return err
+	}
+
+	return nil
+}
+
 // deleteChannelOpeningState removes any state for chanPoint from the database.
 func (f *Manager) deleteChannelOpeningState(chanPoint *wire.OutPoint) error {
 	var outpointBytes bytes.Buffer
@@ -23,6 +23,7 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/aliasmgr"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainreg"
 	"github.com/lightningnetwork/lnd/chanacceptor"
@@ -32,13 +33,17 @@ import (
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/labels"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnpeer"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lntest/mock"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/stretchr/testify/require"
 )
 
@@ -199,6 +204,7 @@ type testNode struct {
 	mockChanEvent   *mockChanEvent
 	testDir         string
 	shutdownChannel chan struct{}
+	localFeatures   []lnwire.FeatureBit
 	remoteFeatures  []lnwire.FeatureBit
 
 	remotePeer  *testNode
@@ -234,7 +240,9 @@ func (n *testNode) QuitSignal() <-chan struct{} {
 }
 
 func (n *testNode) LocalFeatures() *lnwire.FeatureVector {
-	return lnwire.NewFeatureVector(nil, nil)
+	return lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(n.localFeatures...), nil,
+	)
 }
 
 func (n *testNode) RemoteFeatures() *lnwire.FeatureVector {
@@ -553,9 +561,15 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		UpdateLabel: func(chainhash.Hash, string) error {
 			return nil
 		},
-		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
-		ReservationTimeout:    oldCfg.ReservationTimeout,
-		OpenChannelPredicate:  chainedAcceptor,
+		WatchNewChannel:               oldCfg.WatchNewChannel,
+		ReportShortChanID:             oldCfg.ReportShortChanID,
+		NotifyOpenChannelEvent:        oldCfg.NotifyOpenChannelEvent,
+		NotifyPendingOpenChannelEvent: oldCfg.NotifyPendingOpenChannelEvent,
+		ZombieSweeperInterval:         oldCfg.ZombieSweeperInterval,
+		ReservationTimeout:            oldCfg.ReservationTimeout,
+		OpenChannelPredicate:          chainedAcceptor,
+
+		MaxWaitNumBlocksFundingConf: oldCfg.MaxWaitNumBlocksFundingConf,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -825,6 +839,8 @@ func assertFundingMsgSent(t *testing.T, msgChan chan lnwire.Message,
 		ok      bool
 	)
 	switch msgType {
+	case "OpenChannel":
+		sentMsg, ok = msg.(*lnwire.OpenChannel)
 	case "AcceptChannel":
 		sentMsg, ok = msg.(*lnwire.AcceptChannel)
 	case "FundingCreated":
@@ -1592,6 +1608,68 @@ func TestFundingManagerRestartBehavior(t *testing.T) {
 	assertNoChannelState(t, alice, bob, fundingOutPoint)
 }
 
+// TestFundingManagerRestartBeforeConfirmation checks that a funding flow that
+// was interrupted after the funding transaction was broadcast, but before it
+// confirmed, is resumed from the persisted channel state on restart.
+func TestFundingManagerRestartBeforeConfirmation(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	localAmt := btcutil.Amount(500000)
+	pushAmt := btcutil.Amount(0)
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, localAmt, pushAmt, 1, updateChan, true,
+	)
+
+	// Alice restarts before the funding transaction confirms. As she
+	// initiated the channel, she should rebroadcast the funding
+	// transaction in case it didn't make it to the network.
+	recreateAliceFundingManager(t, alice)
+
+	select {
+	case tx := <-alice.publTxChan:
+		require.Equal(t, fundingTx.TxHash(), tx.TxHash())
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not rebroadcast the funding tx")
+	}
+
+	// Both parties still consider the channel pending.
+	assertNumPendingChannelsRemains(t, alice, 1)
+	assertNumPendingChannelsRemains(t, bob, 1)
+
+	// Once the funding transaction confirms, the restarted funding manager
+	// should continue the funding flow where it left off.
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+
+	fundingLockedAlice := assertFundingMsgSent(
+		t, alice.msgChan, "FundingLocked",
+	).(*lnwire.FundingLocked)
+	fundingLockedBob := assertFundingMsgSent(
+		t, bob.msgChan, "FundingLocked",
+	).(*lnwire.FundingLocked)
+
+	assertDatabaseState(t, alice, fundingOutPoint, fundingLockedSent)
+	assertDatabaseState(t, bob, fundingOutPoint, fundingLockedSent)
+
+	// Exchange the fundingLocked messages, after which both parties
+	// consider the channel open.
+	alice.fundingMgr.ProcessFundingMsg(fundingLockedBob, bob)
+	bob.fundingMgr.ProcessFundingMsg(fundingLockedAlice, alice)
+
+	assertHandleFundingLocked(t, alice, bob)
+}
+
 // TestFundingManagerOfflinePeer checks that the fundingManager waits for the
 // server to notify when the peer comes online, in case sending the
 // fundingLocked message fails the first time.
@@ -1979,14 +2057,16 @@ func TestFundingManagerFundingTimeout(t *testing.T) {
 	// We expect Bob to forget the channel after 2016 blocks (2 weeks), so
 	// mine 2016-1, and check that it is still pending.
 	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf - 1,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf - 1,
 	}
 
 	// Bob should still be waiting for the channel to open.
 	assertNumPendingChannelsRemains(t, bob, 1)
 
 	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf,
 	}
 
 	// Bob should have sent an Error message to Alice.
@@ -1996,6 +2076,41 @@ func TestFundingManagerFundingTimeout(t *testing.T) {
 	assertNumPendingChannelsBecomes(t, bob, 0)
 }
 
+// TestFundingManagerCustomFundingTimeout checks that the number of blocks we
+// wait for the funding transaction of a remotely initiated channel to confirm
+// can be configured.
+func TestFundingManagerCustomFundingTimeout(t *testing.T) {
+	t.Parallel()
+
+	const maxWait = 10
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.MaxWaitNumBlocksFundingConf = maxWait
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	_, _ = openChannel(t, alice, bob, 500000, 0, 1, updateChan, true)
+	assertNumPendingChannelsBecomes(t, bob, 1)
+
+	// Bob should still be waiting for the channel one block before the
+	// configured timeout.
+	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
+		Height: fundingBroadcastHeight + maxWait - 1,
+	}
+	assertNumPendingChannelsRemains(t, bob, 1)
+
+	// Once the timeout is reached, Bob should forget the channel.
+	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
+		Height: fundingBroadcastHeight + maxWait,
+	}
+	assertErrorSent(t, bob.msgChan)
+	assertNumPendingChannelsBecomes(t, bob, 0)
+}
+
 // TestFundingManagerFundingNotTimeoutInitiator checks that if the user was
 // the channel initiator, that it does not timeout when the lnd restarts.
 func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
@@ -2031,13 +2146,16 @@ func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
 		t.Fatalf("alice did not publish funding tx")
 	}
 
-	// Increase the height to 1 minus the maxWaitNumBlocksFundingConf height.
+	// Increase the height to 1 minus the
+	// DefaultMaxWaitNumBlocksFundingConf height.
 	alice.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf - 1,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf - 1,
 	}
 
 	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf - 1,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf - 1,
 	}
 
 	// Assert both and Alice and Bob still have 1 pending channels.
@@ -2045,13 +2163,16 @@ func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
 
 	assertNumPendingChannelsRemains(t, bob, 1)
 
-	// Increase both Alice and Bob to maxWaitNumBlocksFundingConf height.
+	// Increase both Alice and Bob to DefaultMaxWaitNumBlocksFundingConf
+	// height.
 	alice.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf,
 	}
 
 	bob.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
-		Height: fundingBroadcastHeight + maxWaitNumBlocksFundingConf,
+		Height: fundingBroadcastHeight +
+			DefaultMaxWaitNumBlocksFundingConf,
 	}
 
 	// Since Alice was the initiator, the channel should not have timed out.
@@ -2064,6 +2185,54 @@ func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
 	assertNumPendingChannelsBecomes(t, bob, 0)
 }
 
+// TestFundingManagerAbandonPendingChannel checks that abandoning a channel
+// whose funding transaction hasn't confirmed yet stops the funding manager from
+// advancing it any further.
+func TestFundingManagerAbandonPendingChannel(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true,
+	)
+
+	abandonSignalsLen := func() int {
+		alice.fundingMgr.abandonMtx.Lock()
+		defer alice.fundingMgr.abandonMtx.Unlock()
+
+		return len(alice.fundingMgr.abandonSignals)
+	}
+
+	// Wait for Alice to start waiting for the funding confirmation.
+	require.Eventually(t, func() bool {
+		return abandonSignalsLen() == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	// Abandon the channel, which should stop Alice from waiting for the
+	// funding transaction to confirm.
+	require.NoError(t, alice.fundingMgr.AbandonChannel(fundingOutPoint))
+	require.Zero(t, abandonSignalsLen())
+
+	// Abandoning a channel the funding manager doesn't know about is a
+	// no-op.
+	require.NoError(t, alice.fundingMgr.AbandonChannel(fundingOutPoint))
+
+	// Even if the funding transaction confirms now, Alice should neither
+	// send FundingLocked nor store any opening state for the channel.
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	assertErrorNotSent(t, alice.msgChan)
+	assertErrChannelNotFound(t, alice, fundingOutPoint)
+}
+
 // TestFundingManagerReceiveFundingLockedTwice checks that the fundingManager
 // continues to operate as expected in case we receive a duplicate fundingLocked
 // message.
@@ -2451,6 +2620,90 @@ func TestFundingManagerPrivateChannel(t *testing.T) {
 	assertNoChannelState(t, alice, bob, fundingOutPoint)
 }
 
+// TestFundingManagerScidAlias asserts that the alias of a private channel is
+// assigned when its reservation is created, and that it's used in the
+// FundingLocked message once the channel confirms.
+func TestFundingManagerScidAlias(t *testing.T) {
+	t.Parallel()
+
+	var aliasMgrs []*aliasmgr.Manager
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		tempDir, err := ioutil.TempDir("", "aliasmgr")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			os.RemoveAll(tempDir)
+		})
+
+		db, err := kvdb.Create(
+			kvdb.BoltBackendName,
+			filepath.Join(tempDir, "alias.db"), true,
+			kvdb.DefaultDBTimeout,
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			db.Close()
+		})
+
+		aliasMgr, err := aliasmgr.NewManager(db)
+		require.NoError(t, err)
+
+		cfg.AliasManager = aliasMgr
+		aliasMgrs = append(aliasMgrs, aliasMgr)
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	for _, node := range []*testNode{alice, bob} {
+		node.localFeatures = []lnwire.FeatureBit{
+			lnwire.ScidAliasOptional,
+		}
+		node.remoteFeatures = []lnwire.FeatureBit{
+			lnwire.ScidAliasOptional,
+		}
+	}
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Run through the process of opening a private channel, up until the
+	// funding transaction is broadcasted.
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, false,
+	)
+
+	// Both sides should already have handed out an alias for the channel
+	// before it confirmed, so the next alias they hand out is the second
+	// one.
+	for _, aliasMgr := range aliasMgrs {
+		nextAlias, err := aliasMgr.RequestAlias()
+		require.NoError(t, err)
+		require.NotEqual(t, aliasmgr.StartingAlias, nextAlias)
+	}
+
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+
+	assertMarkedOpen(t, alice, bob, fundingOutPoint)
+
+	// The FundingLocked messages should carry the aliases that were
+	// assigned when the reservations were created.
+	fundingLockedAlice := assertFundingMsgSent(
+		t, alice.msgChan, "FundingLocked",
+	).(*lnwire.FundingLocked)
+	fundingLockedBob := assertFundingMsgSent(
+		t, bob.msgChan, "FundingLocked",
+	).(*lnwire.FundingLocked)
+
+	for _, msg := range []*lnwire.FundingLocked{
+		fundingLockedAlice, fundingLockedBob,
+	} {
+		require.NotNil(t, msg.AliasScid)
+		require.Equal(t, aliasmgr.StartingAlias, *msg.AliasScid)
+	}
+}
+
 // TestFundingManagerPrivateRestart tests that the privacy guarantees granted
 // by the private channel persist even on restart. This means that the
 // announcement signatures nor the node announcement messages are sent upon
@@ -2599,6 +2852,7 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 	const minHtlcIn = 1234
 	const maxValueInFlight = 50000
 	const fundingAmt = 5000000
+	const chanReserve = 100000
 
 	// We will consume the channel updates as we go, so no buffering is
 	// needed.
@@ -2612,17 +2866,18 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 	// workflow.
 	errChan := make(chan error, 1)
 	initReq := &InitFundingMsg{
-		Peer:             bob,
-		TargetPubkey:     bob.privKey.PubKey(),
-		ChainHash:        *fundingNetParams.GenesisHash,
-		LocalFundingAmt:  localAmt,
-		PushAmt:          lnwire.NewMSatFromSatoshis(pushAmt),
-		Private:          false,
-		MaxValueInFlight: maxValueInFlight,
-		MinHtlcIn:        minHtlcIn,
-		RemoteCsvDelay:   csvDelay,
-		Updates:          updateChan,
-		Err:              errChan,
+		Peer:              bob,
+		TargetPubkey:      bob.privKey.PubKey(),
+		ChainHash:         *fundingNetParams.GenesisHash,
+		LocalFundingAmt:   localAmt,
+		PushAmt:           lnwire.NewMSatFromSatoshis(pushAmt),
+		Private:           false,
+		MaxValueInFlight:  maxValueInFlight,
+		MinHtlcIn:         minHtlcIn,
+		RemoteCsvDelay:    csvDelay,
+		RemoteChanReserve: chanReserve,
+		Updates:           updateChan,
+		Err:               errChan,
 	}
 
 	alice.fundingMgr.InitFundingWorkflow(initReq)
@@ -2667,6 +2922,10 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 			maxValueInFlight, openChannelReq.MaxValueInFlight)
 	}
 
+	// Check that the custom channel reserve is sent as part of
+	// OpenChannel.
+	require.EqualValues(t, chanReserve, openChannelReq.ChannelReserve)
+
 	chanID := openChannelReq.PendingChannelID
 
 	// Let Bob handle the init message.
@@ -2790,6 +3049,10 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Bob should be required to keep the custom channel reserve.
+	theirReserve := resCtx.reservation.TheirContribution().ChanReserve
+	require.EqualValues(t, chanReserve, theirReserve)
+
 	// Also make sure the parameters are properly set on Bob's end.
 	resCtx, err = bob.fundingMgr.getReservationCtx(alicePubKey, chanID)
 	if err != nil {
@@ -2808,6 +3071,9 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		maxValueInFlight, maxValueAcceptChannel); err != nil {
 		t.Fatal(err)
 	}
+
+	ourReserve := resCtx.reservation.OurContribution().ChanReserve
+	require.EqualValues(t, chanReserve, ourReserve)
 	// Give the message to Bob.
 	bob.fundingMgr.ProcessFundingMsg(fundingCreated, alice)
 
@@ -3114,6 +3380,119 @@ func TestFundingManagerRejectPush(t *testing.T) {
 	}
 }
 
+// TestFundingManagerRejectUnknownPeers checks behaviour of 'rejectunknownpeers'
+// option, namely that non-allowed peers without an open channel can't open
+// channels to us.
+func TestFundingManagerRejectUnknownPeers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		allowedPeers map[route.Vertex]struct{}
+		expectReject bool
+	}{
+		{
+			name:         "unknown peer",
+			expectReject: true,
+		},
+		{
+			name: "allowed peer",
+			allowedPeers: map[route.Vertex]struct{}{
+				route.NewVertex(alicePubKey): {},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			alice, bob := setupFundingManagers(
+				t, func(cfg *Config) {
+					cfg.RejectUnknownPeers = true
+					cfg.AllowedPeers = test.allowedPeers
+				},
+			)
+			defer tearDownFundingManagers(t, alice, bob)
+
+			updateChan := make(chan *lnrpc.OpenStatusUpdate)
+			errChan := make(chan error, 1)
+			initReq := &InitFundingMsg{
+				Peer:            bob,
+				TargetPubkey:    bob.privKey.PubKey(),
+				ChainHash:       *fundingNetParams.GenesisHash,
+				LocalFundingAmt: 500000,
+				PushAmt:         lnwire.NewMSatFromSatoshis(0),
+				Updates:         updateChan,
+				Err:             errChan,
+			}
+			alice.fundingMgr.InitFundingWorkflow(initReq)
+
+			// Let Bob handle the OpenChannel message of Alice,
+			// with whom he doesn't have any channels yet.
+			openChannelReq := expectOpenChannelMsg(
+				t, alice.msgChan,
+			)
+			bob.fundingMgr.ProcessFundingMsg(openChannelReq, alice)
+
+			if !test.expectReject {
+				assertFundingMsgSent(
+					t, bob.msgChan, "AcceptChannel",
+				)
+				return
+			}
+
+			err := assertFundingMsgSent(
+				t, bob.msgChan, "Error",
+			).(*lnwire.Error)
+			require.Contains(
+				t, err.Error(),
+				"channels from unknown peers are disabled",
+			)
+		})
+	}
+}
+
+// TestFundingManagerRejectLowCommitFee ensures that we reject a funding
+// proposal whose commitment fee rate is below the minimum relay fee rate.
+func TestFundingManagerRejectLowCommitFee(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Create a funding request and start the workflow.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &InitFundingMsg{
+		Peer:            bob,
+		TargetPubkey:    bob.privKey.PubKey(),
+		ChainHash:       *fundingNetParams.GenesisHash,
+		LocalFundingAmt: 500000,
+		Private:         true,
+		Updates:         updateChan,
+		Err:             errChan,
+	}
+
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+
+	// Lower the proposed fee rate below the floor before letting Bob
+	// handle the message.
+	openChannelReq.FeePerKiloWeight = uint32(chainfee.FeePerKwFloor - 1)
+	bob.fundingMgr.ProcessFundingMsg(openChannelReq, alice)
+
+	// Bob should reject the channel.
+	err := assertFundingMsgSent(t, bob.msgChan, "Error").(*lnwire.Error)
+	require.Contains(t, err.Error(), "below minimum fee rate")
+}
+
 // TestFundingManagerMaxConfs ensures that we don't accept a funding proposal
 // that proposes a MinAcceptDepth greater than the maximum number of
 // confirmations we're willing to accept.
@@ -3468,6 +3847,196 @@ func TestMaxChannelSizeConfig(t *testing.T) {
 	assertErrorSent(t, bob.msgChan)
 }
 
+// TestOutboundChannelSizeConfig tests that the funding manager refuses to
+// open channels outside of the configured outbound channel size limits.
+func TestOutboundChannelSizeConfig(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.MinOutboundChanSize = 100000
+		cfg.MaxOutboundChanSize = 200000
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	tests := []struct {
+		name        string
+		amt         btcutil.Amount
+		expectedErr error
+	}{
+		{
+			name: "below minimum",
+			amt:  99999,
+			expectedErr: lnwallet.ErrChanTooSmall(
+				99999, 100000,
+			),
+		},
+		{
+			name: "above maximum",
+			amt:  200001,
+			expectedErr: lnwallet.ErrChanTooLarge(
+				200001, 200000,
+			),
+		},
+	}
+
+	for _, test := range tests {
+		updateChan := make(chan *lnrpc.OpenStatusUpdate)
+		errChan := make(chan error, 1)
+		initReq := &InitFundingMsg{
+			Peer:            bob,
+			TargetPubkey:    bob.privKey.PubKey(),
+			ChainHash:       *fundingNetParams.GenesisHash,
+			LocalFundingAmt: test.amt,
+			PushAmt:         lnwire.NewMSatFromSatoshis(0),
+			Updates:         updateChan,
+			Err:             errChan,
+		}
+
+		// Alice should refuse to start the funding flow, without
+		// sending anything to Bob.
+		alice.fundingMgr.InitFundingWorkflow(initReq)
+
+		select {
+		case err := <-errChan:
+			require.Equal(t, test.expectedErr, err, test.name)
+
+		case <-alice.msgChan:
+			t.Fatalf("%v: alice sent a message", test.name)
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%v: alice did not reject the channel",
+				test.name)
+		}
+	}
+
+	// A channel within the limits should be opened as usual.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &InitFundingMsg{
+		Peer:            bob,
+		TargetPubkey:    bob.privKey.PubKey(),
+		ChainHash:       *fundingNetParams.GenesisHash,
+		LocalFundingAmt: 150000,
+		PushAmt:         lnwire.NewMSatFromSatoshis(0),
+		Updates:         updateChan,
+		Err:             errChan,
+	}
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+	expectOpenChannelMsg(t, alice.msgChan)
+}
+
+// TestFundingManagerFundMax tests that a channel funded up to a maximum amount
+// is capped by the configured outbound channel size limit.
+func TestFundingManagerFundMax(t *testing.T) {
+	t.Parallel()
+
+	const maxOutbound = 500000
+
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.MaxOutboundChanSize = maxOutbound
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// The wallet of Alice holds more than the maximum amount, so the
+	// channel should be funded with exactly the outbound limit.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &InitFundingMsg{
+		Peer:           bob,
+		TargetPubkey:   bob.privKey.PubKey(),
+		ChainHash:      *fundingNetParams.GenesisHash,
+		FundUpToMaxAmt: MaxBtcFundingAmount,
+		MinFundAmt:     MinChanFundingSize,
+		PushAmt:        lnwire.NewMSatFromSatoshis(0),
+		Updates:        updateChan,
+		Err:            errChan,
+	}
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+
+	openChanMsg := expectOpenChannelMsg(t, alice.msgChan)
+	require.EqualValues(t, maxOutbound, openChanMsg.FundingAmount)
+
+	// A minimum amount above the outbound limit can never be satisfied.
+	errChan = make(chan error, 1)
+	initReq = &InitFundingMsg{
+		Peer:           bob,
+		TargetPubkey:   bob.privKey.PubKey(),
+		ChainHash:      *fundingNetParams.GenesisHash,
+		FundUpToMaxAmt: MaxBtcFundingAmount,
+		MinFundAmt:     maxOutbound + 1,
+		PushAmt:        lnwire.NewMSatFromSatoshis(0),
+		Updates:        updateChan,
+		Err:            errChan,
+	}
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+
+	select {
+	case err := <-errChan:
+		expectedErr := lnwallet.ErrMinFundAmtExceedsMax(
+			maxOutbound+1, maxOutbound,
+		)
+		require.Equal(t, expectedErr, err)
+
+	case <-alice.msgChan:
+		t.Fatalf("alice sent a message")
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not reject the channel")
+	}
+}
+
+// TestFundingManagerFundFromAccount tests that the coins funding a channel are
+// selected from the wallet account given by the caller.
+func TestFundingManagerFundFromAccount(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// The account we fund the channel from doesn't hold any coins, while
+	// the default account of Alice has plenty.
+	const account = "empty"
+	walletController := alice.fundingMgr.cfg.Wallet.WalletController
+	wc, ok := walletController.(*mock.WalletController)
+	require.True(t, ok)
+	wc.AccountUtxos = map[string][]*lnwallet.Utxo{
+		account: {},
+	}
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &InitFundingMsg{
+		Peer:            bob,
+		TargetPubkey:    bob.privKey.PubKey(),
+		ChainHash:       *fundingNetParams.GenesisHash,
+		LocalFundingAmt: 500000,
+		PushAmt:         lnwire.NewMSatFromSatoshis(0),
+		Account:         account,
+		Updates:         updateChan,
+		Err:             errChan,
+	}
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+
+	select {
+	case err := <-errChan:
+		var insufficientErr *chanfunding.ErrInsufficientFunds
+		require.ErrorAs(t, err, &insufficientErr)
+
+	case <-alice.msgChan:
+		t.Fatalf("alice sent a message")
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not fail the funding flow")
+	}
+
+	// Funding the channel from the default account succeeds.
+	initReq.Account = ""
+	initReq.Err = make(chan error, 1)
+	alice.fundingMgr.InitFundingWorkflow(initReq)
+
+	expectOpenChannelMsg(t, alice.msgChan)
+}
+
 // TestWumboChannelConfig tests that the funding manager will respect the wumbo
 // channel config param when creating or accepting new channels.
 func TestWumboChannelConfig(t *testing.T) {
@@ -3684,3 +4253,119 @@ func testUpfrontFailure(t *testing.T, pkscript []byte, expectErr bool) {
 		require.True(t, ok, "did not receive AcceptChannel")
 	}
 }
+
+// TestFundingManagerFundingTxLabel tests that the label of the funding
+// transaction is replaced by one containing the short channel ID once it
+// confirms, unless the user set a custom label on it.
+func TestFundingManagerFundingTxLabel(t *testing.T) {
+	t.Parallel()
+
+	defaultLabel := labels.MakeLabel(labels.LabelTypeChannelOpen, nil)
+
+	testCases := []struct {
+		name      string
+		label     string
+		lookupErr error
+		relabel   bool
+	}{
+		{
+			name:  "custom label kept",
+			label: "my channel",
+		},
+		{
+			name:    "default label replaced",
+			label:   defaultLabel,
+			relabel: true,
+		},
+		{
+			name:      "lookup error relabels",
+			label:     "my channel",
+			lookupErr: errors.New("lookup failed"),
+			relabel:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testFundingTxLabel(t, tc.label, tc.lookupErr, tc.relabel)
+		})
+	}
+}
+
+// testFundingTxLabel opens a channel whose funding transaction has the given
+// label in Alice's wallet, and asserts whether it is relabeled on
+// confirmation.
+func testFundingTxLabel(t *testing.T, label string, lookupErr error,
+	relabel bool) {
+
+	type labelUpdate struct {
+		hash  chainhash.Hash
+		label string
+	}
+	labelChan := make(chan labelUpdate, 2)
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.UpdateLabel = func(hash chainhash.Hash, label string) error {
+			labelChan <- labelUpdate{hash: hash, label: label}
+			return nil
+		}
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true,
+	)
+
+	// The funding transaction is now known to Alice's wallet with the
+	// given label.
+	walletController := alice.fundingMgr.cfg.Wallet.WalletController
+	wc, ok := walletController.(*mock.WalletController)
+	require.True(t, ok)
+	wc.TransactionDetails = []*lnwallet.TransactionDetail{{
+		Hash:  fundingOutPoint.Hash,
+		Label: label,
+	}}
+	wc.TransactionDetailsErr = lookupErr
+
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	assertMarkedOpen(t, alice, bob, fundingOutPoint)
+
+	// The label is updated before Alice sends FundingLocked.
+	assertFundingMsgSent(t, alice.msgChan, "FundingLocked")
+	assertFundingMsgSent(t, bob.msgChan, "FundingLocked")
+
+	if !relabel {
+		select {
+		case update := <-labelChan:
+			t.Fatalf("unexpected label update: %v", update.label)
+		default:
+		}
+
+		return
+	}
+
+	channelDB := alice.fundingMgr.cfg.Wallet.Cfg.Database
+	channel, err := channelDB.FetchChannel(nil, *fundingOutPoint)
+	require.NoError(t, err)
+	shortChanID := channel.ShortChanID()
+
+	select {
+	case update := <-labelChan:
+		require.Equal(t, fundingOutPoint.Hash, update.hash)
+		require.Equal(t, labels.MakeLabel(
+			labels.LabelTypeChannelOpen, &shortChanID,
+		), update.label)
+
+	default:
+		t.Fatalf("funding transaction was not relabeled")
+	}
+}
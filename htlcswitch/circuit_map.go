@@ -702,10 +702,13 @@ func (cm *circuitMap) TrimOpenCircuits(chanID lnwire.ShortChannelID,
 			break
 		}
 
+		// The circuit must be removed from the hash index before its
+		// keystone is cleared, since the index is keyed by the
+		// outgoing circuit key.
+		cm.removeCircuitFromHashIndex(circuit)
 		circuit.Outgoing = nil
 		delete(cm.opened, outKey)
 		trimmedOutKeys = append(trimmedOutKeys, outKey)
-		cm.removeCircuitFromHashIndex(circuit)
 	}
 	cm.mtx.Unlock()
 
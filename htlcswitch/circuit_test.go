@@ -1391,3 +1391,85 @@ func TestCircuitMapDeleteOpenCircuit(t *testing.T) {
 			circuit2, nil)
 	}
 }
+
+// TestCircuitMapTrimOpenCircuitsHashIndex asserts that trimming an open circuit
+// also removes it from the payment hash index, such that a later circuit that
+// reuses the same outgoing circuit key isn't returned for the old payment
+// hash.
+func TestCircuitMapTrimOpenCircuitsHashIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		chan2 = lnwire.NewShortChanIDFromInt(2)
+	)
+
+	_, circuitMap := newCircuitMap(t)
+
+	outKey := htlcswitch.CircuitKey{
+		ChanID: chan2,
+		HtlcID: 0,
+	}
+
+	// Commit and open a circuit for the first payment hash.
+	circuit1 := &htlcswitch.PaymentCircuit{
+		Incoming: htlcswitch.CircuitKey{
+			ChanID: chan1,
+			HtlcID: 1,
+		},
+		PaymentHash:    hash1,
+		ErrorEncrypter: htlcswitch.NewMockObfuscator(),
+	}
+	if _, err := circuitMap.CommitCircuits(circuit1); err != nil {
+		t.Fatalf("failed to commit circuit: %v", err)
+	}
+	err := circuitMap.OpenCircuits(htlcswitch.Keystone{
+		InKey:  circuit1.Incoming,
+		OutKey: outKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to open circuit: %v", err)
+	}
+
+	// Trim the circuit, returning it to a half-open state.
+	if err := circuitMap.TrimOpenCircuits(chan2, 0); err != nil {
+		t.Fatalf("unable to trim circuits: %v", err)
+	}
+	trimmed := circuitMap.LookupByPaymentHash(hash1)
+	if len(trimmed) != 0 {
+		t.Fatalf("expected no circuits for trimmed hash, got %d",
+			len(trimmed))
+	}
+
+	// Now open a circuit with a different payment hash that reuses the
+	// same outgoing circuit key.
+	circuit2 := &htlcswitch.PaymentCircuit{
+		Incoming: htlcswitch.CircuitKey{
+			ChanID: chan1,
+			HtlcID: 2,
+		},
+		PaymentHash:    hash2,
+		ErrorEncrypter: htlcswitch.NewMockObfuscator(),
+	}
+	if _, err := circuitMap.CommitCircuits(circuit2); err != nil {
+		t.Fatalf("failed to commit circuit: %v", err)
+	}
+	err = circuitMap.OpenCircuits(htlcswitch.Keystone{
+		InKey:  circuit2.Incoming,
+		OutKey: outKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to open circuit: %v", err)
+	}
+
+	// The new circuit must only be found under its own payment hash.
+	trimmed = circuitMap.LookupByPaymentHash(hash1)
+	if len(trimmed) != 0 {
+		t.Fatalf("expected no circuits for trimmed hash, got %d",
+			len(trimmed))
+	}
+	circuits := circuitMap.LookupByPaymentHash(hash2)
+	if len(circuits) != 1 || circuits[0] != circuit2 {
+		t.Fatalf("expected to find new circuit by its payment hash")
+	}
+}
@@ -180,6 +180,12 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte,
 			return nil, lnwire.CodeInvalidOnionHmac
 		case sphinx.ErrInvalidOnionKey:
 			return nil, lnwire.CodeInvalidOnionKey
+
+		// A replayed packet is failed with a temporary channel failure,
+		// matching the treatment of replays in DecodeHopIterators.
+		case sphinx.ErrReplayedPacket:
+			log.Errorf("unable to process onion packet: %v", err)
+			return nil, lnwire.CodeTemporaryChannelFailure
 		default:
 			log.Errorf("unable to process onion packet: %v", err)
 			return nil, lnwire.CodeInvalidOnionKey
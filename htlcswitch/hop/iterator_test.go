@@ -5,11 +5,15 @@ import (
 	"encoding/binary"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btclog"
 	"github.com/davecgh/go-spew/spew"
 	sphinx "github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSphinxHopIteratorForwardingInstructions tests that we're able to
@@ -99,3 +103,62 @@ func TestSphinxHopIteratorForwardingInstructions(t *testing.T) {
 		}
 	}
 }
+
+// TestDecodeHopIteratorReplay tests that a replayed onion packet is rejected
+// with a temporary channel failure, while the first decoding succeeds.
+func TestDecodeHopIteratorReplay(t *testing.T) {
+	// The package logger is only set by the parent package, so we disable
+	// logging explicitly for this test.
+	UseLogger(btclog.Disabled)
+
+	nodeKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	router := sphinx.NewRouter(
+		&sphinx.PrivKeyECDH{PrivKey: nodeKey},
+		&chaincfg.RegressionNetParams, sphinx.NewMemoryReplayLog(),
+	)
+	processor := NewOnionProcessor(router)
+	require.NoError(t, processor.Start())
+	defer func() {
+		require.NoError(t, processor.Stop())
+	}()
+
+	// Construct a single hop onion packet that is destined for our node.
+	hopPayload, err := sphinx.NewHopPayload(&sphinx.HopData{
+		ForwardAmount: 100000,
+		OutgoingCltv:  4343,
+	}, nil)
+	require.NoError(t, err)
+
+	var path sphinx.PaymentPath
+	path[0] = sphinx.OnionHop{
+		NodePub:    *nodeKey.PubKey(),
+		HopPayload: hopPayload,
+	}
+
+	sessionKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	rHash := bytes.Repeat([]byte{1}, 32)
+	onionPkt, err := sphinx.NewOnionPacket(
+		&path, sessionKey, rHash, sphinx.DeterministicPacketFiller,
+	)
+	require.NoError(t, err)
+
+	var onionBlob bytes.Buffer
+	require.NoError(t, onionPkt.Encode(&onionBlob))
+
+	// The first time the packet is processed, it is accepted.
+	iterator, failCode := processor.DecodeHopIterator(
+		bytes.NewReader(onionBlob.Bytes()), rHash, 100,
+	)
+	require.Equal(t, lnwire.CodeNone, failCode)
+	require.NotNil(t, iterator)
+
+	// Processing the same packet again is detected as a replay.
+	_, failCode = processor.DecodeHopIterator(
+		bytes.NewReader(onionBlob.Bytes()), rHash, 100,
+	)
+	require.Equal(t, lnwire.CodeTemporaryChannelFailure, failCode)
+}
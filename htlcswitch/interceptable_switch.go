@@ -4,12 +4,16 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/htlcswitch/hop"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/queue"
 )
 
 var (
@@ -49,16 +53,65 @@ type InterceptableSwitch struct {
 	// interceptor is connected.
 	requireInterceptor bool
 
+	// notifier is used to receive block epochs, so that held forwards can
+	// be failed back before their incoming htlc expires.
+	notifier chainntnfs.ChainNotifier
+
+	// cltvRejectDelta is the number of blocks before the incoming expiry of
+	// an htlc at which we no longer hold it, but fail it back instead.
+	cltvRejectDelta uint32
+
+	// currentHeight is the currently best known height.
+	currentHeight int32
+
 	// interceptor is the handler for intercepted packets.
 	interceptor ForwardInterceptor
 
 	// holdForwards keeps track of outstanding intercepted forwards.
 	holdForwards map[channeldb.CircuitKey]InterceptedForward
 
+	// maxHoldTime is the maximum duration that a forward may be held. A
+	// zero value disables the limit.
+	maxHoldTime time.Duration
+
+	// chanMaxHoldTimes overrides maxHoldTime for the forwards that arrive
+	// over the given channels.
+	chanMaxHoldTimes map[lnwire.ShortChannelID]time.Duration
+
+	// clock is used to determine when held forwards exceed their hold
+	// time limit.
+	clock clock.Clock
+
+	// holdLimitHeap orders the held forwards that have a hold time limit
+	// by the time at which the limit is exceeded.
+	holdLimitHeap *queue.PriorityQueue
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
 
+// holdLimitEvent describes the moment at which a held forward exceeds its hold
+// time limit.
+type holdLimitEvent struct {
+	// key is the incoming circuit key of the held forward.
+	key channeldb.CircuitKey
+
+	// fwd is the held forward. It's used to detect whether the forward
+	// was resolved and held again since the event was scheduled.
+	fwd InterceptedForward
+
+	// releaseTime is the time at which the forward is failed back.
+	releaseTime time.Time
+}
+
+// Less is used to order PriorityQueueItem's by their release time such that
+// items with the older release time are at the top of the queue.
+//
+// NOTE: Part of the queue.PriorityQueueItem interface.
+func (e *holdLimitEvent) Less(other queue.PriorityQueueItem) bool {
+	return e.releaseTime.Before(other.(*holdLimitEvent).releaseTime)
+}
+
 type interceptedPackets struct {
 	packets  []*htlcPacket
 	linkQuit chan struct{}
@@ -105,17 +158,63 @@ type fwdResolution struct {
 	errChan    chan error
 }
 
+// InterceptableSwitchConfig contains the configuration of an
+// InterceptableSwitch.
+type InterceptableSwitchConfig struct {
+	// Switch is a reference to the actual switch implementation that
+	// packets get sent to on resume.
+	Switch *Switch
+
+	// Notifier is an instance of a chain notifier that we'll use to signal
+	// the switch when a new block has arrived.
+	Notifier chainntnfs.ChainNotifier
+
+	// CltvRejectDelta defines the number of blocks before the expiry of
+	// the incoming htlc at which a held forward is failed back
+	// automatically. This prevents the incoming channel from being force
+	// closed while an interceptor holds on to the htlc.
+	CltvRejectDelta uint32
+
+	// RequireInterceptor indicates whether processing should block if no
+	// interceptor is connected.
+	RequireInterceptor bool
+
+	// MaxHtlcHoldTime is the maximum duration that a forward may be held
+	// by the interceptor before it's failed back. A zero value disables
+	// the limit.
+	MaxHtlcHoldTime time.Duration
+
+	// ChanMaxHtlcHoldTimes overrides MaxHtlcHoldTime for the forwards
+	// that arrive over the given channels.
+	ChanMaxHtlcHoldTimes map[lnwire.ShortChannelID]time.Duration
+
+	// Clock is the clock used to enforce the hold time limits. If nil,
+	// the default clock is used.
+	Clock clock.Clock
+}
+
 // NewInterceptableSwitch returns an instance of InterceptableSwitch.
-func NewInterceptableSwitch(s *Switch,
-	requireInterceptor bool) *InterceptableSwitch {
+func NewInterceptableSwitch(
+	cfg *InterceptableSwitchConfig) *InterceptableSwitch {
+
+	holdClock := cfg.Clock
+	if holdClock == nil {
+		holdClock = clock.NewDefaultClock()
+	}
 
 	return &InterceptableSwitch{
-		htlcSwitch:              s,
+		htlcSwitch:              cfg.Switch,
 		intercepted:             make(chan *interceptedPackets),
 		interceptorRegistration: make(chan ForwardInterceptor),
 		holdForwards:            make(map[channeldb.CircuitKey]InterceptedForward),
 		resolutionChan:          make(chan *fwdResolution),
-		requireInterceptor:      requireInterceptor,
+		requireInterceptor:      cfg.RequireInterceptor,
+		notifier:                cfg.Notifier,
+		cltvRejectDelta:         cfg.CltvRejectDelta,
+		maxHoldTime:             cfg.MaxHtlcHoldTime,
+		chanMaxHoldTimes:        cfg.ChanMaxHtlcHoldTimes,
+		clock:                   holdClock,
+		holdLimitHeap:           &queue.PriorityQueue{},
 
 		quit: make(chan struct{}),
 	}
@@ -136,11 +235,19 @@ func (s *InterceptableSwitch) SetInterceptor(
 }
 
 func (s *InterceptableSwitch) Start() error {
+	blockEpochStream, err := s.notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		return err
+	}
+
+	s.currentHeight = int32(s.htlcSwitch.BestHeight())
+
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
+		defer blockEpochStream.Cancel()
 
-		s.run()
+		s.run(blockEpochStream)
 	}()
 
 	return nil
@@ -153,9 +260,33 @@ func (s *InterceptableSwitch) Stop() error {
 	return nil
 }
 
-func (s *InterceptableSwitch) run() {
+func (s *InterceptableSwitch) run(
+	blockEpochStream *chainntnfs.BlockEpochEvent) {
+
 	for {
+		// If a held forward has a hold time limit, set up a tick for
+		// the earliest one to be exceeded.
+		var nextHoldLimitTick <-chan time.Time
+		if s.holdLimitHeap.Len() > 0 {
+			head := s.holdLimitHeap.Top().(*holdLimitEvent)
+			nextHoldLimitTick = s.clock.TickAfter(
+				head.releaseTime.Sub(s.clock.Now()),
+			)
+		}
+
 		select {
+		// A new block arrived. Fail back any held forwards that are
+		// about to expire on the incoming side.
+		case blockEpoch, ok := <-blockEpochStream.Epochs:
+			if !ok {
+				log.Debugf("Block epoch stream closed")
+
+				return
+			}
+
+			s.currentHeight = blockEpoch.Height
+			s.failExpiringForwards()
+
 		// An interceptor registration or de-registration came in.
 		case interceptor := <-s.interceptorRegistration:
 			s.setInterceptor(interceptor)
@@ -179,11 +310,78 @@ func (s *InterceptableSwitch) run() {
 		case res := <-s.resolutionChan:
 			res.errChan <- s.resolve(res.resolution)
 
+		// The held forward at the top of the heap exceeded its hold
+		// time limit.
+		case <-nextHoldLimitTick:
+			event := s.holdLimitHeap.Pop().(*holdLimitEvent)
+			s.failHoldLimitForward(event)
+
 		case <-s.quit:
 			return
 		}
 	}
 }
+
+// failExpiringForwards fails back all held forwards whose incoming htlc expires
+// within the cltv reject delta.
+func (s *InterceptableSwitch) failExpiringForwards() {
+	for inKey, fwd := range s.holdForwards {
+		if !s.isExpiring(fwd.Packet().IncomingExpiry) {
+			continue
+		}
+
+		log.Infof("Failing held forward %v, incoming expiry %v is "+
+			"too close to current height %v", inKey,
+			fwd.Packet().IncomingExpiry, s.currentHeight)
+
+		delete(s.holdForwards, inKey)
+
+		err := fwd.FailWithCode(lnwire.CodeTemporaryChannelFailure)
+		if err != nil {
+			log.Errorf("Cannot fail held forward %v: %v", inKey,
+				err)
+		}
+	}
+}
+
+// failHoldLimitForward fails back a held forward that exceeded its hold time
+// limit, unless it was resolved in the meantime.
+func (s *InterceptableSwitch) failHoldLimitForward(event *holdLimitEvent) {
+	fwd, ok := s.holdForwards[event.key]
+	if !ok || fwd != event.fwd {
+		return
+	}
+
+	log.Infof("Failing held forward %v, it exceeded its hold time limit",
+		event.key)
+
+	delete(s.holdForwards, event.key)
+
+	err := fwd.FailWithCode(lnwire.CodeTemporaryChannelFailure)
+	if err != nil {
+		log.Errorf("Cannot fail held forward %v: %v", event.key, err)
+	}
+}
+
+// holdLimit returns the maximum duration that a forward arriving over the
+// given channel may be held. A zero duration means that there is no limit.
+func (s *InterceptableSwitch) holdLimit(
+	chanID lnwire.ShortChannelID) time.Duration {
+
+	if limit, ok := s.chanMaxHoldTimes[chanID]; ok {
+		return limit
+	}
+
+	return s.maxHoldTime
+}
+
+// isExpiring returns true if an htlc with the given incoming expiry is too
+// close to the current height to be held any longer.
+func (s *InterceptableSwitch) isExpiring(incomingExpiry uint32) bool {
+	return int64(incomingExpiry) <=
+		int64(s.currentHeight)+int64(s.cltvRejectDelta)
+}
+
 func (s *InterceptableSwitch) sendForward(fwd InterceptedForward) {
 	err := s.interceptor(fwd.Packet())
 	if err != nil {
@@ -231,7 +429,7 @@ func (s *InterceptableSwitch) setInterceptor(interceptor ForwardInterceptor) {
 func (s *InterceptableSwitch) resolve(res *FwdResolution) error {
 	intercepted, ok := s.holdForwards[res.Key]
 	if !ok {
-		return fmt.Errorf("fwd %v not found", res.Key)
+		return fmt.Errorf("fwd %v: %w", res.Key, ErrFwdNotExists)
 	}
 	delete(s.holdForwards, res.Key)
 
@@ -337,6 +535,24 @@ func (s *InterceptableSwitch) interceptForward(packet *htlcPacket,
 			htlcSwitch: s.htlcSwitch,
 		}
 
+		// If the incoming htlc is about to expire, we fail it back
+		// right away instead of holding it. Otherwise the incoming
+		// channel may be force closed before the interceptor decides.
+		if s.isExpiring(packet.incomingTimeout) {
+			log.Infof("Failing forward %v, incoming expiry %v is "+
+				"too close to current height %v", inKey,
+				packet.incomingTimeout, s.currentHeight)
+
+			err := intercepted.FailWithCode(
+				lnwire.CodeTemporaryChannelFailure,
+			)
+			if err != nil {
+				log.Errorf("Cannot fail packet: %v", err)
+			}
+
+			return true
+		}
+
 		if s.interceptor == nil && !isReplay {
 			// There is no interceptor registered, we are in
 			// interceptor-required mode, and this is a new packet
@@ -356,6 +572,16 @@ func (s *InterceptableSwitch) interceptForward(packet *htlcPacket,
 
 		s.holdForwards[inKey] = intercepted
 
+		// If the forward may only be held for a limited time, we'll
+		// schedule it to be failed back once that time has passed.
+		if limit := s.holdLimit(inKey.ChanID); limit > 0 {
+			s.holdLimitHeap.Push(&holdLimitEvent{
+				key:         inKey,
+				fwd:         intercepted,
+				releaseTime: s.clock.Now().Add(limit),
+			})
+		}
+
 		// If there is no interceptor registered, we must be in
 		// interceptor-required mode. The packet is kept in the queue
 		// until the interceptor registers itself.
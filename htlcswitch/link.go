@@ -294,6 +294,10 @@ type ChannelLinkConfig struct {
 	// HtlcNotifier is an instance of a htlcNotifier which we will pipe htlc
 	// events through.
 	HtlcNotifier htlcNotifier
+
+	// GetAliases returns the aliases that we assigned to the channel with
+	// the passed confirmed short channel id, if any.
+	GetAliases func(base lnwire.ShortChannelID) []lnwire.ShortChannelID
 }
 
 // localUpdateAddMsg contains a locally initiated htlc and a channel that will
@@ -710,6 +714,16 @@ func (l *channelLink) syncChanStates() error {
 			fundingLockedMsg := lnwire.NewFundingLocked(
 				l.ChanID(), nextRevocation,
 			)
+
+			// If we assigned an alias to the channel, we'll
+			// include it so the peer learns about it as well.
+			if l.cfg.GetAliases != nil {
+				aliases := l.cfg.GetAliases(l.ShortChanID())
+				if len(aliases) > 0 {
+					fundingLockedMsg.AliasScid = &aliases[0]
+				}
+			}
+
 			err = l.cfg.Peer.SendMessage(false, fundingLockedMsg)
 			if err != nil {
 				return fmt.Errorf("unable to re-send "+
@@ -1497,8 +1511,9 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 			getEventType(pkt),
 		)
 
-		// Immediately update the commitment tx to minimize latency.
-		l.updateCommitTxOrFail()
+		// Update the commitment tx, either immediately to minimize
+		// latency or as part of the pending batch.
+		l.batchOrUpdateCommitTx()
 
 	case *lnwire.UpdateFailHTLC:
 		// If hodl.FailOutgoing mode is active, we exit early to
@@ -1575,9 +1590,24 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 			)
 		}
 
-		// Immediately update the commitment tx to minimize latency.
-		l.updateCommitTxOrFail()
+		// Update the commitment tx, either immediately to minimize
+		// latency or as part of the pending batch.
+		l.batchOrUpdateCommitTx()
+	}
+}
+
+// batchOrUpdateCommitTx updates the commitment transaction right away if the
+// update that was just added is the only pending one, which minimizes the
+// latency of resolving htlcs when traffic is low. Otherwise a commitment
+// covering the pending updates is already due, so the update joins that
+// batch, which is signed once it's full or the batch ticker fires.
+func (l *channelLink) batchOrUpdateCommitTx() {
+	if l.channel.PendingLocalUpdateCount() > 1 {
+		l.tryBatchUpdateCommitTx()
+		return
 	}
+
+	l.updateCommitTxOrFail()
 }
 
 // tryBatchUpdateCommitTx updates the commitment transaction if the batch is
@@ -1791,6 +1821,16 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 		}
 
 	case *lnwire.CommitSig:
+		// If hodl.Revoke mode is active, we will neither accept the
+		// new commitment nor revoke our prior one. Exiting here
+		// allows testing the remote party's handling of a peer that
+		// stalls the commitment dance, e.g. its retransmission of
+		// the signature on reestablishment or going on-chain.
+		if l.cfg.HodlMask.Active(hodl.Revoke) {
+			l.log.Warnf(hodl.Revoke.Warning())
+			return
+		}
+
 		// Since we may have learned new preimages for the first time,
 		// we'll add them to our preimage cache. By doing this, we
 		// ensure any contested contracts watched by any on-chain
@@ -2408,9 +2448,13 @@ func (l *channelLink) CheckHtlcForward(payHash [32]byte,
 	// sender messed up, or an intermediate node tampered with the HTLC.
 	timeDelta := policy.TimeLockDelta
 	if incomingTimeout < outgoingTimeout+timeDelta {
+		// The incoming timeout may be below the outgoing one, so we
+		// compute the actual delta in signed arithmetic to not log an
+		// underflowed value.
+		actualDelta := int64(incomingTimeout) - int64(outgoingTimeout)
 		l.log.Warnf("incoming htlc(%x) has incorrect time-lock value: "+
 			"expected at least %v block delta, got %v block delta",
-			payHash[:], timeDelta, incomingTimeout-outgoingTimeout)
+			payHash[:], timeDelta, actualDelta)
 
 		// Grab the latest routing policy so the sending node is up to
 		// date with our current policy.
@@ -4712,6 +4712,136 @@ func TestChannelLinkNoMoreUpdates(t *testing.T) {
 	}
 }
 
+// TestChannelLinkHodlRevoke asserts that a link in hodl.Revoke mode drops
+// incoming commitment signatures, and never revokes its prior state.
+func TestChannelLinkHodlRevoke(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = btcutil.SatoshiPerBitcoin * 5
+	const chanReserve = btcutil.SatoshiPerBitcoin * 1
+	aliceLink, bobChannel, _, start, cleanUp, _, err :=
+		newSingleLinkTestHarness(chanAmt, chanReserve)
+	if err != nil {
+		t.Fatalf("unable to create link: %v", err)
+	}
+	defer cleanUp()
+
+	var (
+		coreLink  = aliceLink.(*channelLink)
+		aliceMsgs = coreLink.cfg.Peer.(*mockPeer).sentMsgs
+	)
+
+	// We put Alice into hodl.Revoke mode before starting her link, such
+	// that she won't respond to any of Bob's signatures.
+	coreLink.cfg.HodlMask = hodl.Revoke.Mask()
+
+	if err := start(); err != nil {
+		t.Fatalf("unable to start test harness: %v", err)
+	}
+
+	htlc := generateHtlc(t, coreLink, 0)
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  aliceMsgs,
+		bobChannel: bobChannel,
+	}
+
+	ctx.sendHtlcBobToAlice(htlc)
+	ctx.sendCommitSigBobToAlice(1)
+
+	// Alice should neither revoke her prior state, nor sign a new
+	// commitment for Bob.
+	var msg lnwire.Message
+	select {
+	case msg = <-aliceMsgs:
+		t.Fatalf("did not expect message %T", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestChannelLinkBatchSettleFail asserts that a downstream Fail joins the
+// pending batch of updates rather than cutting it short by triggering an
+// immediate commitment update.
+func TestChannelLinkBatchSettleFail(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = btcutil.SatoshiPerBitcoin * 5
+	const chanReserve = btcutil.SatoshiPerBitcoin * 1
+	aliceLink, bobChannel, batchTicker, start, cleanUp, _, err :=
+		newSingleLinkTestHarness(chanAmt, chanReserve)
+	if err != nil {
+		t.Fatalf("unable to create link: %v", err)
+	}
+	defer cleanUp()
+
+	var (
+		coreLink  = aliceLink.(*channelLink)
+		aliceMsgs = coreLink.cfg.Peer.(*mockPeer).sentMsgs
+	)
+
+	// We put Alice into hodl.ExitSettle mode, such that she won't settle
+	// the HTLC that Bob sends her.
+	coreLink.cfg.HodlMask = hodl.ExitSettle.Mask()
+
+	if err := start(); err != nil {
+		t.Fatalf("unable to start test harness: %v", err)
+	}
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  aliceMsgs,
+		bobChannel: bobChannel,
+	}
+
+	// Lock in an HTLC from Bob to Alice.
+	htlc1 := generateHtlc(t, coreLink, 0)
+	ctx.sendHtlcBobToAlice(htlc1)
+	ctx.sendCommitSigBobToAlice(1)
+	ctx.receiveRevAndAckAliceToBob()
+	ctx.receiveCommitSigAliceToBob(1)
+	ctx.sendRevAndAckBobToAlice()
+
+	// Give Alice time to process the revocation, as she would otherwise
+	// sign for the HTLC below right upon processing it.
+	time.Sleep(500 * time.Millisecond)
+
+	// Alice now sends an HTLC to Bob, which is held in the pending batch
+	// as the batch is far from full.
+	htlc2 := generateHtlc(t, coreLink, 1)
+	ctx.sendHtlcAliceToBob(0, htlc2)
+	ctx.receiveHtlcAliceToBob()
+	ctx.assertNoMsgFromAlice(100 * time.Millisecond)
+
+	// Fail back Bob's HTLC. Alice should send the Fail, but not sign a
+	// new commitment yet.
+	err = aliceLink.handleSwitchPacket(&htlcPacket{
+		incomingChanID: aliceLink.ShortChanID(),
+		incomingHTLCID: 0,
+		htlc: &lnwire.UpdateFailHTLC{
+			Reason: []byte("nop"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to handle switch packet: %v", err)
+	}
+	ctx.receiveFailAliceToBob()
+	ctx.assertNoMsgFromAlice(100 * time.Millisecond)
+
+	// Once the batch ticker fires, Alice signs a single commitment that
+	// covers both the Add and the Fail.
+	select {
+	case batchTicker <- time.Now():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("could not force commit sig")
+	}
+
+	ctx.receiveCommitSigAliceToBob(1)
+	ctx.sendRevAndAckBobToAlice()
+}
+
 // checkHasPreimages inspects Alice's preimage cache, and asserts whether the
 // preimages for the provided HTLCs are known and unknown, and that all of them
 // match the expected status of expOk.
@@ -21,6 +21,10 @@ var (
 	// ErrPacketAlreadyExists signals that an attempt to add a packet failed
 	// because it already exists in the mailbox.
 	ErrPacketAlreadyExists = errors.New("mailbox already has packet")
+
+	// ErrMailBoxFull signals that an attempt to add an Add packet failed
+	// because the mailbox already holds the maximum number of Adds.
+	ErrMailBoxFull = errors.New("mailbox is full")
 )
 
 // MailBox is an interface which represents a concurrent-safe, in-order
@@ -107,6 +111,12 @@ type mailBoxConfig struct {
 	// have not been yet been delivered. The computed deadline will expiry
 	// this long after the Adds are added via AddPacket.
 	expiry time.Duration
+
+	// maxAdds is the maximum number of Adds that the mailbox will hold at
+	// once. Any further Adds are rejected with ErrMailBoxFull until the
+	// link acks some of the queued ones. A value of zero disables the
+	// limit.
+	maxAdds int
 }
 
 // memoryMailBox is an implementation of the MailBox struct backed by purely
@@ -610,6 +620,15 @@ func (m *memoryMailBox) AddPacket(pkt *htlcPacket) error {
 			return ErrPacketAlreadyExists
 		}
 
+		// Reject the Add if the link isn't keeping up with the Adds
+		// that are already queued, so that a slow peer can't make the
+		// mailbox grow without bound. Settles and Fails are never
+		// rejected, as they must always reach the incoming link.
+		if m.cfg.maxAdds > 0 && m.addPkts.Len() >= m.cfg.maxAdds {
+			m.pktCond.L.Unlock()
+			return ErrMailBoxFull
+		}
+
 		entry := m.addPkts.PushBack(&pktWithExpiry{
 			pkt:    pkt,
 			expiry: m.cfg.clock.Now().Add(m.cfg.expiry),
@@ -828,6 +847,10 @@ type mailOrchConfig struct {
 	// have not been yet been delivered. The computed deadline will expiry
 	// this long after the Adds are added to a mailbox via AddPacket.
 	expiry time.Duration
+
+	// maxAdds is the maximum number of Adds that each of the generated
+	// mailboxes will hold at once. A value of zero disables the limit.
+	maxAdds int
 }
 
 // newMailOrchestrator initializes a fresh mailOrchestrator.
@@ -886,6 +909,7 @@ func (mo *mailOrchestrator) exclusiveGetOrCreateMailBox(
 			forwardPackets: mo.cfg.forwardPackets,
 			clock:          mo.cfg.clock,
 			expiry:         mo.cfg.expiry,
+			maxAdds:        mo.cfg.maxAdds,
 		})
 		mailbox.Start()
 		mo.mailboxes[chanID] = mailbox
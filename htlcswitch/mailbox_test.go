@@ -541,6 +541,52 @@ func TestMailBoxDuplicateAddPacket(t *testing.T) {
 	})
 }
 
+// TestMailBoxMaxAdds asserts that the mailbox rejects Adds with ErrMailBoxFull
+// once it holds the maximum number of Adds, while still accepting Settles and
+// Fails.
+func TestMailBoxMaxAdds(t *testing.T) {
+	t.Parallel()
+
+	const maxAdds = 5
+
+	ctx := newMailboxContext(t, time.Now(), testExpiry)
+	ctx.mailbox.(*memoryMailBox).cfg.maxAdds = maxAdds
+	defer ctx.mailbox.Stop()
+
+	adds := ctx.sendAdds(0, maxAdds)
+
+	// Any further Add should be rejected, as the mailbox is full.
+	err := ctx.mailbox.AddPacket(&htlcPacket{
+		incomingHTLCID: maxAdds,
+		htlc:           &lnwire.UpdateAddHTLC{},
+	})
+	if err != ErrMailBoxFull {
+		t.Fatalf("expected ErrMailBoxFull, got: %v", err)
+	}
+
+	// Settles and Fails should still be accepted.
+	err = ctx.mailbox.AddPacket(&htlcPacket{
+		incomingHTLCID: 0,
+		htlc:           &lnwire.UpdateFulfillHTLC{},
+	})
+	if err != nil {
+		t.Fatalf("unable to add settle: %v", err)
+	}
+	err = ctx.mailbox.AddPacket(&htlcPacket{
+		incomingHTLCID: 1,
+		htlc:           &lnwire.UpdateFailHTLC{},
+	})
+	if err != nil {
+		t.Fatalf("unable to add fail: %v", err)
+	}
+
+	// Once one of the queued Adds is acked, there's room for another one.
+	if !ctx.mailbox.AckPacket(adds[0].inKey()) {
+		t.Fatalf("unable to ack add")
+	}
+	ctx.sendAdds(maxAdds, 1)
+}
+
 // TestMailBoxDustHandling tests that DustPackets returns the expected values
 // for the local and remote dust sum after calling SetFeeRate and
 // SetDustClosure.
@@ -17,6 +17,7 @@ import (
 	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/htlcswitch/hop"
+	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -41,6 +42,12 @@ const (
 	// DefaultHTLCExpiry is the duration after which Adds will be cancelled
 	// if they could not get added to an outgoing commitment.
 	DefaultHTLCExpiry = time.Minute
+
+	// DefaultMaxMailboxAdds is the default number of Adds that may be
+	// queued in the mailbox of a link. Adds remain in the mailbox until
+	// they're signed for, so this leaves room for a full commitment in
+	// addition to the Adds that are still waiting to be delivered.
+	DefaultMaxMailboxAdds = input.MaxHTLCNumber
 )
 
 var (
@@ -105,6 +112,12 @@ type ChanClose struct {
 	// process for the cooperative closure transaction kicks off.
 	TargetFeePerKw chainfee.SatPerKWeight
 
+	// MaxFee is the highest fee the caller is willing to pay.
+	//
+	// NOTE: This field is only respected if the caller is the initiator of
+	// the channel.
+	MaxFee chainfee.SatPerKWeight
+
 	// DeliveryScript is an optional delivery script to pay funds out to.
 	DeliveryScript lnwire.DeliveryAddress
 
@@ -197,9 +210,20 @@ type Config struct {
 	// AddPacket.
 	HTLCExpiry time.Duration
 
+	// MaxMailboxAdds is the maximum number of Adds that may be queued in
+	// the mailbox of a link. Any further Adds forwarded to the link are
+	// failed back until it catches up. A value of zero disables the
+	// limit.
+	MaxMailboxAdds int
+
 	// DustThreshold is the threshold in milli-satoshis after which we'll
 	// fail incoming or outgoing dust payments for a particular channel.
 	DustThreshold lnwire.MilliSatoshi
+
+	// GetAliases returns the short channel id aliases that were assigned
+	// to the channel with the passed confirmed short channel id. HTLCs
+	// addressed to any of these aliases are forwarded over that channel.
+	GetAliases func(base lnwire.ShortChannelID) []lnwire.ShortChannelID
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -331,6 +355,7 @@ func New(cfg Config, currentHeight uint32) (*Switch, error) {
 		forwardPackets: s.ForwardPackets,
 		clock:          s.cfg.Clock,
 		expiry:         s.cfg.HTLCExpiry,
+		maxAdds:        s.cfg.MaxMailboxAdds,
 	})
 
 	return s, nil
@@ -1050,6 +1075,27 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 
 			return s.failAddPacket(packet, linkError)
 		}
+
+		// If the outgoing channel has an alias, its confirmed short
+		// channel id is only known to us and our peer. We'll refuse to
+		// forward over it, as accepting it would reveal that the
+		// payment route led through the channel.
+		if packet.outgoingChanID == targetLink.ShortChanID() &&
+			len(s.linkAliases(targetLink)) > 0 {
+
+			s.indexMtx.RUnlock()
+
+			log.Debugf("refusing to forward over confirmed short "+
+				"channel id %v of aliased channel",
+				packet.outgoingChanID)
+
+			linkError := NewLinkError(
+				&lnwire.FailUnknownNextPeer{},
+			)
+
+			return s.failAddPacket(packet, linkError)
+		}
+
 		targetPeerKey := targetLink.Peer().PubKey()
 		interfaceLinks, _ := s.getLinks(targetPeerKey)
 		s.indexMtx.RUnlock()
@@ -1103,7 +1149,7 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			// At this point, some or all of the links rejected the
 			// HTLC so we couldn't forward it. So we'll try to look
 			// up the error that came from the source.
-			linkErr, ok := linkErrs[packet.outgoingChanID]
+			linkErr, ok := linkErrs[targetLink.ShortChanID()]
 			if !ok {
 				// If we can't find the error of the source,
 				// then we'll return an unknown next peer,
@@ -1179,7 +1225,19 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 		// Send the packet to the destination channel link which
 		// manages the channel.
 		packet.outgoingChanID = destination.ShortChanID()
-		return destination.handleSwitchPacket(packet)
+		err = destination.handleSwitchPacket(packet)
+
+		// If the destination's mailbox is full, the link isn't keeping
+		// up with its peer, so we fail the add back.
+		if err == ErrMailBoxFull {
+			linkErr := NewLinkError(
+				&lnwire.FailTemporaryChannelFailure{},
+			)
+
+			return s.failAddPacket(packet, linkErr)
+		}
+
+		return err
 
 	case *lnwire.UpdateFailHTLC, *lnwire.UpdateFulfillHTLC:
 		// If the source of this packet has not been set, use the
@@ -1529,11 +1587,13 @@ func (s *Switch) teardownCircuit(pkt *htlcPacket) error {
 // CloseLink creates and sends the close channel command to the target link
 // directing the specified closure type. If the closure type is CloseRegular,
 // targetFeePerKw parameter should be the ideal fee-per-kw that will be used as
-// a starting point for close negotiation. The deliveryScript parameter is an
-// optional parameter which sets a user specified script to close out to.
+// a starting point for close negotiation, and maxFee is the highest fee-per-kw
+// we're willing to pay if we're the initiator of the channel. The
+// deliveryScript parameter is an optional parameter which sets a user
+// specified script to close out to.
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 	closeType contractcourt.ChannelCloseType,
-	targetFeePerKw chainfee.SatPerKWeight,
+	targetFeePerKw chainfee.SatPerKWeight, maxFee chainfee.SatPerKWeight,
 	deliveryScript lnwire.DeliveryAddress) (chan interface{}, chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
@@ -1545,6 +1605,7 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 		ChanPoint:      chanPoint,
 		Updates:        updateChan,
 		TargetFeePerKw: targetFeePerKw,
+		MaxFee:         maxFee,
 		DeliveryScript: deliveryScript,
 		Err:            errChan,
 	}
@@ -2099,6 +2160,12 @@ func (s *Switch) addLiveLink(link ChannelLink) {
 	s.linkIndex[link.ChanID()] = link
 	s.forwardingIndex[link.ShortChanID()] = link
 
+	// Any aliases of the channel are added to the forwarding index as
+	// well, so that HTLCs addressed to them reach this link.
+	for _, alias := range s.linkAliases(link) {
+		s.forwardingIndex[alias] = link
+	}
+
 	// Next we'll add the link to the interface index so we can
 	// quickly look up all the channels for a particular node.
 	peerPub := link.Peer().PubKey()
@@ -2108,6 +2175,15 @@ func (s *Switch) addLiveLink(link ChannelLink) {
 	s.interfaceIndex[peerPub][link.ChanID()] = link
 }
 
+// linkAliases returns the aliases of the channel managed by the passed link.
+func (s *Switch) linkAliases(link ChannelLink) []lnwire.ShortChannelID {
+	if s.cfg.GetAliases == nil {
+		return nil
+	}
+
+	return s.cfg.GetAliases(link.ShortChanID())
+}
+
 // GetLink is used to initiate the handling of the get link command. The
 // request will be propagated/handled to/in the main goroutine.
 func (s *Switch) GetLink(chanID lnwire.ChannelID) (ChannelUpdateHandler,
@@ -2198,6 +2274,9 @@ func (s *Switch) removeLink(chanID lnwire.ChannelID) ChannelLink {
 	delete(s.pendingLinkIndex, link.ChanID())
 	delete(s.linkIndex, link.ChanID())
 	delete(s.forwardingIndex, link.ShortChanID())
+	for _, alias := range s.linkAliases(link) {
+		delete(s.forwardingIndex, alias)
+	}
 
 	// If the link has been added to the peer index, then we'll move to
 	// delete the entry within the index.
@@ -2402,6 +2481,10 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 
 		// Finally check against the defined dust threshold.
 		if localSum > s.cfg.DustThreshold {
+			log.Debugf("ChannelLink(%v): dust sum of %v on local "+
+				"commitment exceeds threshold of %v", sid,
+				localSum, s.cfg.DustThreshold)
+
 			return true
 		}
 	}
@@ -2420,6 +2503,10 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 
 		// Finally check against the defined dust threshold.
 		if remoteSum > s.cfg.DustThreshold {
+			log.Debugf("ChannelLink(%v): dust sum of %v on remote "+
+				"commitment exceeds threshold of %v", sid,
+				remoteSum, s.cfg.DustThreshold)
+
 			return true
 		}
 	}
@@ -13,9 +13,12 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/htlcswitch/hodl"
 	"github.com/lightningnetwork/lnd/htlcswitch/hop"
+	"github.com/lightningnetwork/lnd/lntest/mock"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/ticker"
@@ -92,6 +95,153 @@ func TestSwitchAddDuplicateLink(t *testing.T) {
 	}
 }
 
+// TestSwitchLinkAliases asserts that a live link can be looked up by the
+// aliases of its channel, and that they're removed along with the link.
+func TestSwitchLinkAliases(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+
+	chanID1, _, aliceChanID, _ := genIDs()
+	alias := lnwire.NewShortChanIDFromInt(16_000_000 << 40)
+
+	s.cfg.GetAliases = func(
+		base lnwire.ShortChannelID) []lnwire.ShortChannelID {
+
+		if base == aliceChanID {
+			return []lnwire.ShortChannelID{alias}
+		}
+
+		return nil
+	}
+
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+
+	link, err := s.GetLinkByShortID(alias)
+	require.NoError(t, err)
+	require.Equal(t, aliceChannelLink, link)
+
+	link, err = s.GetLinkByShortID(aliceChanID)
+	require.NoError(t, err)
+	require.Equal(t, aliceChannelLink, link)
+
+	// Once the link is removed, neither the alias nor the confirmed short
+	// channel id should resolve to it.
+	s.RemoveLink(chanID1)
+
+	_, err = s.GetLinkByShortID(alias)
+	require.ErrorIs(t, err, ErrChannelLinkNotFound)
+
+	_, err = s.GetLinkByShortID(aliceChanID)
+	require.ErrorIs(t, err, ErrChannelLinkNotFound)
+}
+
+// TestSwitchForwardAlias asserts that HTLCs are forwarded over the alias of a
+// channel, and that forwards over the confirmed short channel id of an aliased
+// channel are rejected.
+func TestSwitchForwardAlias(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+	alias := lnwire.NewShortChanIDFromInt(16_000_000 << 40)
+
+	s.cfg.GetAliases = func(
+		base lnwire.ShortChannelID) []lnwire.ShortChannelID {
+
+		if base == bobChanID {
+			return []lnwire.ShortChannelID{alias}
+		}
+
+		return nil
+	}
+
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	preimage, err := genPreimage()
+	require.NoError(t, err)
+	rhash := sha256.Sum256(preimage[:])
+
+	// A forward over bob's confirmed short channel id should be failed
+	// back to alice.
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 0,
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		_, ok := pkt.htlc.(*lnwire.UpdateFailHTLC)
+		require.True(t, ok, "expected fail, got %T", pkt.htlc)
+
+	case <-bobChannelLink.packets:
+		t.Fatal("htlc forwarded over confirmed short channel id")
+
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not failed back")
+	}
+
+	// A forward over bob's alias should reach bob's link.
+	packet = &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 1,
+		outgoingChanID: alias,
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not forwarded over alias")
+	}
+}
+
 // TestSwitchHasActiveLink tests the behavior of HasActiveLink, and asserts that
 // it only returns true if a link's short channel id has confirmed (meaning the
 // channel is no longer pending) and it's EligibleToForward method returns true,
@@ -3258,10 +3408,11 @@ func TestSwitchHoldForward(t *testing.T) {
 	rhash := sha256.Sum256(preimage[:])
 	onionBlob := [1366]byte{4, 5, 6}
 	ogPacket := &htlcPacket{
-		incomingChanID: aliceChannelLink.ShortChanID(),
-		incomingHTLCID: 0,
-		outgoingChanID: bobChannelLink.ShortChanID(),
-		obfuscator:     NewMockObfuscator(),
+		incomingChanID:  aliceChannelLink.ShortChanID(),
+		incomingHTLCID:  0,
+		incomingTimeout: testStartingHeight + 100,
+		outgoingChanID:  bobChannelLink.ShortChanID(),
+		obfuscator:      NewMockObfuscator(),
 		htlc: &lnwire.UpdateAddHTLC{
 			PaymentHash: rhash,
 			Amount:      1,
@@ -3273,7 +3424,13 @@ func TestSwitchHoldForward(t *testing.T) {
 		t:               t,
 		interceptedChan: make(chan InterceptedPacket),
 	}
-	switchForwardInterceptor := NewInterceptableSwitch(s, false)
+	switchForwardInterceptor := NewInterceptableSwitch(
+		&InterceptableSwitchConfig{
+			Switch:          s,
+			Notifier:        s.cfg.Notifier,
+			CltvRejectDelta: 10,
+		},
+	)
 	require.NoError(t, switchForwardInterceptor.Start())
 
 	switchForwardInterceptor.SetInterceptor(forwardInterceptor.InterceptForwardHtlc)
@@ -3425,7 +3582,14 @@ func TestSwitchHoldForward(t *testing.T) {
 	require.NoError(t, switchForwardInterceptor.Stop())
 
 	// Test always-on interception.
-	switchForwardInterceptor = NewInterceptableSwitch(s, true)
+	switchForwardInterceptor = NewInterceptableSwitch(
+		&InterceptableSwitchConfig{
+			Switch:             s,
+			Notifier:           s.cfg.Notifier,
+			CltvRejectDelta:    10,
+			RequireInterceptor: true,
+		},
+	)
 	require.NoError(t, switchForwardInterceptor.Start())
 
 	// Forward a fresh packet. It is expected to be failed immediately,
@@ -3492,6 +3656,239 @@ func TestSwitchHoldForward(t *testing.T) {
 	}
 }
 
+// TestSwitchHoldForwardAutoFail tests that held forwards are failed back
+// automatically when their incoming htlc is about to expire, and that forwards
+// that are already close to expiry aren't held at all.
+func TestSwitchHoldForwardAutoFail(t *testing.T) {
+	t.Parallel()
+
+	const cltvRejectDelta = 10
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	tempPath, err := ioutil.TempDir("", "circuitdb")
+	require.NoError(t, err)
+
+	cdb, err := channeldb.Open(tempPath)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, cdb)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	// The interceptable switch gets its own notifier, so that we can
+	// deliver block epochs to it without racing the main switch.
+	notifier := &mock.ChainNotifier{
+		EpochChan: make(chan *chainntnfs.BlockEpoch),
+	}
+	switchForwardInterceptor := NewInterceptableSwitch(
+		&InterceptableSwitchConfig{
+			Switch:          s,
+			Notifier:        notifier,
+			CltvRejectDelta: cltvRejectDelta,
+		},
+	)
+	require.NoError(t, switchForwardInterceptor.Start())
+	defer func() {
+		require.NoError(t, switchForwardInterceptor.Stop())
+	}()
+
+	forwardInterceptor := &mockForwardInterceptor{
+		t:               t,
+		interceptedChan: make(chan InterceptedPacket),
+	}
+	switchForwardInterceptor.SetInterceptor(
+		forwardInterceptor.InterceptForwardHtlc,
+	)
+
+	rhash := sha256.Sum256([]byte{1})
+	newPacket := func(htlcID uint64, expiry uint32) *htlcPacket {
+		return &htlcPacket{
+			incomingChanID:  aliceChannelLink.ShortChanID(),
+			incomingHTLCID:  htlcID,
+			incomingTimeout: expiry,
+			outgoingChanID:  bobChannelLink.ShortChanID(),
+			obfuscator:      NewMockObfuscator(),
+			circuit:         &PaymentCircuit{PaymentHash: rhash},
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+			},
+		}
+	}
+	linkQuit := make(chan struct{})
+
+	// A forward whose incoming expiry is within the reject delta isn't
+	// held, but failed back immediately.
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false,
+		newPacket(0, testStartingHeight+cltvRejectDelta),
+	))
+	assertOutgoingLinkReceive(t, bobChannelLink, false)
+	assertOutgoingLinkReceive(t, aliceChannelLink, true)
+
+	// A forward with one more block of headroom is held.
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false,
+		newPacket(1, testStartingHeight+cltvRejectDelta+1),
+	))
+	intercepted := forwardInterceptor.getIntercepted()
+	assertOutgoingLinkReceive(t, aliceChannelLink, false)
+
+	// Once the next block arrives, the held forward is failed back.
+	notifier.EpochChan <- &chainntnfs.BlockEpoch{
+		Height: testStartingHeight + 1,
+	}
+	assertOutgoingLinkReceive(t, bobChannelLink, false)
+	assertOutgoingLinkReceive(t, aliceChannelLink, true)
+
+	// A later resolution by the interceptor is rejected because the
+	// forward doesn't exist anymore.
+	err = switchForwardInterceptor.Resolve(&FwdResolution{
+		Key:    intercepted.IncomingCircuit,
+		Action: FwdActionResume,
+	})
+	require.ErrorIs(t, err, ErrFwdNotExists)
+}
+
+// TestSwitchHoldForwardHoldLimit tests that held forwards are failed back once
+// they exceed their hold time limit, taking per-channel limits into account.
+func TestSwitchHoldForwardHoldLimit(t *testing.T) {
+	t.Parallel()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	tempPath, err := ioutil.TempDir("", "circuitdb")
+	require.NoError(t, err)
+
+	cdb, err := channeldb.Open(tempPath)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, cdb)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	// Forwards may be held for a minute, except for the ones arriving
+	// from bob, which may only be held for ten seconds.
+	chanHoldTimes := map[lnwire.ShortChannelID]time.Duration{
+		bobChanID: 10 * time.Second,
+	}
+	startTime := time.Unix(1000, 0)
+	testClock := clock.NewTestClock(startTime)
+	switchForwardInterceptor := NewInterceptableSwitch(
+		&InterceptableSwitchConfig{
+			Switch:               s,
+			Notifier:             s.cfg.Notifier,
+			CltvRejectDelta:      10,
+			MaxHtlcHoldTime:      time.Minute,
+			ChanMaxHtlcHoldTimes: chanHoldTimes,
+			Clock:                testClock,
+		},
+	)
+	require.NoError(t, switchForwardInterceptor.Start())
+	defer func() {
+		require.NoError(t, switchForwardInterceptor.Stop())
+	}()
+
+	forwardInterceptor := &mockForwardInterceptor{
+		t:               t,
+		interceptedChan: make(chan InterceptedPacket),
+	}
+	switchForwardInterceptor.SetInterceptor(
+		forwardInterceptor.InterceptForwardHtlc,
+	)
+
+	rhash := sha256.Sum256([]byte{1})
+	newPacket := func(from, to *mockChannelLink) *htlcPacket {
+		return &htlcPacket{
+			incomingChanID:  from.ShortChanID(),
+			incomingHTLCID:  0,
+			incomingTimeout: testStartingHeight + 100,
+			outgoingChanID:  to.ShortChanID(),
+			obfuscator:      NewMockObfuscator(),
+			circuit:         &PaymentCircuit{PaymentHash: rhash},
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+			},
+		}
+	}
+	linkQuit := make(chan struct{})
+
+	// Hold a forward from alice to bob, and one from bob to alice.
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false, newPacket(aliceChannelLink, bobChannelLink),
+	))
+	aliceIntercepted := forwardInterceptor.getIntercepted()
+
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false, newPacket(bobChannelLink, aliceChannelLink),
+	))
+	forwardInterceptor.getIntercepted()
+
+	// Once the channel limit has passed, only the forward from bob is
+	// failed back.
+	testClock.SetTime(startTime.Add(10 * time.Second))
+	assertOutgoingLinkReceive(t, bobChannelLink, true)
+	assertOutgoingLinkReceive(t, aliceChannelLink, false)
+
+	// Once the global limit has passed, the forward from alice is failed
+	// back too.
+	testClock.SetTime(startTime.Add(time.Minute))
+	assertOutgoingLinkReceive(t, aliceChannelLink, true)
+	assertOutgoingLinkReceive(t, bobChannelLink, false)
+
+	// A later resolution by the interceptor is rejected because the
+	// forward doesn't exist anymore.
+	err = switchForwardInterceptor.Resolve(&FwdResolution{
+		Key:    aliceIntercepted.IncomingCircuit,
+		Action: FwdActionResume,
+	})
+	require.ErrorIs(t, err, ErrFwdNotExists)
+}
+
 // TestSwitchDustForwarding tests that the switch properly fails HTLC's which
 // have incoming or outgoing links that breach their dust thresholds.
 func TestSwitchDustForwarding(t *testing.T) {
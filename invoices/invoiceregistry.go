@@ -72,6 +72,15 @@ type RegistryConfig struct {
 	// KeysendHoldTime indicates for how long we want to accept and hold
 	// spontaneous keysend payments.
 	KeysendHoldTime time.Duration
+
+	// MaxHtlcHoldTime is the maximum duration that an accepted htlc may
+	// remain unresolved before it's failed back. A zero value disables
+	// the limit.
+	MaxHtlcHoldTime time.Duration
+
+	// ChanMaxHtlcHoldTimes overrides MaxHtlcHoldTime for the htlcs that
+	// arrive over the given channels.
+	ChanMaxHtlcHoldTimes map[lnwire.ShortChannelID]time.Duration
 }
 
 // htlcReleaseEvent describes an htlc auto-release event. It is used to release
@@ -85,6 +94,10 @@ type htlcReleaseEvent struct {
 
 	// releaseTime is the time at which to release the htlc.
 	releaseTime time.Time
+
+	// holdLimit indicates that the htlc is released because it exceeded
+	// its hold time limit, rather than because its mpp set timed out.
+	holdLimit bool
 }
 
 // Less is used to order PriorityQueueItem's by their release time such that
@@ -330,9 +343,22 @@ func (i *InvoiceRegistry) invoiceEventLoop() {
 		// The htlc at the top of the heap needs to be auto-released.
 		case <-nextReleaseTick:
 			event := autoReleaseHeap.Pop().(*htlcReleaseEvent)
-			err := i.cancelSingleHtlc(
-				event.invoiceRef, event.key, ResultMppTimeout,
-			)
+
+			var err error
+			if event.holdLimit {
+				var releases []*htlcReleaseEvent
+				releases, err = i.failHeldHtlc(
+					event.invoiceRef, event.key,
+				)
+				for _, release := range releases {
+					autoReleaseHeap.Push(release)
+				}
+			} else {
+				err = i.cancelSingleHtlc(
+					event.invoiceRef, event.key,
+					ResultMppTimeout,
+				)
+			}
 			if err != nil {
 				log.Errorf("HTLC timer: %v", err)
 			}
@@ -619,6 +645,168 @@ func (i *InvoiceRegistry) startHtlcTimer(invoiceRef channeldb.InvoiceRef,
 	}
 }
 
+// htlcHoldLimit returns the maximum duration that the htlc with the given
+// circuit key may remain unresolved, taking the limit of the channel it
+// arrived over into account. A zero duration means that there is no limit.
+func (i *InvoiceRegistry) htlcHoldLimit(
+	key channeldb.CircuitKey) time.Duration {
+
+	if limit, ok := i.cfg.ChanMaxHtlcHoldTimes[key.ChanID]; ok {
+		return limit
+	}
+
+	return i.cfg.MaxHtlcHoldTime
+}
+
+// startHoldLimitTimer starts a new timer via the invoice registry main loop
+// that fails back a single htlc when its hold time limit has passed.
+func (i *InvoiceRegistry) startHoldLimitTimer(invoiceRef channeldb.InvoiceRef,
+	key channeldb.CircuitKey, acceptTime time.Time,
+	limit time.Duration) error {
+
+	event := &htlcReleaseEvent{
+		invoiceRef:  invoiceRef,
+		key:         key,
+		releaseTime: acceptTime.Add(limit),
+		holdLimit:   true,
+	}
+
+	select {
+	case i.htlcAutoReleaseChan <- event:
+		return nil
+
+	case <-i.quit:
+		return ErrShuttingDown
+	}
+}
+
+// failHeldHtlc fails back an accepted htlc that exceeded its hold time limit.
+// If the invoice is still open, only the htlc itself is canceled. If the htlc
+// is part of a fully accepted set, the invoice is moved back to the open state
+// as well, as the set is no longer complete. The release events for the
+// remaining htlcs of that set are returned, so they can be released after the
+// mpp timeout like those of any other incomplete set.
+func (i *InvoiceRegistry) failHeldHtlc(invoiceRef channeldb.InvoiceRef,
+	key channeldb.CircuitKey) ([]*htlcReleaseEvent, error) {
+
+	invoice, err := i.cdb.LookupInvoice(invoiceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	switch invoice.State {
+	case channeldb.ContractOpen:
+		return nil, i.cancelSingleHtlc(
+			invoiceRef, key, ResultHtlcHoldTimeExceeded,
+		)
+
+	case channeldb.ContractAccepted:
+		return i.cancelAcceptedHtlc(
+			invoiceRef, key, ResultHtlcHoldTimeExceeded,
+		)
+
+	default:
+		return nil, nil
+	}
+}
+
+// cancelAcceptedHtlc cancels a single htlc of an accepted invoice and moves
+// the invoice back to the open state. The release events for the htlcs that
+// remain accepted on the invoice are returned.
+func (i *InvoiceRegistry) cancelAcceptedHtlc(invoiceRef channeldb.InvoiceRef,
+	key channeldb.CircuitKey,
+	result FailResolutionResult) ([]*htlcReleaseEvent, error) {
+
+	i.Lock()
+	defer i.Unlock()
+
+	payHash := invoiceRef.PayHash()
+	if payHash == nil {
+		return nil, fmt.Errorf("unable to cancel htlc %v of invoice%v: "+
+			"no payment hash", key, invoiceRef)
+	}
+
+	updateInvoice := func(invoice *channeldb.Invoice) (
+		*channeldb.InvoiceUpdateDesc, error) {
+
+		if invoice.State != channeldb.ContractAccepted {
+			log.Debugf("cancelAcceptedHtlc: invoice %v no longer "+
+				"accepted", invoiceRef)
+
+			return nil, nil
+		}
+
+		htlc, ok := invoice.Htlcs[key]
+		if !ok || htlc.State != channeldb.HtlcStateAccepted {
+			log.Debugf("cancelAcceptedHtlc: htlc %v on invoice %v "+
+				"is already resolved", key, invoiceRef)
+
+			return nil, nil
+		}
+
+		log.Infof("Invoice%v: htlc %v exceeded its hold time limit, "+
+			"canceling htlc and reopening invoice", invoiceRef, key)
+
+		return &channeldb.InvoiceUpdateDesc{
+			CancelHtlcs: map[channeldb.CircuitKey]struct{}{
+				key: {},
+			},
+			State: &channeldb.InvoiceStateUpdateDesc{
+				NewState: channeldb.ContractOpen,
+			},
+		}, nil
+	}
+
+	var updated bool
+	invoice, err := i.cdb.UpdateInvoice(invoiceRef, nil,
+		func(invoice *channeldb.Invoice) (
+			*channeldb.InvoiceUpdateDesc, error) {
+
+			updateDesc, err := updateInvoice(invoice)
+			if err != nil {
+				return nil, err
+			}
+			updated = updateDesc != nil
+
+			return updateDesc, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, nil
+	}
+
+	htlc, ok := invoice.Htlcs[key]
+	if !ok {
+		return nil, fmt.Errorf("htlc %v not found", key)
+	}
+	i.notifyHodlSubscribers(
+		NewFailResolution(key, int32(htlc.AcceptHeight), result),
+	)
+	i.notifyClients(*payHash, invoice, nil)
+
+	// The remaining htlcs of the set are now held on an open invoice, so
+	// we'll release them after the mpp timeout.
+	var releases []*htlcReleaseEvent
+	for htlcKey, htlc := range invoice.Htlcs {
+		if htlc.State != channeldb.HtlcStateAccepted {
+			continue
+		}
+
+		releases = append(releases, &htlcReleaseEvent{
+			invoiceRef: invoiceRef,
+			key:        htlcKey,
+			releaseTime: htlc.AcceptTime.Add(
+				i.cfg.HtlcHoldDuration,
+			),
+		})
+	}
+
+	return releases, nil
+}
+
 // cancelSingleHtlc cancels a single accepted htlc on an invoice. It takes
 // a resolution result which will be used to notify subscribed links and
 // resolvers of the details of the htlc cancellation.
@@ -965,6 +1153,18 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 			}
 		}
 
+		// If the htlc may only be held for a limited time, we'll also
+		// schedule it to be failed back once that time has passed.
+		if limit := i.htlcHoldLimit(circuitKey); limit > 0 {
+			err := i.startHoldLimitTimer(
+				ctx.invoiceRef(), circuitKey, r.acceptTime,
+				limit,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// We return a nil resolution because htlc acceptances are
 		// represented as nil resolutions externally.
 		// TODO(carla) update calling code to handle accept resolutions.
@@ -1140,11 +1340,14 @@ func (i *InvoiceRegistry) notifyExitHopHtlcLocked(
 			"outcome: %v, at accept height: %v",
 			res.outcome, acceptHeight))
 
+		// Record when the htlc was accepted, as the timers that may
+		// release it are based on this time.
+		res.acceptTime = invoiceHtlc.AcceptTime
+
 		// Auto-release the htlc if the invoice is still open. It can
 		// only happen for mpp payments that there are htlcs in state
 		// Accepted while the invoice is Open.
 		if invoice.State == channeldb.ContractOpen {
-			res.acceptTime = invoiceHtlc.AcceptTime
 			res.autoRelease = true
 		}
 
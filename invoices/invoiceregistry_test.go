@@ -937,6 +937,111 @@ func TestMppPayment(t *testing.T) {
 	}
 }
 
+// TestHtlcHoldTimeLimit asserts that accepted htlcs are failed back once they
+// exceed their hold time limit, both while the invoice is still open and after
+// the invoice has been accepted.
+func TestHtlcHoldTimeLimit(t *testing.T) {
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	// Fail back htlcs after a minute, except for the ones that arrive over
+	// the channel of htlc 20, which may only be held for ten seconds.
+	limitedChan := getCircuitKey(20).ChanID
+	limitedChan.TxPosition++
+
+	chanLimits := make(map[lnwire.ShortChannelID]time.Duration)
+	chanLimits[limitedChan] = 10 * time.Second
+
+	ctx.registry.cfg.MaxHtlcHoldTime = time.Minute
+	ctx.registry.cfg.ChanMaxHtlcHoldTimes = chanLimits
+
+	_, err := ctx.registry.AddInvoice(testInvoice, testInvoicePaymentHash)
+	require.NoError(t, err)
+
+	mppPayload := &mockPayload{
+		mpp: record.NewMPP(testInvoiceAmt, [32]byte{}),
+	}
+
+	// Send a partial payment over the limited channel. It is released
+	// before the mpp timeout because of the lower per-channel limit.
+	limitedKey := channeldb.CircuitKey{
+		ChanID: limitedChan,
+		HtlcID: 20,
+	}
+	hodlChan1 := make(chan interface{}, 1)
+	resolution, err := ctx.registry.NotifyExitHopHtlc(
+		testInvoicePaymentHash, testInvoice.Terms.Value/2,
+		testHtlcExpiry, testCurrentHeight, limitedKey, hodlChan1,
+		mppPayload,
+	)
+	require.NoError(t, err)
+	require.Nil(t, resolution, "expected no direct resolution")
+
+	ctx.clock.SetTime(testTime.Add(10 * time.Second))
+
+	htlcResolution := (<-hodlChan1).(HtlcResolution)
+	require.IsType(t, &HtlcFailResolution{}, htlcResolution)
+	failResolution := htlcResolution.(*HtlcFailResolution)
+	require.Equal(t, ResultHtlcHoldTimeExceeded, failResolution.Outcome)
+
+	// Add a hold invoice and pay it in full with two htlcs, one of them
+	// over the limited channel, so that it moves to the accepted state.
+	hodlPreimage := lntypes.Preimage{2}
+	hodlHash := hodlPreimage.Hash()
+	_, err = ctx.registry.AddInvoice(testHodlInvoice, hodlHash)
+	require.NoError(t, err)
+
+	limitedKey.HtlcID = 21
+	hodlChan2 := make(chan interface{}, 1)
+	resolution, err = ctx.registry.NotifyExitHopHtlc(
+		hodlHash, testInvoiceAmt/2, testHtlcExpiry, testCurrentHeight,
+		limitedKey, hodlChan2, mppPayload,
+	)
+	require.NoError(t, err)
+	require.Nil(t, resolution, "expected htlc to be held")
+
+	hodlChan3 := make(chan interface{}, 1)
+	resolution, err = ctx.registry.NotifyExitHopHtlc(
+		hodlHash, testInvoiceAmt/2, testHtlcExpiry, testCurrentHeight,
+		getCircuitKey(22), hodlChan3, mppPayload,
+	)
+	require.NoError(t, err)
+	require.Nil(t, resolution, "expected htlc to be held")
+
+	inv, err := ctx.registry.LookupInvoice(hodlHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractAccepted, inv.State)
+
+	// Once the channel limit has passed, only the htlc over the limited
+	// channel is failed back. The invoice isn't canceled, but moves back
+	// to the open state, as its htlc set is no longer complete.
+	ctx.clock.SetTime(testTime.Add(20 * time.Second))
+
+	htlcResolution = (<-hodlChan2).(HtlcResolution)
+	require.IsType(t, &HtlcFailResolution{}, htlcResolution)
+	failResolution = htlcResolution.(*HtlcFailResolution)
+	require.Equal(t, ResultHtlcHoldTimeExceeded, failResolution.Outcome)
+
+	inv, err = ctx.registry.LookupInvoice(hodlHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractOpen, inv.State)
+
+	// The remaining htlc of the incomplete set is released once the mpp
+	// timeout has passed, while the invoice stays open.
+	ctx.clock.SetTime(testTime.Add(40 * time.Second))
+
+	htlcResolution = (<-hodlChan3).(HtlcResolution)
+	require.IsType(t, &HtlcFailResolution{}, htlcResolution)
+	failResolution = htlcResolution.(*HtlcFailResolution)
+	require.Equal(t, ResultMppTimeout, failResolution.Outcome)
+
+	inv, err = ctx.registry.LookupInvoice(hodlHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractOpen, inv.State)
+}
+
 // Tests that invoices are canceled after expiration.
 func TestInvoiceExpiryWithRegistry(t *testing.T) {
 	t.Parallel()
@@ -120,6 +120,10 @@ const (
 	// ResultAmpReconstruction is returned when the derived child
 	// hash/preimage pairs were invalid for at least one HTLC in the set.
 	ResultAmpReconstruction
+
+	// ResultHtlcHoldTimeExceeded is returned when an accepted htlc
+	// remained unresolved for longer than its hold time limit.
+	ResultHtlcHoldTimeExceeded
 )
 
 // String returns a string representation of the result.
@@ -189,6 +193,9 @@ func (f FailResolutionResult) FailureString() string {
 	case ResultAmpReconstruction:
 		return "amp reconstruction failed"
 
+	case ResultHtlcHoldTimeExceeded:
+		return "htlc hold time exceeded"
+
 	default:
 		return "unknown failure resolution result"
 	}
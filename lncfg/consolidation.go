@@ -0,0 +1,84 @@
+package lncfg
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultConsolidationInterval is the default interval at which we
+	// check whether fees are low enough to consolidate utxos.
+	DefaultConsolidationInterval = time.Hour
+
+	// DefaultConsolidationConfTarget is the default confirmation target
+	// used to estimate the fee rate of background consolidations.
+	DefaultConsolidationConfTarget = 144
+
+	// DefaultConsolidationMaxFeeRate is the default maximum fee rate in
+	// sat/vbyte at which utxos are consolidated in the background.
+	DefaultConsolidationMaxFeeRate = 5
+
+	// DefaultConsolidationMaxUtxoValue is the default maximum value in
+	// satoshis of the utxos that are consolidated.
+	DefaultConsolidationMaxUtxoValue = 100_000
+
+	// DefaultConsolidationMinUtxos is the default minimum number of utxos
+	// that need to qualify for a consolidation to happen.
+	DefaultConsolidationMinUtxos = 10
+
+	// MinConsolidationInterval is the minimum interval that can be
+	// configured between consolidation checks.
+	MinConsolidationInterval = time.Minute
+)
+
+// Consolidation holds the configuration options for consolidating small wallet
+// utxos into a larger one while on-chain fees are low.
+type Consolidation struct {
+	Active bool `long:"active" description:"If true, small wallet utxos are consolidated into a single output in the background whenever on-chain fees are low."`
+
+	Interval time.Duration `long:"interval" description:"How often to check whether fees are low enough to consolidate utxos."`
+
+	ConfTarget uint32 `long:"conf-target" description:"The confirmation target used to estimate the fee rate of background consolidations."`
+
+	MaxFeeRate uint64 `long:"max-fee-rate" description:"The maximum fee rate in sat/vbyte at which utxos are consolidated in the background."`
+
+	MaxUtxoValue int64 `long:"max-utxo-value" description:"Only utxos with a value of at most this amount in satoshis are consolidated."`
+
+	MinUtxos uint32 `long:"min-utxos" description:"The minimum number of utxos that need to qualify for a consolidation to happen."`
+}
+
+// Validate checks the consolidation policy for invalid values.
+func (c *Consolidation) Validate() error {
+	if c.MaxUtxoValue <= 0 {
+		return fmt.Errorf("max utxo value must be positive")
+	}
+
+	if c.MinUtxos < 2 {
+		return fmt.Errorf("at least 2 utxos are needed for a " +
+			"consolidation")
+	}
+
+	if !c.Active {
+		return nil
+	}
+
+	if c.Interval < MinConsolidationInterval {
+		return fmt.Errorf("consolidation interval %v is less than "+
+			"the minimum of %v", c.Interval,
+			MinConsolidationInterval)
+	}
+
+	if c.ConfTarget == 0 {
+		return fmt.Errorf("consolidation conf target must be positive")
+	}
+
+	if c.MaxFeeRate == 0 {
+		return fmt.Errorf("consolidation max fee rate must be positive")
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Consolidation implements the Validator
+// interface.
+var _ Validator = (*Consolidation)(nil)
@@ -0,0 +1,57 @@
+package lncfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConsolidationValidate tests that the consolidation policy is validated,
+// and that the background job options are only checked if it's active.
+func TestConsolidationValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		active       bool
+		interval     time.Duration
+		confTarget   uint32
+		maxFeeRate   uint64
+		maxUtxoValue int64
+		minUtxos     uint32
+		valid        bool
+	}{
+		{"valid", true, time.Hour, 144, 5, 100_000, 10, true},
+		{"inactive", false, 0, 0, 0, 100_000, 10, true},
+		{"inactive zero max utxo value", false, 0, 0, 0, 0, 10, false},
+		{"negative max value", true, time.Hour, 144, 5, -1, 10, false},
+		{"single utxo", true, time.Hour, 144, 5, 100_000, 1, false},
+		{"interval too small", true, time.Second, 144, 5, 100_000, 10,
+			false},
+		{"zero conf target", true, time.Hour, 0, 5, 100_000, 10, false},
+		{"zero fee rate", true, time.Hour, 144, 0, 100_000, 10, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Consolidation{
+				Active:       tc.active,
+				Interval:     tc.interval,
+				ConfTarget:   tc.confTarget,
+				MaxFeeRate:   tc.maxFeeRate,
+				MaxUtxoValue: tc.maxUtxoValue,
+				MinUtxos:     tc.minUtxos,
+			}
+
+			err := c.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
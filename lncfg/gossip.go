@@ -15,6 +15,10 @@ type Gossip struct {
 	MaxChannelUpdateBurst int `long:"max-channel-update-burst" description:"The maximum number of updates for a specific channel and direction that lnd will accept over the channel update interval."`
 
 	ChannelUpdateInterval time.Duration `long:"channel-update-interval" description:"The interval used to determine how often lnd should allow a burst of new updates for a specific channel and direction."`
+
+	MaxPeerChannelUpdateBurst int `long:"max-peer-channel-update-burst" description:"The maximum number of updates for already known channels that lnd will accept from a single peer over the peer channel update interval. Setting this to 0 disables per-peer rate limiting."`
+
+	PeerChannelUpdateInterval time.Duration `long:"peer-channel-update-interval" description:"The interval used to determine how often lnd should allow a new update for an already known channel from a specific peer."`
 }
 
 // Parse the pubkeys for the pinned syncers.
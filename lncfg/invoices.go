@@ -1,5 +1,14 @@
 package lncfg
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
 // DefaultHoldInvoiceExpiryDelta defines the number of blocks before the expiry
 // height of a hold invoice's htlc that lnd will automatically cancel the
 // invoice to prevent the channel from force closing. This value *must* be
@@ -9,4 +18,50 @@ const DefaultHoldInvoiceExpiryDelta = DefaultIncomingBroadcastDelta + 2
 // Invoices holds the configuration options for invoices.
 type Invoices struct {
 	HoldExpiryDelta uint32 `long:"holdexpirydelta" description:"The number of blocks before a hold invoice's htlc expires that the invoice should be canceled to prevent a force close. Force closes will not be prevented if this value is not greater than DefaultIncomingBroadcastDelta."`
+
+	MaxHtlcHoldTime time.Duration `long:"maxhtlcholdtime" description:"The maximum duration that an accepted htlc paying to one of our invoices, or a forward held by an htlc interceptor, may remain unresolved before it's failed back. Setting this value to 0 disables the limit."`
+
+	ChanMaxHtlcHoldTimesRaw []string `long:"chanmaxhtlcholdtime" description:"Overrides maxhtlcholdtime for the htlcs arriving over a specific channel, specified as <chan_id>:<duration>, e.g. 770495967390531585:30s. Can be set multiple times."`
+
+	ChanMaxHtlcHoldTimes map[lnwire.ShortChannelID]time.Duration
+}
+
+// Parse parses the per-channel htlc hold time limits and validates that none
+// of the limits are negative.
+func (i *Invoices) Parse() error {
+	if i.MaxHtlcHoldTime < 0 {
+		return fmt.Errorf("maxhtlcholdtime must not be negative, "+
+			"got %v", i.MaxHtlcHoldTime)
+	}
+
+	holdTimes := make(map[lnwire.ShortChannelID]time.Duration)
+	for _, holdTimeStr := range i.ChanMaxHtlcHoldTimesRaw {
+		parts := strings.Split(holdTimeStr, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid channel hold time %v, "+
+				"expected <chan_id>:<duration>", holdTimeStr)
+		}
+
+		chanID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chan_id %v: %v", parts[0],
+				err)
+		}
+
+		holdTime, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid hold time %v: %v", parts[1],
+				err)
+		}
+		if holdTime < 0 {
+			return fmt.Errorf("hold time of channel %v must not "+
+				"be negative, got %v", parts[0], holdTime)
+		}
+
+		holdTimes[lnwire.NewShortChanIDFromInt(chanID)] = holdTime
+	}
+
+	i.ChanMaxHtlcHoldTimes = holdTimes
+
+	return nil
 }
@@ -0,0 +1,63 @@
+package lncfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInvoicesParse tests that the htlc hold time limits are parsed, and that
+// negative limits are rejected.
+func TestInvoicesParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		maxHoldTime   time.Duration
+		chanHoldTimes []string
+		valid         bool
+	}{
+		{"no limits", 0, nil, true},
+		{"global limit", time.Minute, nil, true},
+		{"negative global limit", -time.Minute, nil, false},
+		{"channel limit", 0, []string{"123:30s"}, true},
+		{"zero channel limit", time.Minute, []string{"123:0s"}, true},
+		{"negative channel limit", 0, []string{"123:-30s"}, false},
+		{"missing duration", 0, []string{"123"}, false},
+		{"invalid chan id", 0, []string{"abc:30s"}, false},
+		{"invalid duration", 0, []string{"123:soon"}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Invoices{
+				MaxHtlcHoldTime:         tc.maxHoldTime,
+				ChanMaxHtlcHoldTimesRaw: tc.chanHoldTimes,
+			}
+
+			err := cfg.Parse()
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(
+				t, cfg.ChanMaxHtlcHoldTimes,
+				len(tc.chanHoldTimes),
+			)
+		})
+	}
+
+	// The per-channel limits are keyed by their short channel id.
+	cfg := &Invoices{
+		ChanMaxHtlcHoldTimesRaw: []string{"123:30s", "456:1m"},
+	}
+	require.NoError(t, cfg.Parse())
+	require.Equal(t, map[lnwire.ShortChannelID]time.Duration{
+		lnwire.NewShortChanIDFromInt(123): 30 * time.Second,
+		lnwire.NewShortChanIDFromInt(456): time.Minute,
+	}, cfg.ChanMaxHtlcHoldTimes)
+}
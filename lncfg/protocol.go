@@ -29,6 +29,11 @@ type ProtocolOptions struct {
 	// opening or accepting channels having the script enforced commitment
 	// type for leased channel.
 	NoScriptEnforcedLease bool `long:"no-script-enforced-lease" description:"disable support for script enforced lease commitments"`
+
+	// OptionScidAlias should be set if we want to signal the
+	// option-scid-alias feature bit. This allows scid aliases and the
+	// option-scid-alias channel-type.
+	OptionScidAlias bool `long:"option-scid-alias" description:"enable support for option_scid_alias channels"`
 }
 
 // Wumbo returns true if lnd should permit the creation and acceptance of wumbo
@@ -48,3 +53,8 @@ func (l *ProtocolOptions) NoAnchorCommitments() bool {
 func (l *ProtocolOptions) NoScriptEnforcementLease() bool {
 	return l.NoScriptEnforcedLease
 }
+
+// ScidAlias returns true if we have enabled the option-scid-alias feature bit.
+func (l *ProtocolOptions) ScidAlias() bool {
+	return l.OptionScidAlias
+}
@@ -30,6 +30,11 @@ type ProtocolOptions struct {
 	//
 	// TODO: Move to experimental?
 	ScriptEnforcedLease bool `long:"script-enforced-lease" description:"enable support for script enforced lease commitments"`
+
+	// OptionScidAlias should be set if we want to signal the
+	// option-scid-alias feature bit. This allows scid aliases and the
+	// option-scid-alias channel-type.
+	OptionScidAlias bool `long:"option-scid-alias" description:"enable support for option_scid_alias channels"`
 }
 
 // Wumbo returns true if lnd should permit the creation and acceptance of wumbo
@@ -49,3 +54,8 @@ func (l *ProtocolOptions) NoAnchorCommitments() bool {
 func (l *ProtocolOptions) NoScriptEnforcementLease() bool {
 	return !l.ScriptEnforcedLease
 }
+
+// ScidAlias returns true if we have enabled the option-scid-alias feature bit.
+func (l *ProtocolOptions) ScidAlias() bool {
+	return l.OptionScidAlias
+}
@@ -0,0 +1,109 @@
+package lncfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSignerHost     = "localhost:10019"
+	testSignerMacaroon = "/path/to/admin.macaroon"
+	testSignerCert     = "/path/to/tls.cert"
+)
+
+// TestRemoteSignerValidate tests that an enabled remote signer must be fully
+// configured, and that the watch-only migration requires remote signing.
+func TestRemoteSignerValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		signer RemoteSigner
+		valid  bool
+	}{
+		{
+			name: "valid",
+			signer: RemoteSigner{
+				Enable:       true,
+				RPCHost:      testSignerHost,
+				MacaroonPath: testSignerMacaroon,
+				TLSCertPath:  testSignerCert,
+				Timeout:      DefaultRemoteSignerRPCTimeout,
+			},
+			valid: true,
+		},
+		{
+			name: "valid with migration",
+			signer: RemoteSigner{
+				Enable:           true,
+				RPCHost:          testSignerHost,
+				MacaroonPath:     testSignerMacaroon,
+				TLSCertPath:      testSignerCert,
+				Timeout:          DefaultRemoteSignerRPCTimeout,
+				MigrateWatchOnly: true,
+			},
+			valid: true,
+		},
+		{
+			name:   "disabled",
+			signer: RemoteSigner{},
+			valid:  true,
+		},
+		{
+			name: "migration without remote signing",
+			signer: RemoteSigner{
+				MigrateWatchOnly: true,
+			},
+		},
+		{
+			name: "timeout too small",
+			signer: RemoteSigner{
+				Enable:       true,
+				RPCHost:      testSignerHost,
+				MacaroonPath: testSignerMacaroon,
+				TLSCertPath:  testSignerCert,
+			},
+		},
+		{
+			name: "missing rpc host",
+			signer: RemoteSigner{
+				Enable:       true,
+				MacaroonPath: testSignerMacaroon,
+				TLSCertPath:  testSignerCert,
+				Timeout:      DefaultRemoteSignerRPCTimeout,
+			},
+		},
+		{
+			name: "missing macaroon path",
+			signer: RemoteSigner{
+				Enable:      true,
+				RPCHost:     testSignerHost,
+				TLSCertPath: testSignerCert,
+				Timeout:     DefaultRemoteSignerRPCTimeout,
+			},
+		},
+		{
+			name: "missing tls cert path",
+			signer: RemoteSigner{
+				Enable:       true,
+				RPCHost:      testSignerHost,
+				MacaroonPath: testSignerMacaroon,
+				Timeout:      DefaultRemoteSignerRPCTimeout,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.signer.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,35 @@
 package lncfg
 
+import (
+	"fmt"
+	"time"
+)
+
 // Routing holds the configuration options for routing.
 type Routing struct {
 	AssumeChannelValid bool `long:"assumechanvalid" description:"Skip checking channel spentness during graph validation. This speedup comes at the risk of using an unvalidated view of the network for routing. (default: false)"`
 
 	StrictZombiePruning bool `long:"strictgraphpruning" description:"If true, then the graph will be pruned more aggressively for zombies. In practice this means that edges with a single stale edge will be considered a zombie."`
+
+	ChannelPruneExpiry time.Duration `long:"chanpruneexpiry" description:"The duration after which a channel whose edges haven't been refreshed by a channel update is considered a zombie and pruned from the graph."`
+
+	GraphPruneInterval time.Duration `long:"graphpruneinterval" description:"The interval at which the graph is checked for zombie channels."`
 }
+
+// Validate checks the Routing configuration for values that would leave the
+// graph pruning logic in a broken state.
+func (r *Routing) Validate() error {
+	if r.ChannelPruneExpiry <= 0 {
+		return fmt.Errorf("channel prune expiry must be positive, "+
+			"got %v", r.ChannelPruneExpiry)
+	}
+	if r.GraphPruneInterval <= 0 {
+		return fmt.Errorf("graph prune interval must be positive, "+
+			"got %v", r.GraphPruneInterval)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Routing implements the Validator interface.
+var _ Validator = (*Routing)(nil)
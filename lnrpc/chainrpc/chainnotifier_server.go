@@ -54,6 +54,22 @@ var (
 			Entity: "onchain",
 			Action: "read",
 		}},
+		"/chainrpc.ChainKit/GetBlock": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/chainrpc.ChainKit/GetBlockHeader": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/chainrpc.ChainKit/GetBestBlock": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/chainrpc.ChainKit/GetBlockHash": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
 	}
 
 	// DefaultChainNotifierMacFilename is the default name of the chain
@@ -78,16 +94,19 @@ var (
 // have the necessary dependencies to populate the actual sub-server.
 type ServerShell struct {
 	ChainNotifierServer
+	ChainKitServer
 }
 
 // Server is a sub-server of the main RPC server: the chain notifier RPC. This
 // RPC sub-server allows external callers to access the full chain notifier
 // capabilities of lnd. This allows callers to create custom protocols, external
 // to lnd, even backed by multiple distinct lnd across independent failure
-// domains.
+// domains. It also serves the ChainKit RPC, which gives access to the block
+// data of the active chain backend.
 type Server struct {
 	// Required by the grpc-gateway/v2 library for forward compatibility.
 	UnimplementedChainNotifierServer
+	UnimplementedChainKitServer
 
 	started sync.Once
 	stopped sync.Once
@@ -149,8 +168,10 @@ func New(cfg *Config) (*Server, lnrpc.MacaroonPerms, error) {
 }
 
 // Compile-time checks to ensure that Server fully implements the
-// ChainNotifierServer gRPC service and lnrpc.SubServer interface.
+// ChainNotifierServer and ChainKitServer gRPC services and lnrpc.SubServer
+// interface.
 var _ ChainNotifierServer = (*Server)(nil)
+var _ ChainKitServer = (*Server)(nil)
 var _ lnrpc.SubServer = (*Server)(nil)
 
 // Start launches any helper goroutines required for the server to function.
@@ -192,6 +213,11 @@ func (r *ServerShell) RegisterWithRootServer(grpcServer *grpc.Server) error {
 	log.Debug("ChainNotifier RPC server successfully register with root " +
 		"gRPC server")
 
+	RegisterChainKitServer(grpcServer, r)
+
+	log.Debug("ChainKit RPC server successfully register with root " +
+		"gRPC server")
+
 	return nil
 }
 
@@ -214,6 +240,16 @@ func (r *ServerShell) RegisterWithRestServer(ctx context.Context,
 
 	log.Debugf("ChainNotifier REST server successfully registered with " +
 		"root REST server")
+
+	err = RegisterChainKitHandlerFromEndpoint(ctx, mux, dest, opts)
+	if err != nil {
+		log.Errorf("Could not register ChainKit REST server with "+
+			"root REST server: %v", err)
+		return err
+	}
+
+	log.Debugf("ChainKit REST server successfully registered with " +
+		"root REST server")
 	return nil
 }
 
@@ -233,9 +269,101 @@ func (r *ServerShell) CreateSubServer(configRegistry lnrpc.SubServerConfigDispat
 	}
 
 	r.ChainNotifierServer = subServer
+	r.ChainKitServer = subServer
 	return subServer, macPermissions, nil
 }
 
+// GetBlock returns a block given the corresponding block hash.
+//
+// NOTE: This is part of the chainrpc.ChainKitServer interface.
+func (s *Server) GetBlock(_ context.Context,
+	in *GetBlockRequest) (*GetBlockResponse, error) {
+
+	blockHash, err := chainhash.NewHash(in.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.cfg.Chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize block for RPC response.
+	var blockBuf bytes.Buffer
+	if err := block.Serialize(&blockBuf); err != nil {
+		return nil, err
+	}
+
+	return &GetBlockResponse{
+		RawBlock: blockBuf.Bytes(),
+	}, nil
+}
+
+// GetBlockHeader returns a block header given the corresponding block hash.
+// The header is taken from the full block, as that is the only way to look up
+// blocks that all chain backends support.
+//
+// NOTE: This is part of the chainrpc.ChainKitServer interface.
+func (s *Server) GetBlockHeader(_ context.Context,
+	in *GetBlockHeaderRequest) (*GetBlockHeaderResponse, error) {
+
+	blockHash, err := chainhash.NewHash(in.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.cfg.Chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize block header for RPC response.
+	var headerBuf bytes.Buffer
+	if err := block.Header.Serialize(&headerBuf); err != nil {
+		return nil, err
+	}
+
+	return &GetBlockHeaderResponse{
+		RawBlockHeader: headerBuf.Bytes(),
+	}, nil
+}
+
+// GetBestBlock returns the latest block hash and current height of the valid
+// most-work chain.
+//
+// NOTE: This is part of the chainrpc.ChainKitServer interface.
+func (s *Server) GetBestBlock(_ context.Context,
+	_ *GetBestBlockRequest) (*GetBestBlockResponse, error) {
+
+	blockHash, blockHeight, err := s.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetBestBlockResponse{
+		BlockHash:   blockHash[:],
+		BlockHeight: blockHeight,
+	}, nil
+}
+
+// GetBlockHash returns the hash of the block in the best blockchain at the
+// given height.
+//
+// NOTE: This is part of the chainrpc.ChainKitServer interface.
+func (s *Server) GetBlockHash(_ context.Context,
+	req *GetBlockHashRequest) (*GetBlockHashResponse, error) {
+
+	blockHash, err := s.cfg.Chain.GetBlockHash(req.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetBlockHashResponse{
+		BlockHash: blockHash[:],
+	}, nil
+}
+
 // RegisterConfirmationsNtfn is a synchronous response-streaming RPC that
 // registers an intent for a client to be notified once a confirmation request
 // has reached its required number of confirmations on-chain.
@@ -302,13 +430,17 @@ func (s *Server) RegisterConfirmationsNtfn(in *ConfRequest,
 
 		// The transaction satisfying the request has been reorged out
 		// of the chain, so we'll send an event describing it.
-		case _, ok := <-confEvent.NegativeConf:
+		case reorgDepth, ok := <-confEvent.NegativeConf:
 			if !ok {
 				return chainntnfs.ErrChainNotifierShuttingDown
 			}
 
 			reorg := &ConfEvent{
-				Event: &ConfEvent_Reorg{Reorg: &Reorg{}},
+				Event: &ConfEvent_Reorg{
+					Reorg: &Reorg{
+						Depth: uint32(reorgDepth),
+					},
+				},
 			}
 			if err := confStream.Send(reorg); err != nil {
 				return err
@@ -0,0 +1,207 @@
+//go:build chainrpc
+// +build chainrpc
+
+package chainrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// confNotifier is a chain notifier that hands out a single, preconfigured
+// confirmation event.
+type confNotifier struct {
+	chainntnfs.ChainNotifier
+
+	confEvent *chainntnfs.ConfirmationEvent
+}
+
+func (n *confNotifier) Started() bool {
+	return true
+}
+
+func (n *confNotifier) RegisterConfirmationsNtfn(*chainhash.Hash, []byte,
+	uint32, uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return n.confEvent, nil
+}
+
+// confStream is a confirmation notification stream that forwards all events
+// sent to the client over a channel.
+type confStream struct {
+	ChainNotifier_RegisterConfirmationsNtfnServer
+
+	ctx    context.Context
+	events chan *ConfEvent
+}
+
+func (s *confStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *confStream) Send(event *ConfEvent) error {
+	s.events <- event
+	return nil
+}
+
+// TestRegisterConfirmationsNtfnReorg tests that the depth of a reorg that
+// removed a confirmed transaction is forwarded to the client.
+func TestRegisterConfirmationsNtfnReorg(t *testing.T) {
+	t.Parallel()
+
+	confEvent := chainntnfs.NewConfirmationEvent(1, func() {})
+	s := &Server{
+		cfg: Config{
+			ChainNotifier: &confNotifier{confEvent: confEvent},
+		},
+		quit: make(chan struct{}),
+	}
+
+	stream := &confStream{
+		ctx:    context.Background(),
+		events: make(chan *ConfEvent, 1),
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.RegisterConfirmationsNtfn(&ConfRequest{}, stream)
+	}()
+
+	confEvent.NegativeConf <- 3
+
+	select {
+	case event := <-stream.events:
+		reorg := event.GetReorg()
+		require.NotNil(t, reorg)
+		require.EqualValues(t, 3, reorg.Depth)
+
+	case <-time.After(time.Second * 5):
+		t.Fatal("reorg event not sent")
+	}
+
+	// Once the notifier is done with the request, the stream is closed.
+	confEvent.Done <- struct{}{}
+
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+
+	case <-time.After(time.Second * 5):
+		t.Fatal("stream not closed")
+	}
+}
+
+// errUnknownBlock is returned by the mock chain for blocks it doesn't know.
+var errUnknownBlock = errors.New("unknown block")
+
+// mockChain is a chain backend that knows about a single block, which is also
+// the best block.
+type mockChain struct {
+	lnwallet.BlockChainIO
+
+	block  *wire.MsgBlock
+	height int32
+}
+
+func (c *mockChain) GetBestBlock() (*chainhash.Hash, int32, error) {
+	hash := c.block.BlockHash()
+	return &hash, c.height, nil
+}
+
+func (c *mockChain) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	if height != int64(c.height) {
+		return nil, errUnknownBlock
+	}
+
+	hash := c.block.BlockHash()
+	return &hash, nil
+}
+
+func (c *mockChain) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	if *hash != c.block.BlockHash() {
+		return nil, errUnknownBlock
+	}
+
+	return c.block, nil
+}
+
+// TestChainKit tests that the ChainKit RPCs return the blocks, headers and
+// hashes of the chain backend, and reject invalid block hashes.
+func TestChainKit(t *testing.T) {
+	t.Parallel()
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   1,
+			Timestamp: time.Unix(1_600_000_000, 0),
+			Nonce:     1,
+		},
+		Transactions: []*wire.MsgTx{wire.NewMsgTx(2)},
+	}
+	blockHash := block.BlockHash()
+
+	s := &Server{
+		cfg: Config{
+			Chain: &mockChain{block: block, height: 100},
+		},
+	}
+	ctx := context.Background()
+
+	var rawBlock, rawHeader bytes.Buffer
+	require.NoError(t, block.Serialize(&rawBlock))
+	require.NoError(t, block.Header.Serialize(&rawHeader))
+
+	blockResp, err := s.GetBlock(ctx, &GetBlockRequest{
+		BlockHash: blockHash[:],
+	})
+	require.NoError(t, err)
+	require.Equal(t, rawBlock.Bytes(), blockResp.RawBlock)
+
+	headerResp, err := s.GetBlockHeader(ctx, &GetBlockHeaderRequest{
+		BlockHash: blockHash[:],
+	})
+	require.NoError(t, err)
+	require.Equal(t, rawHeader.Bytes(), headerResp.RawBlockHeader)
+
+	bestResp, err := s.GetBestBlock(ctx, &GetBestBlockRequest{})
+	require.NoError(t, err)
+	require.Equal(t, blockHash[:], bestResp.BlockHash)
+	require.EqualValues(t, 100, bestResp.BlockHeight)
+
+	hashResp, err := s.GetBlockHash(ctx, &GetBlockHashRequest{
+		BlockHeight: 100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, blockHash[:], hashResp.BlockHash)
+
+	// Hashes of an invalid length are rejected before the chain backend
+	// is queried.
+	_, err = s.GetBlock(ctx, &GetBlockRequest{BlockHash: []byte{1, 2, 3}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid hash length")
+	_, err = s.GetBlockHeader(ctx, &GetBlockHeaderRequest{
+		BlockHash: []byte{1, 2, 3},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid hash length")
+
+	// Errors of the chain backend are returned to the caller.
+	unknownHash := chainhash.Hash{1}
+	_, err = s.GetBlock(ctx, &GetBlockRequest{BlockHash: unknownHash[:]})
+	require.ErrorIs(t, err, errUnknownBlock)
+	_, err = s.GetBlockHeader(ctx, &GetBlockHeaderRequest{
+		BlockHash: unknownHash[:],
+	})
+	require.ErrorIs(t, err, errUnknownBlock)
+	_, err = s.GetBlockHash(ctx, &GetBlockHashRequest{BlockHeight: 101})
+	require.ErrorIs(t, err, errUnknownBlock)
+}
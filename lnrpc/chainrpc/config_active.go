@@ -5,6 +5,7 @@ package chainrpc
 
 import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/macaroons"
 )
 
@@ -32,4 +33,8 @@ type Config struct {
 	// notifier RPC server. The job of the chain notifier RPC server is
 	// simply to proxy valid requests to the active chain notifier instance.
 	ChainNotifier chainntnfs.ChainNotifier
+
+	// Chain provides access to the most up-to-date blockchain data of the
+	// active chain backend, which is served by the ChainKit RPC.
+	Chain lnwallet.BlockChainIO
 }
@@ -48,6 +48,9 @@ func createNewSubServer(configRegistry lnrpc.SubServerConfigDispatcher) (
 	case config.ChainNotifier == nil:
 		return nil, nil, fmt.Errorf("ChainNotifier must be set to " +
 			"create chainrpc")
+	case config.Chain == nil:
+		return nil, nil, fmt.Errorf("Chain must be set to create " +
+			"chainrpc")
 	}
 
 	return New(config)
@@ -18,6 +18,7 @@ import (
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/netann"
+	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/zpay32"
 )
@@ -72,6 +73,10 @@ type AddInvoiceConfig struct {
 	// GenAmpInvoiceFeatures returns a feature containing feature bits that
 	// should be advertised on freshly generated AMP invoices.
 	GenAmpInvoiceFeatures func() *lnwire.FeatureVector
+
+	// GetAlias returns the alias that the remote party assigned to the
+	// given channel, which is then used in its hop hints.
+	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
 }
 
 // AddInvoiceData contains the required data to create a new invoice.
@@ -125,6 +130,10 @@ type AddInvoiceData struct {
 	// RouteHints are optional route hints that can each be individually used
 	// to assist in reaching the invoice's destination.
 	RouteHints [][]zpay32.HopHint
+
+	// CustomRecords are optional custom records that are stored alongside
+	// the invoice, but aren't part of the payment request.
+	CustomRecords record.CustomSet
 }
 
 // paymentHashAndPreimage returns the payment hash and preimage for this invoice
@@ -393,6 +402,18 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 				isActive := cfg.IsChannelActive(chanID)
 
 				hopHintInfo := newHopHintInfo(c, isActive)
+
+				// If the peer assigned an alias to the
+				// channel, we'll hand that out instead of
+				// the confirmed short channel id.
+				if cfg.GetAlias != nil {
+					alias, err := cfg.GetAlias(chanID)
+					if err == nil {
+						hopHintInfo.ScidAlias =
+							alias.ToUint64()
+					}
+				}
+
 				filteredChannels = append(
 					filteredChannels, hopHintInfo,
 				)
@@ -468,7 +489,8 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 			PaymentAddr:     paymentAddr,
 			Features:        invoiceFeatures,
 		},
-		HodlInvoice: invoice.HodlInvoice,
+		HodlInvoice:   invoice.HodlInvoice,
+		CustomRecords: invoice.CustomRecords,
 	}
 
 	log.Tracef("[addinvoice] adding new invoice %v",
@@ -552,9 +574,14 @@ func chanCanBeHopHint(channel *HopHintInfo, cfg *SelectHopHintsCfg) (
 func addHopHint(hopHints *[][]zpay32.HopHint,
 	channel *HopHintInfo, chanPolicy *channeldb.ChannelEdgePolicy) {
 
+	chanID := channel.ShortChannelID
+	if channel.ScidAlias != 0 {
+		chanID = channel.ScidAlias
+	}
+
 	hopHint := zpay32.HopHint{
 		NodeID:      channel.RemotePubkey,
-		ChannelID:   channel.ShortChannelID,
+		ChannelID:   chanID,
 		FeeBaseMSat: uint32(chanPolicy.FeeBaseMSat),
 		FeeProportionalMillionths: uint32(
 			chanPolicy.FeeProportionalMillionths,
@@ -587,6 +614,11 @@ type HopHintInfo struct {
 
 	// ShortChannelID is the short channel ID of the channel.
 	ShortChannelID uint64
+
+	// ScidAlias is the alias that the remote party assigned to the
+	// channel. If set, it's used in the hop hint in place of the
+	// ShortChannelID.
+	ScidAlias uint64
 }
 
 func newHopHintInfo(c *channeldb.OpenChannel, isActive bool) *HopHintInfo {
@@ -118,6 +118,28 @@ func TestSelectHopHints(t *testing.T) {
 			CLTVExpiryDelta: privateChan1Policy.TimeLockDelta,
 		}
 
+		// Create a copy of private channel 1 that the peer assigned
+		// an alias to.
+		privateAlias        uint64 = 16_000_000 << 40
+		privateChannelAlias        = &HopHintInfo{
+			IsPublic:        false,
+			IsActive:        true,
+			FundingOutpoint: privateChannel1.FundingOutpoint,
+			RemotePubkey:    pubkey,
+			RemoteBalance:   100,
+			ShortChannelID:  private1ShortID,
+			ScidAlias:       privateAlias,
+		}
+		privateChannelAliasHint = zpay32.HopHint{
+			NodeID:      privateChannel1.RemotePubkey,
+			ChannelID:   privateAlias,
+			FeeBaseMSat: uint32(privateChan1Policy.FeeBaseMSat),
+			FeeProportionalMillionths: uint32(
+				privateChan1Policy.FeeProportionalMillionths,
+			),
+			CLTVExpiryDelta: privateChan1Policy.TimeLockDelta,
+		}
+
 		// Create a second private channel that we'll use for hints.
 		private2ShortID uint64 = 2
 		privateChannel2        = &HopHintInfo{
@@ -307,6 +329,26 @@ func TestSelectHopHints(t *testing.T) {
 			numHints:      1,
 			expectedHints: nil,
 		},
+		{
+			// If the peer assigned an alias to a channel, the hop
+			// hint should use it instead of the short channel id.
+			name: "channel with alias",
+			setupMock: func(h *hopHintsConfigMock) {
+				setMockChannelUsed(
+					h, private1ShortID, privateChan1Policy,
+				)
+			},
+			amount: 100,
+			channels: []*HopHintInfo{
+				privateChannelAlias,
+			},
+			numHints: 1,
+			expectedHints: [][]zpay32.HopHint{
+				{
+					privateChannelAliasHint,
+				},
+			},
+		},
 		{
 			// This test case asserts that we limit our hop hints
 			// when we've reached our maximum number of hints.
@@ -60,4 +60,8 @@ type Config struct {
 	// GenAmpInvoiceFeatures returns a feature containing feature bits that
 	// should be advertised on freshly generated AMP invoices.
 	GenAmpInvoiceFeatures func() *lnwire.FeatureVector
+
+	// GetAlias returns the alias that the remote party assigned to the
+	// given channel, which is then used in its hop hints.
+	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
 }
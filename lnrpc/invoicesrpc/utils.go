@@ -174,6 +174,7 @@ func CreateRPCInvoice(invoice *channeldb.Invoice,
 		IsKeysend:       len(invoice.PaymentRequest) == 0 && !isAmp,
 		PaymentAddr:     invoice.Terms.PaymentAddr[:],
 		IsAmp:           isAmp,
+		CustomRecords:   invoice.CustomRecords,
 	}
 
 	rpcInvoice.AmpInvoiceState = make(map[string]*lnrpc.AMPInvoiceState)
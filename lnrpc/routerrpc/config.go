@@ -42,14 +42,23 @@ type Config struct {
 // DefaultConfig defines the config defaults.
 func DefaultConfig() *Config {
 	defaultRoutingConfig := RoutingConfig{
-		AprioriHopProbability: routing.DefaultAprioriHopProbability,
-		AprioriWeight:         routing.DefaultAprioriWeight,
-		MinRouteProbability:   routing.DefaultMinRouteProbability,
-		PenaltyHalfLife:       routing.DefaultPenaltyHalfLife,
-		AttemptCost:           routing.DefaultAttemptCost.ToSatoshis(),
-		AttemptCostPPM:        routing.DefaultAttemptCostPPM,
-		MaxMcHistory:          routing.DefaultMaxMcHistory,
-		McFlushInterval:       routing.DefaultMcFlushInterval,
+		ProbabilityEstimatorType: routing.DefaultEstimator,
+		AprioriHopProbability:    routing.DefaultAprioriHopProbability,
+		AprioriWeight:            routing.DefaultAprioriWeight,
+		BimodalScaleMsat:         routing.DefaultBimodalScaleMsat,
+		BimodalNodeWeight:        routing.DefaultBimodalNodeWeight,
+		BimodalDecayTime:         routing.DefaultBimodalDecayTime,
+		MinRouteProbability:      routing.DefaultMinRouteProbability,
+		PenaltyHalfLife:          routing.DefaultPenaltyHalfLife,
+		AttemptCost:              routing.DefaultAttemptCost.ToSatoshis(),
+		AttemptCostPPM:           routing.DefaultAttemptCostPPM,
+		RiskFactorBillionths:     routing.DefaultRiskFactorBillionths,
+		MaxMcHistory:             routing.DefaultMaxMcHistory,
+		McFlushInterval:          routing.DefaultMcFlushInterval,
+		MinFailureRelaxInterval:  routing.DefaultMinFailureRelaxInterval,
+		MaxShards:                DefaultMaxParts,
+		MinShardAmt:              routing.DefaultShardMinAmt.ToSatoshis(),
+		SplitStrategy:            routing.SplitStrategyHalving.String(),
 	}
 
 	return &Config{
@@ -60,13 +69,22 @@ func DefaultConfig() *Config {
 // GetRoutingConfig returns the routing config based on this sub server config.
 func GetRoutingConfig(cfg *Config) *RoutingConfig {
 	return &RoutingConfig{
-		AprioriHopProbability: cfg.AprioriHopProbability,
-		AprioriWeight:         cfg.AprioriWeight,
-		MinRouteProbability:   cfg.MinRouteProbability,
-		AttemptCost:           cfg.AttemptCost,
-		AttemptCostPPM:        cfg.AttemptCostPPM,
-		PenaltyHalfLife:       cfg.PenaltyHalfLife,
-		MaxMcHistory:          cfg.MaxMcHistory,
-		McFlushInterval:       cfg.McFlushInterval,
+		ProbabilityEstimatorType: cfg.ProbabilityEstimatorType,
+		AprioriHopProbability:    cfg.AprioriHopProbability,
+		AprioriWeight:            cfg.AprioriWeight,
+		BimodalScaleMsat:         cfg.BimodalScaleMsat,
+		BimodalNodeWeight:        cfg.BimodalNodeWeight,
+		BimodalDecayTime:         cfg.BimodalDecayTime,
+		MinRouteProbability:      cfg.MinRouteProbability,
+		AttemptCost:              cfg.AttemptCost,
+		AttemptCostPPM:           cfg.AttemptCostPPM,
+		RiskFactorBillionths:     cfg.RiskFactorBillionths,
+		PenaltyHalfLife:          cfg.PenaltyHalfLife,
+		MaxMcHistory:             cfg.MaxMcHistory,
+		McFlushInterval:          cfg.McFlushInterval,
+		MinFailureRelaxInterval:  cfg.MinFailureRelaxInterval,
+		MaxShards:                cfg.MaxShards,
+		MinShardAmt:              cfg.MinShardAmt,
+		SplitStrategy:            cfg.SplitStrategy,
 	}
 }
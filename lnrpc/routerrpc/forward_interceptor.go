@@ -59,7 +59,18 @@ func (r *forwardInterceptor) run() error {
 			return err
 		}
 
-		if err := r.resolveFromClient(resp); err != nil {
+		err = r.resolveFromClient(resp)
+
+		// The switch fails back held forwards on its own when they are
+		// about to expire, so a resolution may arrive for a forward
+		// that no longer exists. This doesn't need to terminate the
+		// stream.
+		if errors.Is(err, htlcswitch.ErrFwdNotExists) {
+			log.Debugf("Ignoring resolution from client: %v", err)
+
+			continue
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -101,6 +112,12 @@ func (r *forwardInterceptor) resolveFromClient(
 
 	log.Tracef("Resolving intercepted packet %v", in)
 
+	if in.IncomingCircuitKey == nil {
+		return status.Errorf(
+			codes.InvalidArgument, "incoming circuit key required",
+		)
+	}
+
 	circuitKey := channeldb.CircuitKey{
 		ChanID: lnwire.NewShortChanIDFromInt(in.IncomingCircuitKey.ChanId),
 		HtlcID: in.IncomingCircuitKey.HtlcId,
@@ -0,0 +1,23 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestResolveFromClientMissingCircuitKey tests that a resolution without an
+// incoming circuit key is rejected instead of being dereferenced.
+func TestResolveFromClientMissingCircuitKey(t *testing.T) {
+	t.Parallel()
+
+	interceptor := newForwardInterceptor(nil, nil)
+
+	err := interceptor.resolveFromClient(&ForwardHtlcInterceptResponse{
+		Action: ResolveHoldForwardAction_RESUME,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
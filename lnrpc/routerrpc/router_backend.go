@@ -78,6 +78,11 @@ type RouterBackend struct {
 	// when an RPC caller doesn't specify a value.
 	DefaultFinalCltvDelta uint16
 
+	// DefaultMaxParts is the maximum number of partial payments used when
+	// an RPC caller doesn't specify a value. If zero, DefaultMaxParts is
+	// used.
+	DefaultMaxParts uint32
+
 	// SubscribeHtlcEvents returns a subscription client for the node's
 	// htlc events.
 	SubscribeHtlcEvents func() (*subscribe.Client, error)
@@ -100,9 +105,10 @@ type RouterBackend struct {
 // MissionControl defines the mission control dependencies of routerrpc.
 type MissionControl interface {
 	// GetProbability is expected to return the success probability of a
-	// payment from fromNode to toNode.
+	// payment from fromNode to toNode. The capacity of the channel is zero
+	// if it's unknown.
 	GetProbability(fromNode, toNode route.Vertex,
-		amt lnwire.MilliSatoshi) float64
+		amt lnwire.MilliSatoshi, capacity btcutil.Amount) float64
 
 	// ResetHistory resets the history of MissionControl returning it to a
 	// state as if no payment attempts have been made.
@@ -257,7 +263,7 @@ func (r *RouterBackend) QueryRoutes(ctx context.Context,
 	restrictions := &routing.RestrictParams{
 		FeeLimit: feeLimit,
 		ProbabilitySource: func(fromNode, toNode route.Vertex,
-			amt lnwire.MilliSatoshi) float64 {
+			amt lnwire.MilliSatoshi, capacity btcutil.Amount) float64 {
 
 			if _, ok := ignoredNodes[fromNode]; ok {
 				return 0
@@ -276,7 +282,7 @@ func (r *RouterBackend) QueryRoutes(ctx context.Context,
 			}
 
 			return r.MissionControl.GetProbability(
-				fromNode, toNode, amt,
+				fromNode, toNode, amt, capacity,
 			)
 		},
 		DestCustomRecords: record.CustomSet(in.DestCustomRecords),
@@ -360,8 +366,15 @@ func (r *RouterBackend) getSuccessProbability(rt *route.Route) float64 {
 	for _, hop := range rt.Hops {
 		toNode := hop.PubKeyBytes
 
+		// The capacity is only used to improve the estimate, so we'll
+		// fall back to an unknown capacity if the lookup fails.
+		capacity, err := r.FetchChannelCapacity(hop.ChannelID)
+		if err != nil {
+			capacity = 0
+		}
+
 		probability := r.MissionControl.GetProbability(
-			fromNode, toNode, amtToFwd,
+			fromNode, toNode, amtToFwd, capacity,
 		)
 
 		successProb *= probability
@@ -598,6 +611,9 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	// isn't set, then we'll use the current default value for this
 	// setting.
 	maxParts := rpcPayReq.MaxParts
+	if maxParts == 0 {
+		maxParts = r.DefaultMaxParts
+	}
 	if maxParts == 0 {
 		maxParts = DefaultMaxParts
 	}
@@ -682,6 +698,13 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 			return nil, err
 		}
 
+		// We also require a payment address to be present to protect
+		// the receiver against probing.
+		err = ValidatePayReqPaymentAddr(payReq)
+		if err != nil {
+			return nil, err
+		}
+
 		// If the amount was not included in the invoice, then we let
 		// the payee specify the amount of satoshis they wish to send.
 		// We override the amount to pay with the amount provided from
@@ -976,6 +999,18 @@ func ValidatePayReqExpiry(payReq *zpay32.Invoice) error {
 	return nil
 }
 
+// ValidatePayReqPaymentAddr checks that the passed payment request carries a
+// payment address. Paying invoices without a payment address would allow
+// intermediate nodes to probe the receiver with the payment hash, which is why
+// BOLT 11 requires payers to reject them.
+func ValidatePayReqPaymentAddr(payReq *zpay32.Invoice) error {
+	if payReq.PaymentAddr == nil {
+		return errors.New("invoice doesn't include a payment address")
+	}
+
+	return nil
+}
+
 // ValidateCLTVLimit returns a valid CLTV limit given a value and a maximum. If
 // the value exceeds the maximum, then an error is returned. If the value is 0,
 // then the maximum is used.
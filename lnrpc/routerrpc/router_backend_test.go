@@ -13,6 +13,7 @@ import (
 	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/stretchr/testify/require"
 )
 
@@ -145,18 +146,18 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool,
 		}
 
 		if restrictions.ProbabilitySource(route.Vertex{2},
-			route.Vertex{1}, 0,
+			route.Vertex{1}, 0, 0,
 		) != 0 {
 			t.Fatal("expecting 0% probability for ignored edge")
 		}
 
 		if restrictions.ProbabilitySource(ignoreNodeVertex,
-			route.Vertex{6}, 0,
+			route.Vertex{6}, 0, 0,
 		) != 0 {
 			t.Fatal("expecting 0% probability for ignored node")
 		}
 
-		if restrictions.ProbabilitySource(node1, node2, 0) != 0 {
+		if restrictions.ProbabilitySource(node1, node2, 0, 0) != 0 {
 			t.Fatal("expecting 0% probability for ignored pair")
 		}
 
@@ -181,7 +182,7 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool,
 			expectedProb = testMissionControlProb
 		}
 		if restrictions.ProbabilitySource(route.Vertex{4},
-			route.Vertex{5}, 0,
+			route.Vertex{5}, 0, 0,
 		) != expectedProb {
 			t.Fatal("expecting 100% probability")
 		}
@@ -239,7 +240,7 @@ type mockMissionControl struct {
 }
 
 func (m *mockMissionControl) GetProbability(fromNode, toNode route.Vertex,
-	amt lnwire.MilliSatoshi) float64 {
+	amt lnwire.MilliSatoshi, capacity btcutil.Amount) float64 {
 
 	return testMissionControlProb
 }
@@ -468,3 +469,15 @@ func testUnmarshalAMP(t *testing.T, test unmarshalAMPTest) {
 		t.Fatalf("test case has non-standard outcome")
 	}
 }
+
+// TestValidatePayReqPaymentAddr asserts that payment requests without a
+// payment address are rejected.
+func TestValidatePayReqPaymentAddr(t *testing.T) {
+	t.Parallel()
+
+	payReq := &zpay32.Invoice{}
+	require.Error(t, ValidatePayReqPaymentAddr(payReq))
+
+	payReq.PaymentAddr = &[32]byte{1}
+	require.NoError(t, ValidatePayReqPaymentAddr(payReq))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -18,6 +19,7 @@ import (
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"google.golang.org/grpc"
@@ -357,6 +359,18 @@ func (s *Server) EstimateRouteFee(ctx context.Context,
 	// TODO: Change this into behaviour that makes more sense.
 	feeLimit := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
 
+	// Use the final CLTV delta of the destination if the caller knows it,
+	// otherwise fall back to our default.
+	finalCltvDelta := s.cfg.RouterBackend.DefaultFinalCltvDelta
+	if req.FinalCltvDelta != 0 {
+		if req.FinalCltvDelta > math.MaxUint16 {
+			return nil, fmt.Errorf("final cltv delta %v exceeds "+
+				"maximum of %v", req.FinalCltvDelta,
+				math.MaxUint16)
+		}
+		finalCltvDelta = uint16(req.FinalCltvDelta)
+	}
+
 	// Finally, we'll query for a route to the destination that can carry
 	// that target amount, we'll only request a single route. Set a
 	// restriction for the default CLTV limit, otherwise we can find a route
@@ -368,7 +382,7 @@ func (s *Server) EstimateRouteFee(ctx context.Context,
 			FeeLimit:          feeLimit,
 			CltvLimit:         s.cfg.RouterBackend.MaxTotalTimelock,
 			ProbabilitySource: mc.GetProbability,
-		}, nil, nil, s.cfg.RouterBackend.DefaultFinalCltvDelta,
+		}, nil, nil, finalCltvDelta,
 	)
 	if err != nil {
 		return nil, err
@@ -452,6 +466,10 @@ func (s *Server) GetMissionControlConfig(ctx context.Context,
 			Weight:                      float32(cfg.AprioriWeight),
 			MaximumPaymentResults:       uint32(cfg.MaxMcHistory),
 			MinimumFailureRelaxInterval: uint64(cfg.MinFailureRelaxInterval.Seconds()),
+			Estimator:                   cfg.Estimator,
+			BimodalScaleMsat:            uint64(cfg.BimodalScaleMsat),
+			BimodalNodeWeight:           float32(cfg.BimodalNodeWeight),
+			BimodalDecayTimeSeconds:     uint64(cfg.BimodalDecayTime.Seconds()),
 		},
 	}, nil
 }
@@ -461,7 +479,33 @@ func (s *Server) SetMissionControlConfig(ctx context.Context,
 	req *SetMissionControlConfigRequest) (*SetMissionControlConfigResponse,
 	error) {
 
+	// The estimator and its bimodal parameters were added later, so
+	// callers that don't set them keep the currently active ones.
+	currentCfg := s.cfg.RouterBackend.MissionControl.GetConfig()
+	estimator := currentCfg.Estimator
+	bimodalCfg := currentCfg.BimodalEstimatorCfg
+	if req.Config.Estimator != "" {
+		estimator = req.Config.Estimator
+	}
+	if req.Config.BimodalScaleMsat != 0 ||
+		req.Config.BimodalNodeWeight != 0 ||
+		req.Config.BimodalDecayTimeSeconds != 0 {
+
+		bimodalCfg = routing.BimodalEstimatorCfg{
+			BimodalScaleMsat: lnwire.MilliSatoshi(
+				req.Config.BimodalScaleMsat,
+			),
+			BimodalNodeWeight: float64(
+				req.Config.BimodalNodeWeight,
+			),
+			BimodalDecayTime: time.Duration(
+				req.Config.BimodalDecayTimeSeconds,
+			) * time.Second,
+		}
+	}
+
 	cfg := &routing.MissionControlConfig{
+		Estimator: estimator,
 		ProbabilityEstimatorCfg: routing.ProbabilityEstimatorCfg{
 			PenaltyHalfLife: time.Duration(
 				req.Config.HalfLifeSeconds,
@@ -469,7 +513,8 @@ func (s *Server) SetMissionControlConfig(ctx context.Context,
 			AprioriHopProbability: float64(req.Config.HopProbability),
 			AprioriWeight:         float64(req.Config.Weight),
 		},
-		MaxMcHistory: int(req.Config.MaximumPaymentResults),
+		BimodalEstimatorCfg: bimodalCfg,
+		MaxMcHistory:        int(req.Config.MaximumPaymentResults),
 		MinFailureRelaxInterval: time.Duration(
 			req.Config.MinimumFailureRelaxInterval,
 		) * time.Second,
@@ -582,6 +627,10 @@ func toPairSnapshot(pairResult *PairHistory) (*routing.MissionControlPairSnapsho
 			"differ", pairPrefix)
 	}
 
+	if pairResult.History == nil {
+		return nil, fmt.Errorf("%v history required", pairPrefix)
+	}
+
 	failAmt, failTime, err := getPair(
 		lnwire.MilliSatoshi(pairResult.History.FailAmtMsat),
 		btcutil.Amount(pairResult.History.FailAmtSat),
@@ -700,7 +749,9 @@ func (s *Server) QueryProbability(ctx context.Context,
 	amt := lnwire.MilliSatoshi(req.AmtMsat)
 
 	mc := s.cfg.RouterBackend.MissionControl
-	prob := mc.GetProbability(fromNode, toNode, amt)
+	// The request doesn't specify a channel, so the capacity between the
+	// nodes is unknown.
+	prob := mc.GetProbability(fromNode, toNode, amt, 0)
 	history := mc.GetPairHistorySnapshot(fromNode, toNode)
 
 	return &QueryProbabilityResponse{
@@ -809,15 +860,26 @@ func (s *Server) BuildRoute(ctx context.Context,
 
 	var payAddr *[32]byte
 	if len(req.PaymentAddr) != 0 {
+		if len(req.PaymentAddr) != 32 {
+			return nil, fmt.Errorf("payment address must be 32 "+
+				"bytes, got %v", len(req.PaymentAddr))
+		}
+
 		var backingPayAddr [32]byte
 		copy(backingPayAddr[:], req.PaymentAddr)
 
 		payAddr = &backingPayAddr
 	}
 
+	customRecords := record.CustomSet(req.DestCustomRecords)
+	if err := customRecords.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Build the route and return it to the caller.
 	route, err := s.cfg.Router.BuildRoute(
 		amt, hops, outgoingChan, req.FinalCltvDelta, payAddr,
+		customRecords,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,83 @@
+package routerrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToPairSnapshot tests the conversion of an RPC pair history into a
+// mission control pair snapshot.
+func TestToPairSnapshot(t *testing.T) {
+	t.Parallel()
+
+	from := route.Vertex{0x02}
+	to := route.Vertex{0x03}
+
+	testCases := []struct {
+		name    string
+		pair    *PairHistory
+		expErr  bool
+		failAmt lnwire.MilliSatoshi
+	}{
+		{
+			name: "missing history",
+			pair: &PairHistory{
+				NodeFrom: from[:],
+				NodeTo:   to[:],
+			},
+			expErr: true,
+		},
+		{
+			name: "same nodes",
+			pair: &PairHistory{
+				NodeFrom: from[:],
+				NodeTo:   from[:],
+				History:  &PairData{},
+			},
+			expErr: true,
+		},
+		{
+			name: "no results",
+			pair: &PairHistory{
+				NodeFrom: from[:],
+				NodeTo:   to[:],
+				History:  &PairData{},
+			},
+			expErr: true,
+		},
+		{
+			name: "failure",
+			pair: &PairHistory{
+				NodeFrom: from[:],
+				NodeTo:   to[:],
+				History: &PairData{
+					FailTime:    100,
+					FailAmtMsat: 2000,
+				},
+			},
+			failAmt: 2000,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			snapshot, err := toPairSnapshot(testCase.pair)
+			if testCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, from, snapshot.Pair.From)
+			require.Equal(t, to, snapshot.Pair.To)
+			require.Equal(t, testCase.failAmt, snapshot.FailAmt)
+			require.Equal(t, time.Unix(100, 0), snapshot.FailTime)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package routerrpc
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
 )
 
 // RoutingConfig contains the configurable parameters that control routing.
@@ -12,6 +14,10 @@ type RoutingConfig struct {
 	// to attempt the payment.
 	MinRouteProbability float64 `long:"minrtprob" description:"Minimum required route success probability to attempt the payment"`
 
+	// ProbabilityEstimatorType is the name of the probability estimator
+	// that is used in pathfinding.
+	ProbabilityEstimatorType string `long:"estimator" description:"The probability estimator used for pathfinding. 'apriori' uses a fixed a priori hop probability and the time since the last failure, 'bimodal' models channel liquidity as mostly located at either end of a channel and takes channel capacities into account" choice:"apriori" choice:"bimodal"`
+
 	// AprioriHopProbability is the assumed success probability of a hop in
 	// a route when no other information is available.
 	AprioriHopProbability float64 `long:"apriorihopprob" description:"Assumed success probability of a hop in a route when no other information is available."`
@@ -25,6 +31,19 @@ type RoutingConfig struct {
 	// results, unless there are none available.
 	AprioriWeight float64 `long:"aprioriweight" description:"Weight of the a priori probability in success probability estimation. Valid values are in [0, 1]."`
 
+	// BimodalScaleMsat describes the scale over which channels
+	// statistically have some liquidity left with the bimodal estimator.
+	BimodalScaleMsat lnwire.MilliSatoshi `long:"bimodalscale" description:"The scale in msat over which channels statistically have some liquidity left, used by the bimodal estimator. Smaller values assume more unbalanced channels"`
+
+	// BimodalNodeWeight defines how strongly previous forwardings on other
+	// channels of a router are taken into account by the bimodal
+	// estimator.
+	BimodalNodeWeight float64 `long:"bimodalnodeweight" description:"Weight of previous results on other channels of a node when estimating a channel's success probability with the bimodal estimator. Valid values are in [0, 1]."`
+
+	// BimodalDecayTime is the time scale over which previous results are
+	// forgotten by the bimodal estimator.
+	BimodalDecayTime time.Duration `long:"bimodaldecaytime" description:"The time scale over which previous payment results are forgotten by the bimodal estimator"`
+
 	// PenaltyHalfLife defines after how much time a penalized node or
 	// channel is back at 50% probability.
 	PenaltyHalfLife time.Duration `long:"penaltyhalflife" description:"Defines the duration after which a penalized node or channel is back at 50% probability"`
@@ -40,6 +59,10 @@ type RoutingConfig struct {
 	// expressed in parts per million of the total payment amount.
 	AttemptCostPPM int64 `long:"attemptcostppm" description:"The proportional (virtual) cost in sats of a failed payment attempt expressed in parts per million of the total payment amount"`
 
+	// RiskFactorBillionths controls the influence of the time lock delta
+	// of a channel on route selection.
+	RiskFactorBillionths int64 `long:"riskfactor" description:"The influence of the time lock of a channel on route selection, expressed as billionths of msat per msat sent through the channel per time lock delta block. Higher values favor routes with lower time locks over cheaper ones, zero ignores time locks"`
+
 	// MaxMcHistory defines the maximum number of payment results that
 	// are held on disk by mission control.
 	MaxMcHistory int `long:"maxmchistory" description:"the maximum number of payment results that are held on disk by mission control"`
@@ -47,4 +70,50 @@ type RoutingConfig struct {
 	// McFlushInterval defines the timer interval to use to flush mission
 	// control state to the DB.
 	McFlushInterval time.Duration `long:"mcflushinterval" description:"the timer interval to use to flush mission control state to the DB"`
+
+	// MinFailureRelaxInterval is the minimum time that must have passed
+	// since the previously recorded failure before the failure amount may
+	// be raised.
+	MinFailureRelaxInterval time.Duration `long:"minfailurerelaxinterval" description:"the minimum time that must have passed since the previously recorded failure of a channel before a failure for a larger amount is recorded for it"`
+
+	// MaxShards is the maximum number of partial payments that a payment
+	// may be split into if the caller doesn't specify a value.
+	MaxShards uint32 `long:"maxshards" description:"The default maximum number of partial payments (shards) a payment can be split into if the caller doesn't specify one. Lower values consume fewer HTLC slots, higher values improve the chance of completing large payments"`
+
+	// MinShardAmt is the amount beyond which we won't try to further split
+	// a payment if no route is found.
+	MinShardAmt btcutil.Amount `long:"minshardamt" description:"The minimum amount in sats of a single shard when splitting a payment"`
+
+	// SplitStrategy defines how the amount of the next shard is picked if
+	// no route can be found for the current one.
+	SplitStrategy string `long:"splitstrategy" description:"The strategy used to pick the amount of the next shard when no route is found. 'halving' halves the amount, 'liquidity' first clamps it to the largest local channel balance" choice:"halving" choice:"liquidity"`
+}
+
+// Validate checks that the routing config values are sane.
+//
+// NOTE: This is part of the lncfg.Validator interface.
+func (c *RoutingConfig) Validate() error {
+	// A negative risk factor would reward long time locks in path
+	// finding, and could even make edge weights negative.
+	if c.RiskFactorBillionths < 0 {
+		return fmt.Errorf("riskfactor must not be negative, got %v",
+			c.RiskFactorBillionths)
+	}
+
+	// Without any shards, no payment could ever be attempted.
+	if c.MaxShards == 0 {
+		return fmt.Errorf("maxshards must be positive")
+	}
+
+	if c.MinShardAmt <= 0 {
+		return fmt.Errorf("minshardamt must be positive, got %v",
+			c.MinShardAmt)
+	}
+
+	if c.MinFailureRelaxInterval <= 0 {
+		return fmt.Errorf("minfailurerelaxinterval must be positive, "+
+			"got %v", c.MinFailureRelaxInterval)
+	}
+
+	return nil
 }
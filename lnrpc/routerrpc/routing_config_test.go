@@ -0,0 +1,103 @@
+package routerrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutingConfigValidate tests that invalid routing config values are
+// rejected.
+func TestRoutingConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		riskFactor  int64
+		maxShards   uint32
+		minShardAmt btcutil.Amount
+		relax       time.Duration
+		valid       bool
+	}{{
+		name:        "default values",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 10_000,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+		valid:       true,
+	}, {
+		name:        "zero risk factor",
+		riskFactor:  0,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 10_000,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+		valid:       true,
+	}, {
+		name:        "negative risk factor",
+		riskFactor:  -1,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 10_000,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+	}, {
+		name:        "single shard",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   1,
+		minShardAmt: 1,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+		valid:       true,
+	}, {
+		name:        "zero max shards",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   0,
+		minShardAmt: 10_000,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+	}, {
+		name:        "zero min shard amount",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 0,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+	}, {
+		name:        "negative min shard amount",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: -1,
+		relax:       routing.DefaultMinFailureRelaxInterval,
+	}, {
+		name:        "zero failure relax interval",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 10_000,
+		relax:       0,
+	}, {
+		name:        "negative failure relax interval",
+		riskFactor:  routing.DefaultRiskFactorBillionths,
+		maxShards:   DefaultMaxParts,
+		minShardAmt: 10_000,
+		relax:       -time.Minute,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig()
+			cfg.RiskFactorBillionths = testCase.riskFactor
+			cfg.MaxShards = testCase.maxShards
+			cfg.MinShardAmt = testCase.minShardAmt
+			cfg.MinFailureRelaxInterval = testCase.relax
+
+			err := cfg.Validate()
+			if testCase.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
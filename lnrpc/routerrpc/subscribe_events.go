@@ -194,6 +194,9 @@ func rpcFailureResolution(invoiceFailure invoices.FailResolutionResult) (
 	case invoices.ResultMppInProgress:
 		return FailureDetail_MPP_IN_PROGRESS, nil
 
+	case invoices.ResultHtlcHoldTimeExceeded:
+		return FailureDetail_HTLC_HOLD_TIME_EXCEEDED, nil
+
 	default:
 		return 0, fmt.Errorf("unknown fail resolution: %v",
 			invoiceFailure.FailureString())
@@ -3,10 +3,14 @@ package lnrpc
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 )
 
 const (
@@ -146,3 +150,86 @@ func GetChanPointFundingTxid(chanPoint *ChannelPoint) (*chainhash.Hash, error) {
 
 	return chainhash.NewHash(txid)
 }
+
+// UnmarshallOutPoint converts an outpoint from its lnrpc type to its canonical
+// type.
+func UnmarshallOutPoint(op *OutPoint) (*wire.OutPoint, error) {
+	if op == nil {
+		return nil, fmt.Errorf("empty outpoint provided")
+	}
+
+	var hash chainhash.Hash
+	switch {
+	case len(op.TxidBytes) == 0 && len(op.TxidStr) == 0:
+		fallthrough
+
+	case len(op.TxidBytes) != 0 && len(op.TxidStr) != 0:
+		return nil, fmt.Errorf("either TxidBytes or TxidStr must be " +
+			"specified, but not both")
+
+	// The hash was provided as raw bytes.
+	case len(op.TxidBytes) != 0:
+		copy(hash[:], op.TxidBytes)
+
+	// The hash was provided as a hex-encoded string.
+	case len(op.TxidStr) != 0:
+		h, err := chainhash.NewHashFromStr(op.TxidStr)
+		if err != nil {
+			return nil, err
+		}
+		hash = *h
+	}
+
+	return &wire.OutPoint{
+		Hash:  hash,
+		Index: op.OutputIndex,
+	}, nil
+}
+
+// UnmarshallCoinSelectionStrategy converts a lnrpc.CoinSelectionStrategy proto
+// type to its chanfunding counterpart. A nil strategy is returned if the global
+// config should be used.
+func UnmarshallCoinSelectionStrategy(
+	strategy CoinSelectionStrategy) (chanfunding.CoinSelectionStrategy,
+	error) {
+
+	switch strategy {
+	case CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG:
+		return nil, nil
+
+	case CoinSelectionStrategy_STRATEGY_LARGEST:
+		return chanfunding.LargestFirstCoinSelection{}, nil
+
+	case CoinSelectionStrategy_STRATEGY_RANDOM:
+		return chanfunding.RandomCoinSelection{}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %v",
+			strategy)
+	}
+}
+
+// UnmarshallWalletCoinSelectionStrategy converts a lnrpc.CoinSelectionStrategy
+// proto type to the on-chain wallet's coin selection strategy. A nil strategy
+// is returned if the global config should be used.
+func UnmarshallWalletCoinSelectionStrategy(
+	strategy CoinSelectionStrategy) (*wallet.CoinSelectionStrategy, error) {
+
+	var walletStrategy wallet.CoinSelectionStrategy
+	switch strategy {
+	case CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG:
+		return nil, nil
+
+	case CoinSelectionStrategy_STRATEGY_LARGEST:
+		walletStrategy = wallet.CoinSelectionLargest
+
+	case CoinSelectionStrategy_STRATEGY_RANDOM:
+		walletStrategy = wallet.CoinSelectionRandom
+
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %v",
+			strategy)
+	}
+
+	return &walletStrategy, nil
+}
@@ -38,7 +38,8 @@ type SignerClient interface {
 	//Note that when using this method to sign inputs belonging to the wallet,
 	//the only items of the SignDescriptor that need to be populated are pkScript
 	//in the TxOut field, the value in that same field, and finally the input
-	//index.
+	//index. Spending a p2tr output of the wallet additionally requires the full
+	//list of prev_outputs.
 	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
 	//
 	//SignMessage signs a message with the key specified in the key locator. The
@@ -143,7 +144,8 @@ type SignerServer interface {
 	//Note that when using this method to sign inputs belonging to the wallet,
 	//the only items of the SignDescriptor that need to be populated are pkScript
 	//in the TxOut field, the value in that same field, and finally the input
-	//index.
+	//index. Spending a p2tr output of the wallet additionally requires the full
+	//list of prev_outputs.
 	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
 	//
 	//SignMessage signs a message with the key specified in the key locator. The
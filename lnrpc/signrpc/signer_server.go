@@ -266,32 +266,11 @@ func (s *Server) SignOutputRaw(_ context.Context, in *SignReq) (*SignResp,
 		return nil, fmt.Errorf("unable to decode tx: %v", err)
 	}
 
-	var (
-		sigHashCache      = input.NewTxSigHashesV0Only(&txToSign)
-		prevOutputFetcher = txscript.NewMultiPrevOutFetcher(nil)
+	sigHashCache, prevOutputFetcher, err := sigHashesForRequest(
+		&txToSign, in,
 	)
-
-	// If we're spending one or more SegWit v1 (Taproot) inputs, then we
-	// need the full UTXO information available.
-	if len(in.PrevOutputs) > 0 {
-		if len(in.PrevOutputs) != len(txToSign.TxIn) {
-			return nil, fmt.Errorf("provided previous outputs " +
-				"doesn't match number of transaction inputs")
-		}
-
-		// Add all previous inputs to our sighash prev out fetcher so we
-		// can calculate the sighash correctly.
-		for idx, txIn := range txToSign.TxIn {
-			prevOutputFetcher.AddPrevOut(
-				txIn.PreviousOutPoint, &wire.TxOut{
-					Value:    in.PrevOutputs[idx].Value,
-					PkScript: in.PrevOutputs[idx].PkScript,
-				},
-			)
-		}
-		sigHashCache = txscript.NewTxSigHashes(
-			&txToSign, prevOutputFetcher,
-		)
+	if err != nil {
+		return nil, err
 	}
 
 	log.Debugf("Generating sigs for %v inputs: ", len(in.SignDescs))
@@ -334,6 +313,11 @@ func (s *Server) SignOutputRaw(_ context.Context, in *SignReq) (*SignResp,
 			}
 		}
 
+		if signDesc.Output == nil {
+			return nil, fmt.Errorf("output MUST be specified for " +
+				"every sign descriptor")
+		}
+
 		// If a witness script isn't passed, then we can't proceed, as
 		// in the p2wsh case, we can't properly generate the sighash.
 		// A P2WKH doesn't need a witness script. But SignOutputRaw
@@ -408,6 +392,8 @@ func (s *Server) SignOutputRaw(_ context.Context, in *SignReq) (*SignResp,
 // Note that when using this method to sign inputs belonging to the wallet, the
 // only items of the SignDescriptor that need to be populated are pkScript in
 // the TxOut field, the value in that same field, and finally the input index.
+// Spending a p2tr output of the wallet additionally requires the full list of
+// previous outputs.
 func (s *Server) ComputeInputScript(ctx context.Context,
 	in *SignReq) (*InputScriptResp, error) {
 
@@ -433,10 +419,20 @@ func (s *Server) ComputeInputScript(ctx context.Context,
 		return nil, fmt.Errorf("unable to decode tx: %v", err)
 	}
 
-	sigHashCache := input.NewTxSigHashesV0Only(&txToSign)
+	sigHashCache, prevOutputFetcher, err := sigHashesForRequest(
+		&txToSign, in,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	signDescs := make([]*input.SignDescriptor, 0, len(in.SignDescs))
 	for _, signDesc := range in.SignDescs {
+		if signDesc.Output == nil {
+			return nil, fmt.Errorf("output MUST be specified for " +
+				"every sign descriptor")
+		}
+
 		// For this method, the only fields that we care about are the
 		// hash type, and the information concerning the output as we
 		// only know how to provide full witnesses for outputs that we
@@ -446,9 +442,10 @@ func (s *Server) ComputeInputScript(ctx context.Context,
 				Value:    signDesc.Output.Value,
 				PkScript: signDesc.Output.PkScript,
 			},
-			HashType:   txscript.SigHashType(signDesc.Sighash),
-			SigHashes:  sigHashCache,
-			InputIndex: int(signDesc.InputIndex),
+			HashType:          txscript.SigHashType(signDesc.Sighash),
+			SigHashes:         sigHashCache,
+			InputIndex:        int(signDesc.InputIndex),
+			PrevOutputFetcher: prevOutputFetcher,
 		})
 	}
 
@@ -476,6 +473,38 @@ func (s *Server) ComputeInputScript(ctx context.Context,
 	return resp, nil
 }
 
+// sigHashesForRequest returns the sighash cache and previous output fetcher
+// for the transaction to sign. The full UTXO information is only needed when
+// spending one or more SegWit v1 (Taproot) inputs, otherwise only the v0
+// sighash midstate is computed.
+func sigHashesForRequest(tx *wire.MsgTx, in *SignReq) (*txscript.TxSigHashes,
+	*txscript.MultiPrevOutFetcher, error) {
+
+	prevOutputFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	if len(in.PrevOutputs) == 0 {
+		return input.NewTxSigHashesV0Only(tx), prevOutputFetcher, nil
+	}
+
+	if len(in.PrevOutputs) != len(tx.TxIn) {
+		return nil, nil, fmt.Errorf("provided previous outputs " +
+			"doesn't match number of transaction inputs")
+	}
+
+	// Add all previous inputs to our sighash prev out fetcher so we can
+	// calculate the sighash correctly.
+	for idx, txIn := range tx.TxIn {
+		prevOutputFetcher.AddPrevOut(
+			txIn.PreviousOutPoint, &wire.TxOut{
+				Value:    in.PrevOutputs[idx].Value,
+				PkScript: in.PrevOutputs[idx].PkScript,
+			},
+		)
+	}
+
+	return txscript.NewTxSigHashes(tx, prevOutputFetcher),
+		prevOutputFetcher, nil
+}
+
 // SignMessage signs a message with the key specified in the key locator. The
 // returned signature is fixed-size LN wire format encoded.
 func (s *Server) SignMessage(_ context.Context,
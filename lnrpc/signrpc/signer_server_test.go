@@ -0,0 +1,137 @@
+//go:build signrpc
+// +build signrpc
+
+package signrpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSigner is a signer that records the sign descriptors it's asked to
+// compute input scripts for. Any other call panics.
+type recordingSigner struct {
+	input.Signer
+
+	signDescs []*input.SignDescriptor
+}
+
+func (s *recordingSigner) ComputeInputScript(_ *wire.MsgTx,
+	signDesc *input.SignDescriptor) (*input.Script, error) {
+
+	s.signDescs = append(s.signDescs, signDesc)
+
+	return &input.Script{Witness: wire.TxWitness{{0x01}}}, nil
+}
+
+// newSignReqTx returns a serialized transaction that spends two inputs.
+func newSignReqTx(t *testing.T) (*wire.MsgTx, []byte) {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+	})
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{2}},
+	})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x00}})
+
+	var buf bytes.Buffer
+	require.NoError(t, tx.Serialize(&buf))
+
+	return tx, buf.Bytes()
+}
+
+// TestSignReqMissingOutput tests that sign descriptors without an output are
+// rejected instead of being dereferenced.
+func TestSignReqMissingOutput(t *testing.T) {
+	t.Parallel()
+
+	_, rawTx := newSignReqTx(t)
+	s := &Server{cfg: &Config{Signer: &recordingSigner{}}}
+	req := &SignReq{
+		RawTxBytes: rawTx,
+		SignDescs:  []*SignDescriptor{{WitnessScript: []byte{0x51}}},
+	}
+
+	_, err := s.ComputeInputScript(context.Background(), req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "output MUST be specified")
+
+	_, err = s.SignOutputRaw(context.Background(), req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "output MUST be specified")
+}
+
+// TestComputeInputScriptPrevOutputs tests that the previous outputs of a
+// request are used to compute the sighashes of taproot inputs, and that they
+// have to match the inputs of the transaction.
+func TestComputeInputScriptPrevOutputs(t *testing.T) {
+	t.Parallel()
+
+	tx, rawTx := newSignReqTx(t)
+	prevOutputs := []*TxOut{
+		{Value: 2000, PkScript: make([]byte, input.P2TRSize)},
+		{Value: 3000, PkScript: make([]byte, input.P2WPKHSize)},
+	}
+	signDescs := []*SignDescriptor{{
+		Output:     prevOutputs[0],
+		Sighash:    uint32(txscript.SigHashDefault),
+		InputIndex: 0,
+	}}
+
+	signer := &recordingSigner{}
+	s := &Server{cfg: &Config{Signer: signer}}
+
+	// Without the previous outputs, only the v0 sighashes can be
+	// computed.
+	_, err := s.ComputeInputScript(context.Background(), &SignReq{
+		RawTxBytes: rawTx,
+		SignDescs:  signDescs,
+	})
+	require.NoError(t, err)
+	require.Len(t, signer.signDescs, 1)
+	require.Equal(
+		t, input.NewTxSigHashesV0Only(tx), signer.signDescs[0].SigHashes,
+	)
+
+	// The previous outputs must match the inputs of the transaction.
+	_, err = s.ComputeInputScript(context.Background(), &SignReq{
+		RawTxBytes:  rawTx,
+		SignDescs:   signDescs,
+		PrevOutputs: prevOutputs[:1],
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "doesn't match number of transaction")
+
+	// With all previous outputs, the signer gets the full sighashes and
+	// is able to look up the outputs spent by the transaction.
+	signer.signDescs = nil
+	resp, err := s.ComputeInputScript(context.Background(), &SignReq{
+		RawTxBytes:  rawTx,
+		SignDescs:   signDescs,
+		PrevOutputs: prevOutputs,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.InputScripts, 1)
+	require.Len(t, signer.signDescs, 1)
+
+	signDesc := signer.signDescs[0]
+	prevOutFetcher := signDesc.PrevOutputFetcher
+	for idx, txIn := range tx.TxIn {
+		require.Equal(t, &wire.TxOut{
+			Value:    prevOutputs[idx].Value,
+			PkScript: prevOutputs[idx].PkScript,
+		}, prevOutFetcher.FetchPrevOutput(txIn.PreviousOutPoint))
+	}
+	require.Equal(
+		t, txscript.NewTxSigHashes(tx, prevOutFetcher),
+		signDesc.SigHashes,
+	)
+}
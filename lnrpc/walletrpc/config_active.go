@@ -61,6 +61,10 @@ type Config struct {
 	// sweeping inputs in batches back into the wallet.
 	Sweeper *sweep.UtxoSweeper
 
+	// Consolidator is used to consolidate the small utxos of the wallet on
+	// request.
+	Consolidator *sweep.UtxoConsolidator
+
 	// Chain is an interface that the WalletKit will use to determine state
 	// about the backing chain of the wallet.
 	Chain lnwallet.BlockChainIO
@@ -59,6 +59,10 @@ func createNewSubServer(configRegistry lnrpc.SubServerConfigDispatcher) (
 		return nil, nil, fmt.Errorf("Sweeper must be set to create " +
 			"WalletKit RPC server")
 
+	case config.Consolidator == nil:
+		return nil, nil, fmt.Errorf("Consolidator must be set to " +
+			"create WalletKit RPC server")
+
 	case config.Chain == nil:
 		return nil, nil, fmt.Errorf("Chain must be set to create " +
 			"WalletKit RPC server")
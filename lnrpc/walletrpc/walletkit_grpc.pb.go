@@ -101,6 +101,14 @@ type WalletKitClient interface {
 	//once. This is ideal when wanting to batch create a set of transactions.
 	SendOutputs(ctx context.Context, in *SendOutputsRequest, opts ...grpc.CallOption) (*SendOutputsResponse, error)
 	//
+	//ConsolidateUtxos creates, signs and publishes a transaction that
+	//consolidates the small utxos of the default wallet account into a single
+	//new output of the wallet. Only confirmed utxos with a value of at most
+	//max_utxo_value_sat that are worth spending at the chosen fee rate are
+	//consolidated. If less than min_utxos of them qualify, no transaction is
+	//created and an error is returned.
+	ConsolidateUtxos(ctx context.Context, in *ConsolidateUtxosRequest, opts ...grpc.CallOption) (*ConsolidateUtxosResponse, error)
+	//
 	//EstimateFee attempts to query the internal fee estimator of the wallet to
 	//determine the fee (in sat/kw) to attach to a transaction in order to
 	//achieve the confirmation target.
@@ -317,6 +325,15 @@ func (c *walletKitClient) SendOutputs(ctx context.Context, in *SendOutputsReques
 	return out, nil
 }
 
+func (c *walletKitClient) ConsolidateUtxos(ctx context.Context, in *ConsolidateUtxosRequest, opts ...grpc.CallOption) (*ConsolidateUtxosResponse, error) {
+	out := new(ConsolidateUtxosResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ConsolidateUtxos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletKitClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
 	out := new(EstimateFeeResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/EstimateFee", in, out, opts...)
@@ -475,6 +492,14 @@ type WalletKitServer interface {
 	//once. This is ideal when wanting to batch create a set of transactions.
 	SendOutputs(context.Context, *SendOutputsRequest) (*SendOutputsResponse, error)
 	//
+	//ConsolidateUtxos creates, signs and publishes a transaction that
+	//consolidates the small utxos of the default wallet account into a single
+	//new output of the wallet. Only confirmed utxos with a value of at most
+	//max_utxo_value_sat that are worth spending at the chosen fee rate are
+	//consolidated. If less than min_utxos of them qualify, no transaction is
+	//created and an error is returned.
+	ConsolidateUtxos(context.Context, *ConsolidateUtxosRequest) (*ConsolidateUtxosResponse, error)
+	//
 	//EstimateFee attempts to query the internal fee estimator of the wallet to
 	//determine the fee (in sat/kw) to attach to a transaction in order to
 	//achieve the confirmation target.
@@ -616,6 +641,9 @@ func (UnimplementedWalletKitServer) PublishTransaction(context.Context, *Transac
 func (UnimplementedWalletKitServer) SendOutputs(context.Context, *SendOutputsRequest) (*SendOutputsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendOutputs not implemented")
 }
+func (UnimplementedWalletKitServer) ConsolidateUtxos(context.Context, *ConsolidateUtxosRequest) (*ConsolidateUtxosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsolidateUtxos not implemented")
+}
 func (UnimplementedWalletKitServer) EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
 }
@@ -869,6 +897,24 @@ func _WalletKit_SendOutputs_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_ConsolidateUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsolidateUtxosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ConsolidateUtxos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ConsolidateUtxos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ConsolidateUtxos(ctx, req.(*ConsolidateUtxosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletKit_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EstimateFeeRequest)
 	if err := dec(in); err != nil {
@@ -1068,6 +1114,10 @@ var WalletKit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendOutputs",
 			Handler:    _WalletKit_SendOutputs_Handler,
 		},
+		{
+			MethodName: "ConsolidateUtxos",
+			Handler:    _WalletKit_ConsolidateUtxos_Handler,
+		},
 		{
 			MethodName: "EstimateFee",
 			Handler:    _WalletKit_EstimateFee_Handler,
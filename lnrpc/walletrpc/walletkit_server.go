@@ -83,6 +83,10 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/walletrpc.WalletKit/ConsolidateUtxos": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/walletrpc.WalletKit/EstimateFee": {{
 			Entity: "onchain",
 			Action: "read",
@@ -328,7 +332,8 @@ func (w *WalletKit) internalScope() waddrmgr.KeyScope {
 // information returned is: outpoint, amount in satoshis, address, address
 // type, scriptPubKey in hex and number of confirmations. The result is
 // filtered to contain outputs whose number of confirmations is between a
-// minimum and maximum number of confirmations specified by the user.
+// minimum and maximum number of confirmations specified by the user. Leased
+// outputs are only included if explicitly requested.
 func (w *WalletKit) ListUnspent(ctx context.Context,
 	req *ListUnspentRequest) (*ListUnspentResponse, error) {
 
@@ -360,6 +365,13 @@ func (w *WalletKit) ListUnspent(ctx context.Context,
 	// be shown available to us.
 	var utxos []*lnwallet.Utxo
 	err = w.cfg.CoinSelectionLocker.WithCoinSelectLock(func() error {
+		if req.IncludeLeased {
+			utxos, err = w.listUnspentIncludingLeased(
+				minConfs, maxConfs, req.Account,
+			)
+			return err
+		}
+
 		utxos, err = w.cfg.Wallet.ListUnspentWitness(
 			minConfs, maxConfs, req.Account,
 		)
@@ -379,6 +391,168 @@ func (w *WalletKit) ListUnspent(ctx context.Context,
 	}, nil
 }
 
+// listUnspentIncludingLeased returns the unspent witness outputs of the wallet
+// like ListUnspentWitness, but also includes the outputs that are currently
+// leased. The wallet never returns leased outputs, so they're looked up one by
+// one and added if they match the confirmation and account filters. Leased
+// outputs the wallet can't look up are skipped. The leases themselves are left
+// untouched.
+//
+// NOTE: This method requires the global coin selection lock to be held.
+func (w *WalletKit) listUnspentIncludingLeased(minConfs, maxConfs int32,
+	account string) ([]*lnwallet.Utxo, error) {
+
+	utxos, err := w.cfg.Wallet.ListUnspentWitness(
+		minConfs, maxConfs, account,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	leases, err := w.cfg.Wallet.ListLeasedOutputs()
+	if err != nil {
+		return nil, err
+	}
+	if len(leases) == 0 {
+		return utxos, nil
+	}
+
+	// The wallet only clears a lease once the transaction spending the
+	// output confirms, so we need to know about the unconfirmed
+	// transactions to skip the outputs they already spend.
+	unconfirmedTxs, spent, err := w.unconfirmedSpends()
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*waddrmgr.AccountProperties
+	if account != "" {
+		accounts, err = w.cfg.Wallet.ListAccounts(account, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, lease := range leases {
+		if _, ok := spent[lease.Outpoint]; ok {
+			continue
+		}
+
+		// A lease can outlive the output it locks, for example if the
+		// output was spent by a transaction the wallet no longer knows
+		// about. Such a stale lease shouldn't fail the whole listing.
+		utxo, err := w.cfg.Wallet.FetchInputInfo(&lease.Outpoint)
+		if err != nil {
+			log.Warnf("Skipping leased output %v: unable to fetch "+
+				"output info: %v", lease.Outpoint, err)
+			continue
+		}
+
+		// Only the witness outputs ListUnspentWitness would return are
+		// included.
+		if utxo.AddressType == lnwallet.UnknownAddressType {
+			continue
+		}
+
+		// Unlike ListUnspentWitness, the wallet counts the
+		// confirmations of a single output from zero for the block
+		// that includes it, and also reports zero for unconfirmed
+		// outputs.
+		confs := int32(0)
+		if _, ok := unconfirmedTxs[lease.Outpoint.Hash]; !ok {
+			confs = int32(utxo.Confirmations) + 1
+		}
+		if confs < minConfs || confs > maxConfs {
+			continue
+		}
+		utxo.Confirmations = int64(confs)
+
+		if account != "" {
+			match, err := w.outputInAccounts(utxo, accounts)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+
+		utxos = append(utxos, utxo)
+	}
+
+	return utxos, nil
+}
+
+// unconfirmedSpends returns the hashes of the wallet's unconfirmed
+// transactions, and the outpoints they spend.
+func (w *WalletKit) unconfirmedSpends() (map[chainhash.Hash]struct{},
+	map[wire.OutPoint]struct{}, error) {
+
+	txDetails, err := w.cfg.Wallet.ListTransactionDetails(-1, -1, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txs := make(map[chainhash.Hash]struct{}, len(txDetails))
+	spent := make(map[wire.OutPoint]struct{})
+	for _, txDetail := range txDetails {
+		if txDetail.NumConfirmations > 0 {
+			continue
+		}
+		txs[txDetail.Hash] = struct{}{}
+
+		var tx wire.MsgTx
+		err := tx.Deserialize(bytes.NewReader(txDetail.RawTx))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, txIn := range tx.TxIn {
+			spent[txIn.PreviousOutPoint] = struct{}{}
+		}
+	}
+
+	return txs, spent, nil
+}
+
+// outputInAccounts returns true if the given output belongs to one of the
+// given wallet accounts.
+func (w *WalletKit) outputInAccounts(utxo *lnwallet.Utxo,
+	accounts []*waddrmgr.AccountProperties) (bool, error) {
+
+	addr, _, _, err := w.cfg.Wallet.ScriptForOutput(&wire.TxOut{
+		Value:    int64(utxo.Value),
+		PkScript: utxo.PkScript,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Individually imported keys don't carry any derivation info, they
+	// all belong to the imported account of their key scope.
+	if addr.Imported() {
+		for _, account := range accounts {
+			name := account.AccountName
+			if name == waddrmgr.ImportedAddrAccountName {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	keyScope, _, _ := addr.DerivationInfo()
+	for _, account := range accounts {
+		if account.KeyScope == keyScope &&
+			account.AccountNumber == addr.InternalAccount() {
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // LeaseOutput locks an output to the given ID, preventing it from being
 // available for any future coin selection attempts. The absolute time of the
 // lock's expiration is returned. The expiration of the lock can be extended by
@@ -408,15 +582,15 @@ func (w *WalletKit) LeaseOutput(ctx context.Context,
 		return nil, errors.New("reserved id cannot be used")
 	}
 
-	op, err := unmarshallOutPoint(req.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use the specified lock duration or fall back to the default.
-	duration := DefaultLockDuration
-	if req.ExpirationSeconds != 0 {
-		duration = time.Duration(req.ExpirationSeconds) * time.Second
+	duration, err := leaseDuration(req.ExpirationSeconds)
+	if err != nil {
+		return nil, err
 	}
 
 	// Acquire the global coin selection lock to ensure there aren't any
@@ -437,6 +611,25 @@ func (w *WalletKit) LeaseOutput(ctx context.Context,
 	}, nil
 }
 
+// leaseDuration returns the duration of an output lease that expires after the
+// given number of seconds. If no expiration is set, the default lock duration
+// is used.
+func leaseDuration(expirationSeconds uint64) (time.Duration, error) {
+	if expirationSeconds == 0 {
+		return DefaultLockDuration, nil
+	}
+
+	// Reject durations that can't be represented, as they'd otherwise
+	// overflow into a lease that has already expired.
+	maxSeconds := uint64(math.MaxInt64 / int64(time.Second))
+	if expirationSeconds > maxSeconds {
+		return 0, fmt.Errorf("expiration of %d seconds exceeds the "+
+			"maximum of %d seconds", expirationSeconds, maxSeconds)
+	}
+
+	return time.Duration(expirationSeconds) * time.Second, nil
+}
+
 // ReleaseOutput unlocks an output, allowing it to be available for coin
 // selection if it remains unspent. The ID should match the one used to
 // originally lock the output.
@@ -449,7 +642,7 @@ func (w *WalletKit) ReleaseOutput(ctx context.Context,
 	var lockID wtxmgr.LockID
 	copy(lockID[:], req.Id)
 
-	op, err := unmarshallOutPoint(req.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -598,6 +791,24 @@ func (w *WalletKit) SendOutputs(ctx context.Context,
 	case len(req.Outputs) == 0:
 		return nil, fmt.Errorf("must specify at least one output " +
 			"to create")
+
+	// Without a fee rate we can't craft the transaction, as there's no
+	// fee estimation for this call.
+	case req.SatPerKw <= 0:
+		return nil, fmt.Errorf("a positive fee rate must be specified")
+	}
+
+	// A fee rate below the relay floor would result in a transaction that
+	// doesn't propagate through the network. As the conversion from
+	// sat/vbyte rounds down, a fee rate of 1 sat/vbyte ends up just below
+	// the floor, so we'll bump it up instead of rejecting it.
+	feePerKw := chainfee.SatPerKWeight(req.SatPerKw)
+	if feePerKw < chainfee.FeePerKwFloor {
+		log.Infof("Manual fee rate input of %d sat/kw is too low, "+
+			"using %d sat/kw instead", feePerKw,
+			chainfee.FeePerKwFloor)
+
+		feePerKw = chainfee.FeePerKwFloor
 	}
 
 	// Before we can request this transaction to be created, we'll need to
@@ -623,10 +834,17 @@ func (w *WalletKit) SendOutputs(ctx context.Context,
 		return nil, err
 	}
 
+	strategy, err := lnrpc.UnmarshallWalletCoinSelectionStrategy(
+		req.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now that we have the outputs mapped, we can request that the wallet
 	// attempt to create this transaction.
 	tx, err := w.cfg.Wallet.SendOutputs(
-		outputsToCreate, chainfee.SatPerKWeight(req.SatPerKw), minConfs, label,
+		outputsToCreate, feePerKw, minConfs, label, strategy,
 	)
 	if err != nil {
 		return nil, err
@@ -642,6 +860,46 @@ func (w *WalletKit) SendOutputs(ctx context.Context,
 	}, nil
 }
 
+// ConsolidateUtxos creates, signs and publishes a transaction that consolidates
+// the small utxos of the default wallet account into a single new output of
+// the wallet.
+func (w *WalletKit) ConsolidateUtxos(ctx context.Context,
+	req *ConsolidateUtxosRequest) (*ConsolidateUtxosResponse, error) {
+
+	if req.MaxUtxoValueSat < 0 {
+		return nil, fmt.Errorf("max utxo value must not be negative")
+	}
+
+	feeRate, err := sweep.DetermineFeePerKw(
+		w.cfg.FeeEstimator, sweep.FeePreference{
+			ConfTarget: req.TargetConf,
+			FeeRate: chainfee.SatPerKVByte(
+				req.SatPerVbyte * 1000,
+			).FeePerKWeight(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := w.cfg.Consolidator.Consolidate(
+		feeRate, btcutil.Amount(req.MaxUtxoValueSat),
+		int(req.MinUtxos),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	return &ConsolidateUtxosResponse{
+		RawTx: b.Bytes(),
+	}, nil
+}
+
 // EstimateFee attempts to query the internal fee estimator of the wallet to
 // determine the fee (in sat/kw) to attach to a transaction in order to achieve
 // the confirmation target.
@@ -749,41 +1007,6 @@ func (w *WalletKit) PendingSweeps(ctx context.Context,
 	}, nil
 }
 
-// unmarshallOutPoint converts an outpoint from its lnrpc type to its canonical
-// type.
-func unmarshallOutPoint(op *lnrpc.OutPoint) (*wire.OutPoint, error) {
-	if op == nil {
-		return nil, fmt.Errorf("empty outpoint provided")
-	}
-
-	var hash chainhash.Hash
-	switch {
-	case len(op.TxidBytes) == 0 && len(op.TxidStr) == 0:
-		fallthrough
-
-	case len(op.TxidBytes) != 0 && len(op.TxidStr) != 0:
-		return nil, fmt.Errorf("either TxidBytes or TxidStr must be " +
-			"specified, but not both")
-
-	// The hash was provided as raw bytes.
-	case len(op.TxidBytes) != 0:
-		copy(hash[:], op.TxidBytes)
-
-	// The hash was provided as a hex-encoded string.
-	case len(op.TxidStr) != 0:
-		h, err := chainhash.NewHashFromStr(op.TxidStr)
-		if err != nil {
-			return nil, err
-		}
-		hash = *h
-	}
-
-	return &wire.OutPoint{
-		Hash:  hash,
-		Index: op.OutputIndex,
-	}, nil
-}
-
 // BumpFee allows bumping the fee rate of an arbitrary input. A fee preference
 // can be expressed either as a specific fee rate or a delta of blocks in which
 // the output should be swept on-chain within. If a fee preference is not
@@ -793,7 +1016,7 @@ func (w *WalletKit) BumpFee(ctx context.Context,
 	in *BumpFeeRequest) (*BumpFeeResponse, error) {
 
 	// Parse the outpoint from the request.
-	op, err := unmarshallOutPoint(in.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(in.Outpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -1050,7 +1273,7 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 
 		txIn := make([]*wire.OutPoint, len(tpl.Inputs))
 		for idx, in := range tpl.Inputs {
-			op, err := unmarshallOutPoint(in)
+			op, err := lnrpc.UnmarshallOutPoint(in)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing "+
 					"outpoint: %v", err)
@@ -1107,6 +1330,13 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 		return nil, err
 	}
 
+	strategy, err := lnrpc.UnmarshallWalletCoinSelectionStrategy(
+		req.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// The RPC parsing part is now over. Several of the following operations
 	// require us to hold the global coin selection lock so we do the rest
 	// of the tasks while holding the lock. The result is a list of locked
@@ -1144,7 +1374,7 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 		// lock any coins but might still change the wallet DB by
 		// generating a new change address.
 		changeIndex, err = w.cfg.Wallet.FundPsbt(
-			packet, minConfs, feeSatPerKW, account,
+			packet, minConfs, feeSatPerKW, account, strategy,
 		)
 		if err != nil {
 			return fmt.Errorf("wallet couldn't fund PSBT: %v", err)
@@ -1567,7 +1797,10 @@ func (w *WalletKit) ImportPublicKey(ctx context.Context,
 		return nil, err
 	}
 
-	if err := w.cfg.Wallet.ImportPublicKey(pubKey, *addrType); err != nil {
+	err = w.cfg.Wallet.ImportPublicKey(
+		pubKey, *addrType, req.RescanStartHeight,
+	)
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,254 @@
+//go:build walletrpc
+// +build walletrpc
+
+package walletrpc
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLeaseDuration tests that the lease duration is derived from the requested
+// expiration, and that expirations that would overflow are rejected.
+func TestLeaseDuration(t *testing.T) {
+	duration, err := leaseDuration(0)
+	require.NoError(t, err)
+	require.Equal(t, DefaultLockDuration, duration)
+
+	duration, err = leaseDuration(3600)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, duration)
+
+	_, err = leaseDuration(math.MaxUint64)
+	require.Error(t, err)
+}
+
+// TestSendOutputsValidation tests that SendOutputs rejects requests without
+// outputs or without a fee rate before funding anything.
+func TestSendOutputsValidation(t *testing.T) {
+	w := &WalletKit{cfg: &Config{}}
+	outputs := []*signrpc.TxOut{{
+		Value:    1000,
+		PkScript: make([]byte, 22),
+	}}
+
+	_, err := w.SendOutputs(context.Background(), &SendOutputsRequest{
+		SatPerKw: 2500,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "at least one output")
+
+	_, err = w.SendOutputs(context.Background(), &SendOutputsRequest{
+		Outputs: outputs,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "positive fee rate")
+
+	_, err = w.SendOutputs(context.Background(), &SendOutputsRequest{
+		SatPerKw: -1,
+		Outputs:  outputs,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "positive fee rate")
+}
+
+// strategyWallet is a wallet controller that records the fee rate and coin
+// selection strategy it was asked to send outputs with.
+type strategyWallet struct {
+	lnwallet.WalletController
+
+	feeRate  chainfee.SatPerKWeight
+	strategy *wallet.CoinSelectionStrategy
+}
+
+func (w *strategyWallet) SendOutputs(_ []*wire.TxOut,
+	feeRate chainfee.SatPerKWeight, _ int32, _ string,
+	strategy *wallet.CoinSelectionStrategy) (*wire.MsgTx, error) {
+
+	w.feeRate = feeRate
+	w.strategy = strategy
+
+	return wire.NewMsgTx(2), nil
+}
+
+// TestSendOutputsFeeRateFloor tests that fee rates below the relay floor, such
+// as 1 sat/vbyte converted to sat/kw, are raised to the floor, while higher fee
+// rates are passed to the wallet as they are.
+func TestSendOutputsFeeRateFloor(t *testing.T) {
+	controller := &strategyWallet{}
+	w := &WalletKit{cfg: &Config{Wallet: controller}}
+	req := &SendOutputsRequest{
+		SatPerKw: int64(chainfee.SatPerKVByte(1000).FeePerKWeight()),
+		Outputs: []*signrpc.TxOut{{
+			Value:    1000,
+			PkScript: make([]byte, 22),
+		}},
+		MinConfs: 1,
+	}
+	require.EqualValues(t, 250, req.SatPerKw)
+
+	_, err := w.SendOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, chainfee.FeePerKwFloor, controller.feeRate)
+
+	req.SatPerKw = 2500
+	_, err = w.SendOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.EqualValues(t, 2500, controller.feeRate)
+}
+
+// TestSendOutputsCoinSelectionStrategy tests that the coin selection strategy
+// of a SendOutputs request is passed to the wallet, and that the wallet's
+// configured strategy is used if none is set.
+func TestSendOutputsCoinSelectionStrategy(t *testing.T) {
+	controller := &strategyWallet{}
+	w := &WalletKit{cfg: &Config{Wallet: controller}}
+	req := &SendOutputsRequest{
+		SatPerKw: 2500,
+		Outputs: []*signrpc.TxOut{{
+			Value:    1000,
+			PkScript: make([]byte, 22),
+		}},
+		MinConfs: 1,
+	}
+
+	_, err := w.SendOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.Nil(t, controller.strategy)
+
+	req.CoinSelectionStrategy = lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM
+	_, err = w.SendOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, controller.strategy)
+	require.Equal(t, wallet.CoinSelectionRandom, *controller.strategy)
+
+	req.CoinSelectionStrategy = lnrpc.CoinSelectionStrategy(99)
+	_, err = w.SendOutputs(context.Background(), req)
+	require.Error(t, err)
+}
+
+// leasedWallet is a wallet controller that knows about a set of unspent and
+// leased outputs. Any other call, including releasing or leasing an output,
+// panics.
+type leasedWallet struct {
+	lnwallet.WalletController
+
+	unspent     []*lnwallet.Utxo
+	leased      map[wire.OutPoint]*lnwallet.Utxo
+	staleLeases []wire.OutPoint
+	unconfirmed []*wire.MsgTx
+}
+
+func (w *leasedWallet) ListUnspentWitness(_, _ int32,
+	_ string) ([]*lnwallet.Utxo, error) {
+
+	return w.unspent, nil
+}
+
+func (w *leasedWallet) ListLeasedOutputs() ([]*wtxmgr.LockedOutput, error) {
+	leases := make([]*wtxmgr.LockedOutput, 0, len(w.leased))
+	for op := range w.leased {
+		leases = append(leases, &wtxmgr.LockedOutput{Outpoint: op})
+	}
+	for _, op := range w.staleLeases {
+		leases = append(leases, &wtxmgr.LockedOutput{Outpoint: op})
+	}
+
+	return leases, nil
+}
+
+func (w *leasedWallet) ListTransactionDetails(_, _ int32,
+	_ string) ([]*lnwallet.TransactionDetail, error) {
+
+	txDetails := make([]*lnwallet.TransactionDetail, 0, len(w.unconfirmed))
+	for _, tx := range w.unconfirmed {
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+
+		txDetails = append(txDetails, &lnwallet.TransactionDetail{
+			Hash:  tx.TxHash(),
+			RawTx: buf.Bytes(),
+		})
+	}
+
+	return txDetails, nil
+}
+
+func (w *leasedWallet) FetchInputInfo(
+	op *wire.OutPoint) (*lnwallet.Utxo, error) {
+
+	leased, ok := w.leased[*op]
+	if !ok {
+		return nil, lnwallet.ErrNotMine
+	}
+
+	utxo := *leased
+	return &utxo, nil
+}
+
+// TestListUnspentIncludingLeased tests that leased outputs are added to the
+// unspent outputs of the wallet without releasing their leases, unless they're
+// already spent, don't match the confirmation filter or can't be looked up.
+func TestListUnspentIncludingLeased(t *testing.T) {
+	utxo := func(op wire.OutPoint, confs int64) *lnwallet.Utxo {
+		return &lnwallet.Utxo{
+			AddressType:   lnwallet.WitnessPubKey,
+			Value:         1000,
+			Confirmations: confs,
+			OutPoint:      op,
+		}
+	}
+
+	// The unconfirmed transaction spends one leased output, and creates
+	// another one.
+	spentOutpoint := wire.OutPoint{Hash: chainhash.Hash{2}}
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{PreviousOutPoint: spentOutpoint})
+	spendTx.AddTxOut(&wire.TxOut{Value: 1000})
+
+	unspent := utxo(wire.OutPoint{Hash: chainhash.Hash{1}}, 1)
+	confirmed := wire.OutPoint{Hash: chainhash.Hash{3}}
+	unconfirmed := wire.OutPoint{Hash: spendTx.TxHash()}
+
+	w := &WalletKit{cfg: &Config{Wallet: &leasedWallet{
+		unspent: []*lnwallet.Utxo{unspent},
+		leased: map[wire.OutPoint]*lnwallet.Utxo{
+			spentOutpoint: utxo(spentOutpoint, 2),
+			confirmed:     utxo(confirmed, 5),
+			unconfirmed:   utxo(unconfirmed, 0),
+		},
+		staleLeases: []wire.OutPoint{{Hash: chainhash.Hash{4}}},
+		unconfirmed: []*wire.MsgTx{spendTx},
+	}}}
+
+	// The confirmations of the confirmed leased output are counted from
+	// one for the block including it, like for all other outputs. The
+	// stale lease the wallet can't look up is skipped.
+	utxos, err := w.listUnspentIncludingLeased(0, math.MaxInt32, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*lnwallet.Utxo{
+		unspent, utxo(confirmed, 6), utxo(unconfirmed, 0),
+	}, utxos)
+
+	// Unconfirmed leased outputs are filtered out like any other.
+	utxos, err = w.listUnspentIncludingLeased(1, math.MaxInt32, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*lnwallet.Utxo{
+		unspent, utxo(confirmed, 6),
+	}, utxos)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -30,6 +31,18 @@ type WalletController struct {
 	PublishedTransactions chan *wire.MsgTx
 	index                 uint32
 	Utxos                 []*lnwallet.Utxo
+
+	// AccountUtxos optionally holds the utxos returned when listing the
+	// unspent outputs of the account with the given name.
+	AccountUtxos map[string][]*lnwallet.Utxo
+
+	// TransactionDetails optionally holds the transactions returned by
+	// ListTransactionDetails.
+	TransactionDetails []*lnwallet.TransactionDetail
+
+	// TransactionDetailsErr optionally holds the error returned by
+	// ListTransactionDetails.
+	TransactionDetailsErr error
 }
 
 // BackEnd returns "mock" to signify a mock wallet controller.
@@ -114,29 +127,36 @@ func (w *WalletController) ImportAccount(string, *hdkeychain.ExtendedKey,
 
 // ImportPublicKey currently returns a dummy value.
 func (w *WalletController) ImportPublicKey(*btcec.PublicKey,
-	waddrmgr.AddressType) error {
+	waddrmgr.AddressType, uint32) error {
 
 	return nil
 }
 
 // SendOutputs currently returns dummy values.
 func (w *WalletController) SendOutputs([]*wire.TxOut,
-	chainfee.SatPerKWeight, int32, string) (*wire.MsgTx, error) {
+	chainfee.SatPerKWeight, int32, string,
+	*wallet.CoinSelectionStrategy) (*wire.MsgTx, error) {
 
 	return nil, nil
 }
 
 // CreateSimpleTx currently returns dummy values.
 func (w *WalletController) CreateSimpleTx([]*wire.TxOut,
-	chainfee.SatPerKWeight, int32, bool) (*txauthor.AuthoredTx, error) {
+	chainfee.SatPerKWeight, int32, bool,
+	*wallet.CoinSelectionStrategy) (*txauthor.AuthoredTx, error) {
 
 	return nil, nil
 }
 
 // ListUnspentWitness is called by the wallet when doing coin selection. We just
 // need one unspent for the funding transaction.
-func (w *WalletController) ListUnspentWitness(int32, int32,
-	string) ([]*lnwallet.Utxo, error) {
+func (w *WalletController) ListUnspentWitness(_, _ int32,
+	accountFilter string) ([]*lnwallet.Utxo, error) {
+
+	// If the mock has a list of utxos for the account, return it.
+	if utxos, ok := w.AccountUtxos[accountFilter]; ok {
+		return utxos, nil
+	}
 
 	// If the mock already has a list of utxos, return it.
 	if w.Utxos != nil {
@@ -159,11 +179,11 @@ func (w *WalletController) ListUnspentWitness(int32, int32,
 	return ret, nil
 }
 
-// ListTransactionDetails currently returns dummy values.
+// ListTransactionDetails returns the configured transactions and error.
 func (w *WalletController) ListTransactionDetails(int32, int32,
 	string) ([]*lnwallet.TransactionDetail, error) {
 
-	return nil, nil
+	return w.TransactionDetails, w.TransactionDetailsErr
 }
 
 // LockOutpoint currently does nothing.
@@ -190,7 +210,7 @@ func (w *WalletController) ListLeasedOutputs() ([]*wtxmgr.LockedOutput, error) {
 
 // FundPsbt currently does nothing.
 func (w *WalletController) FundPsbt(*psbt.Packet, int32, chainfee.SatPerKWeight,
-	string) (int32, error) {
+	string, *wallet.CoinSelectionStrategy) (int32, error) {
 
 	return 0, nil
 }
@@ -725,12 +725,28 @@ func (b *BtcWallet) ListAccounts(name string,
 // pubkeys everywhere) and our own BIP-0049Plus address schema (nested pubkeys
 // externally, witness pubkeys internally).
 //
+// The account name must be unique across all key scopes.
+//
 // This is a part of the WalletController interface.
 func (b *BtcWallet) ImportAccount(name string, accountPubKey *hdkeychain.ExtendedKey,
 	masterKeyFingerprint uint32, addrType *waddrmgr.AddressType,
 	dryRun bool) (*waddrmgr.AccountProperties, []btcutil.Address,
 	[]btcutil.Address, error) {
 
+	// The wallet only requires account names to be unique within a key
+	// scope, but we identify accounts by their name alone, e.g. when
+	// filtering balances and UTXOs. We therefore make sure the name isn't
+	// already taken by an account of any other key scope.
+	_, _, err := b.wallet.LookupAccount(name)
+	switch {
+	case err == nil:
+		return nil, nil, nil, fmt.Errorf("account with name %q "+
+			"already exists", name)
+
+	case !waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound):
+		return nil, nil, nil, err
+	}
+
 	if !dryRun {
 		accountProps, err := b.wallet.ImportAccount(
 			name, accountPubKey, masterKeyFingerprint, addrType,
@@ -769,13 +785,101 @@ func (b *BtcWallet) ImportAccount(name string, accountPubKey *hdkeychain.Extende
 // address type can usually be inferred from the key's version, but in the case
 // of legacy versions (xpub, tpub), an address type must be specified as we
 // intend to not support importing BIP-44 keys into the wallet using the legacy
-// pay-to-pubkey-hash (P2PKH) scheme.
+// pay-to-pubkey-hash (P2PKH) scheme. If the rescan start height is non-zero,
+// the chain is rescanned in the background from that height to detect past
+// events of the key.
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) ImportPublicKey(pubKey *btcec.PublicKey,
-	addrType waddrmgr.AddressType) error {
+	addrType waddrmgr.AddressType, rescanStartHeight uint32) error {
+
+	// Make sure we know the address the wallet is going to watch, and
+	// that we can actually rescan from the given height before importing
+	// the key.
+	addr, err := importedKeyAddress(pubKey, addrType, b.netParams)
+	if err != nil {
+		return err
+	}
+
+	var rescanFrom *waddrmgr.BlockStamp
+	if rescanStartHeight != 0 {
+		_, bestHeight, err := b.chain.GetBestBlock()
+		if err != nil {
+			return err
+		}
+		if int64(rescanStartHeight) > int64(bestHeight) {
+			return fmt.Errorf("rescan start height %d is beyond "+
+				"the best height %d", rescanStartHeight,
+				bestHeight)
+		}
+
+		blockHash, err := b.chain.GetBlockHash(int64(rescanStartHeight))
+		if err != nil {
+			return err
+		}
+		rescanFrom = &waddrmgr.BlockStamp{
+			Height: int32(rescanStartHeight),
+			Hash:   *blockHash,
+		}
+	}
+
+	if err := b.wallet.ImportPublicKey(pubKey, addrType); err != nil {
+		return err
+	}
+
+	if rescanFrom == nil {
+		return nil
+	}
 
-	return b.wallet.ImportPublicKey(pubKey, addrType)
+	// The rescan can take a while, so we don't wait for it to complete.
+	// Any outputs found are added to the wallet as they're detected, and
+	// the caller can't do anything about a failed rescan anymore, so we
+	// only log it.
+	errChan := b.wallet.SubmitRescan(&base.RescanJob{
+		Addrs:      []btcutil.Address{addr},
+		BlockStamp: *rescanFrom,
+	})
+	go func() {
+		if err := <-errChan; err != nil {
+			log.Errorf("Unable to rescan for imported public key "+
+				"%x from height %d: %v",
+				pubKey.SerializeCompressed(),
+				rescanFrom.Height, err)
+		}
+	}()
+
+	return nil
+}
+
+// importedKeyAddress returns the address of the given type that the wallet
+// watches for an imported public key.
+func importedKeyAddress(pubKey *btcec.PublicKey, addrType waddrmgr.AddressType,
+	netParams *chaincfg.Params) (btcutil.Address, error) {
+
+	keyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+	witnessAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		keyHash, netParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch addrType {
+	case waddrmgr.WitnessPubKey:
+		return witnessAddr, nil
+
+	case waddrmgr.NestedWitnessPubKey:
+		witnessProgram, err := txscript.PayToAddrScript(witnessAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		return btcutil.NewAddressScriptHash(witnessProgram, netParams)
+
+	default:
+		return nil, fmt.Errorf("address type %v is not supported",
+			addrType)
+	}
 }
 
 // ImportTaprootScript imports a user-provided taproot script into the address
@@ -806,8 +910,8 @@ func (b *BtcWallet) ImportTaprootScript(scope waddrmgr.KeyScope,
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
-	feeRate chainfee.SatPerKWeight, minConfs int32,
-	label string) (*wire.MsgTx, error) {
+	feeRate chainfee.SatPerKWeight, minConfs int32, label string,
+	strategy *base.CoinSelectionStrategy) (*wire.MsgTx, error) {
 
 	// Convert our fee rate from sat/kw to sat/kb since it's required by
 	// SendOutputs.
@@ -825,7 +929,7 @@ func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
 
 	return b.wallet.SendOutputs(
 		outputs, nil, defaultAccount, minConfs, feeSatPerKB,
-		b.cfg.CoinSelectionStrategy, label,
+		b.coinSelectionStrategy(strategy), label,
 	)
 }
 
@@ -843,8 +947,8 @@ func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) CreateSimpleTx(outputs []*wire.TxOut,
-	feeRate chainfee.SatPerKWeight, minConfs int32,
-	dryRun bool) (*txauthor.AuthoredTx, error) {
+	feeRate chainfee.SatPerKWeight, minConfs int32, dryRun bool,
+	strategy *base.CoinSelectionStrategy) (*txauthor.AuthoredTx, error) {
 
 	// The fee rate is passed in using units of sat/kw, so we'll convert
 	// this to sat/KB as the CreateSimpleTx method requires this unit.
@@ -876,10 +980,22 @@ func (b *BtcWallet) CreateSimpleTx(outputs []*wire.TxOut,
 
 	return b.wallet.CreateSimpleTx(
 		nil, defaultAccount, outputs, minConfs, feeSatPerKB,
-		b.cfg.CoinSelectionStrategy, dryRun,
+		b.coinSelectionStrategy(strategy), dryRun,
 	)
 }
 
+// coinSelectionStrategy returns the given coin selection strategy, or the
+// wallet's configured strategy if none is given.
+func (b *BtcWallet) coinSelectionStrategy(
+	strategy *base.CoinSelectionStrategy) base.CoinSelectionStrategy {
+
+	if strategy == nil {
+		return b.cfg.CoinSelectionStrategy
+	}
+
+	return *strategy
+}
+
 // LockOutpoint marks an outpoint as locked meaning it will no longer be deemed
 // as eligible for coin selection. Locking outputs are utilized in order to
 // avoid race conditions when selecting inputs for usage when funding a
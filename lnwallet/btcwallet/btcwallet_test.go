@@ -0,0 +1,186 @@
+package btcwallet
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImportAccountDuplicateName tests that an account name can't be imported
+// twice, even if the second account uses a different key scope.
+func TestImportAccountDuplicateName(t *testing.T) {
+	netParams := &chaincfg.RegressionNetParams
+	w, _, cleanup := newTestWallet(t, netParams, seedBytes)
+	defer cleanup()
+
+	// We'll import an account key of a different wallet, derived at
+	// m/84'/1'/0'.
+	rootKey, err := hdkeychain.NewMaster(
+		[]byte("another wallet's seed"), netParams,
+	)
+	require.NoError(t, err)
+
+	accountKey := rootKey
+	for _, index := range []uint32{84, 1, 0} {
+		accountKey, err = accountKey.Derive(
+			hdkeychain.HardenedKeyStart + index,
+		)
+		require.NoError(t, err)
+	}
+	accountPubKey, err := accountKey.Neuter()
+	require.NoError(t, err)
+
+	// The names of the wallet's own accounts are already taken.
+	witnessAddrType := waddrmgr.WitnessPubKey
+	for _, name := range []string{
+		lnwallet.DefaultAccountName, waddrmgr.ImportedAddrAccountName,
+	} {
+		_, _, _, err = w.ImportAccount(
+			name, accountPubKey, 0, &witnessAddrType, false,
+		)
+		require.Error(t, err)
+	}
+
+	_, _, _, err = w.ImportAccount(
+		"custom", accountPubKey, 0, &witnessAddrType, false,
+	)
+	require.NoError(t, err)
+
+	// Importing the same name again is rejected, both for the same and
+	// for a different key scope, and for dry runs too.
+	nestedAddrType := waddrmgr.NestedWitnessPubKey
+	for _, addrType := range []*waddrmgr.AddressType{
+		&witnessAddrType, &nestedAddrType,
+	} {
+		for _, dryRun := range []bool{true, false} {
+			_, _, _, err = w.ImportAccount(
+				"custom", accountPubKey, 0, addrType, dryRun,
+			)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "already exists")
+		}
+	}
+
+	// The account is only known in the key scope it was imported to.
+	accounts, err := w.ListAccounts("custom", nil)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, waddrmgr.KeyScopeBIP0084, accounts[0].KeyScope)
+}
+
+// TestImportedKeyAddress tests that the address watched for an imported public
+// key is derived according to its address type.
+func TestImportedKeyAddress(t *testing.T) {
+	t.Parallel()
+
+	netParams := &chaincfg.RegressionNetParams
+	keyHash := btcutil.Hash160(testPubKey.SerializeCompressed())
+
+	addr, err := importedKeyAddress(
+		testPubKey, waddrmgr.WitnessPubKey, netParams,
+	)
+	require.NoError(t, err)
+	witnessAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		keyHash, netParams,
+	)
+	require.NoError(t, err)
+	require.Equal(t, witnessAddr, addr)
+
+	addr, err = importedKeyAddress(
+		testPubKey, waddrmgr.NestedWitnessPubKey, netParams,
+	)
+	require.NoError(t, err)
+	witnessProgram, err := txscript.PayToAddrScript(witnessAddr)
+	require.NoError(t, err)
+	nestedAddr, err := btcutil.NewAddressScriptHash(
+		witnessProgram, netParams,
+	)
+	require.NoError(t, err)
+	require.Equal(t, nestedAddr, addr)
+
+	_, err = importedKeyAddress(testPubKey, waddrmgr.PubKeyHash, netParams)
+	require.Error(t, err)
+}
+
+// TestImportPublicKeyRescan tests that importing a public key with a rescan
+// start height finds the outputs sent to it before the import, and that
+// invalid requests are rejected before the key is imported.
+func TestImportPublicKeyRescan(t *testing.T) {
+	netParams := &chaincfg.RegressionNetParams
+	w, miner, cleanup := newTestWallet(t, netParams, seedBytes)
+	defer cleanup()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+
+	addr, err := importedKeyAddress(
+		pubKey, waddrmgr.WitnessPubKey, netParams,
+	)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// We'll pay to the key and confirm the payment before the wallet
+	// knows about the key.
+	_, err = miner.SendOutputs([]*wire.TxOut{{
+		Value:    btcutil.SatoshiPerBitcoin,
+		PkScript: pkScript,
+	}}, 1)
+	require.NoError(t, err)
+	_, err = miner.Client.Generate(1)
+	require.NoError(t, err)
+	_, bestHeight, err := miner.Client.GetBestBlock()
+	require.NoError(t, err)
+
+	// Neither an unsupported address type nor a rescan start height
+	// beyond the best block imports the key.
+	err = w.ImportPublicKey(pubKey, waddrmgr.PubKeyHash, 0)
+	require.Error(t, err)
+	err = w.ImportPublicKey(
+		pubKey, waddrmgr.WitnessPubKey, math.MaxInt32,
+	)
+	require.Error(t, err)
+	require.False(t, w.IsOurAddress(addr))
+
+	// Wait for the wallet to catch up with the miner, so the rescan
+	// start height is known to it.
+	require.Eventually(t, func() bool {
+		synced, _, err := w.IsSynced()
+		require.NoError(t, err)
+
+		return synced &&
+			w.InternalWallet().Manager.SyncedTo().Height >=
+				bestHeight
+	}, time.Minute, 50*time.Millisecond)
+
+	err = w.ImportPublicKey(
+		pubKey, waddrmgr.WitnessPubKey, uint32(bestHeight),
+	)
+	require.NoError(t, err)
+	require.True(t, w.IsOurAddress(addr))
+
+	// The rescan runs in the background and eventually finds the
+	// confirmed output.
+	require.Eventually(t, func() bool {
+		utxos, err := w.ListUnspentWitness(
+			1, math.MaxInt32, waddrmgr.ImportedAddrAccountName,
+		)
+		require.NoError(t, err)
+
+		return len(utxos) == 1 && bytes.Equal(
+			utxos[0].PkScript, pkScript,
+		)
+	}, time.Minute, 50*time.Millisecond)
+}
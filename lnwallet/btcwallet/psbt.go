@@ -46,7 +46,8 @@ var (
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
-	feeRate chainfee.SatPerKWeight, accountName string) (int32, error) {
+	feeRate chainfee.SatPerKWeight, accountName string,
+	strategy *wallet.CoinSelectionStrategy) (int32, error) {
 
 	// The fee rate is passed in using units of sat/kw, so we'll convert
 	// this to sat/KB as the CreateSimpleTx method requires this unit.
@@ -81,7 +82,7 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
 	// the partial TX information in the packet.
 	return b.wallet.FundPsbt(
 		packet, keyScope, minConfs, accountNum, feeSatPerKB,
-		b.cfg.CoinSelectionStrategy,
+		b.coinSelectionStrategy(strategy),
 	)
 }
 
@@ -35,6 +35,11 @@ const (
 	// maxFeeUpdateTimeout represents the maximum interval in which a
 	// WebAPIEstimator will request fresh fees from its API.
 	maxFeeUpdateTimeout = 20 * time.Minute
+
+	// maxWebAPIFeeRate is the highest fee rate in sat/kvB a WebAPIEstimator
+	// accepts from its API. Anything above is considered bogus and won't be
+	// cached.
+	maxWebAPIFeeRate uint32 = 10_000 * 1000
 )
 
 var (
@@ -91,10 +96,16 @@ func NewStaticEstimator(feePerKW, relayFee SatPerKWeight) *StaticEstimator {
 	}
 }
 
-// EstimateFeePerKW will return a static value for fee calculations.
+// EstimateFeePerKW will return a static value for fee calculations. The static
+// fee rate is clamped to the relay fee, as transactions paying less wouldn't
+// propagate.
 //
 // NOTE: This method is part of the Estimator interface.
 func (e StaticEstimator) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight, error) {
+	if e.feePerKW < e.relayFee {
+		return e.relayFee, nil
+	}
+
 	return e.feePerKW, nil
 }
 
@@ -231,6 +242,14 @@ func (b *BtcdEstimator) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight, error
 		fallthrough
 
 	case feeEstimate == 0:
+		// The fall back fee rate is still subject to the current
+		// minimum relay fee of the backend, as transactions paying
+		// less wouldn't propagate.
+		minRelayFee := b.minFeeManager.fetchMinFee()
+		if b.fallbackFeePerKW < minRelayFee {
+			return minRelayFee, nil
+		}
+
 		return b.fallbackFeePerKW, nil
 	}
 
@@ -411,6 +430,14 @@ func (b *BitcoindEstimator) EstimateFeePerKW(
 		fallthrough
 
 	case feeEstimate == 0:
+		// The fall back fee rate is still subject to the current
+		// minimum relay fee of the backend, as transactions paying
+		// less wouldn't propagate.
+		minRelayFee := b.minFeeManager.fetchMinFee()
+		if b.fallbackFeePerKW < minRelayFee {
+			return minRelayFee, nil
+		}
+
 		return b.fallbackFeePerKW, nil
 	}
 
@@ -797,11 +824,43 @@ func (w *WebAPIEstimator) updateFeeEstimates() {
 		return
 	}
 
+	// Make sure we only cache sane fee rates, so a misbehaving API can't
+	// cause us to pay absurd fees or wipe the estimates we already have.
+	feesByBlockTarget = sanitizeFeeEstimates(feesByBlockTarget)
+	if len(feesByBlockTarget) == 0 {
+		log.Errorf("web api returned no valid fee estimates, keeping " +
+			"previous estimates")
+		return
+	}
+
 	w.feesMtx.Lock()
 	w.feeByBlockTarget = feesByBlockTarget
 	w.feesMtx.Unlock()
 }
 
+// sanitizeFeeEstimates returns the fee estimates of a web API response that
+// are within bounds. Estimates for conf targets we don't support, as well as
+// zero or absurdly high fee rates, are dropped.
+func sanitizeFeeEstimates(fees map[uint32]uint32) map[uint32]uint32 {
+	sane := make(map[uint32]uint32, len(fees))
+	for target, feeRate := range fees {
+		switch {
+		case target < minBlockTarget || target > maxBlockTarget:
+			log.Warnf("Ignoring web API fee rate for unsupported "+
+				"conf target %d", target)
+
+		case feeRate == 0 || feeRate > maxWebAPIFeeRate:
+			log.Warnf("Ignoring web API fee rate of %d sat/kvB for "+
+				"conf target %d", feeRate, target)
+
+		default:
+			sane[target] = feeRate
+		}
+	}
+
+	return sane
+}
+
 // feeUpdateManager updates the fee estimates whenever a new block comes in.
 func (w *WebAPIEstimator) feeUpdateManager() {
 	defer w.wg.Done()
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -106,6 +108,20 @@ func TestStaticFeeEstimator(t *testing.T) {
 	if feeRate != feePerKw {
 		t.Fatalf("expected fee rate %v, got %v", feePerKw, feeRate)
 	}
+
+	// A static fee rate below the relay fee should be clamped to the
+	// relay fee.
+	const relayFee = feePerKw * 2
+	feeEstimator = NewStaticEstimator(feePerKw, relayFee)
+
+	feeRate, err = feeEstimator.EstimateFeePerKW(6)
+	if err != nil {
+		t.Fatalf("unable to get fee rate: %v", err)
+	}
+
+	if feeRate != relayFee {
+		t.Fatalf("expected fee rate %v, got %v", relayFee, feeRate)
+	}
 }
 
 // TestSparseConfFeeSource checks that SparseConfFeeSource generates URLs and
@@ -209,6 +225,13 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 			est:    testFeeRate,
 			err:    "",
 		},
+		{
+			name:   "bogus_fee_rate_ignored",
+			target: 3,
+			apiEst: maxWebAPIFeeRate + 1,
+			est:    testFeeRate,
+			err:    "",
+		},
 	}
 
 	// Construct mock fee source for the Estimator to pull fees from.
@@ -219,8 +242,15 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 		}
 	}
 
+	// The fee source doesn't look at the response, so we only need a
+	// local server to answer the query.
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {},
+	))
+	defer server.Close()
+
 	feeSource := mockSparseConfFeeSource{
-		url:  "https://www.github.com",
+		url:  server.URL,
 		fees: testFees,
 	}
 
@@ -262,6 +292,17 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 			}
 		})
 	}
+
+	// A response without any valid fee rates shouldn't wipe the estimates
+	// we already have.
+	for target := range testFees {
+		testFees[target] = maxWebAPIFeeRate + 1
+	}
+	estimator.updateFeeEstimates()
+
+	est, err := estimator.EstimateFeePerKW(20)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(testFeeRate).FeePerKWeight(), est)
 }
 
 // TestGetCachedFee checks that the fee caching logic works as expected.
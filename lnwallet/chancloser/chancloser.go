@@ -35,6 +35,19 @@ var (
 	// shutdown script previously set for that party.
 	ErrUpfrontShutdownScriptMismatch = fmt.Errorf("shutdown script does not " +
 		"match upfront shutdown script")
+
+	// ErrProposalExceedsMaxFee is returned when, as the initiator, the fee
+	// we'd need to propose to converge with the responder exceeds our max
+	// fee.
+	ErrProposalExceedsMaxFee = fmt.Errorf("latest fee proposal exceeds " +
+		"max fee")
+)
+
+const (
+	// DefaultMaxFeeMultiplier is the multiplier applied to our ideal fee
+	// to obtain the maximum fee we're willing to pay as the initiator of
+	// a cooperative close, if no explicit max fee is set.
+	DefaultMaxFeeMultiplier = 3
 )
 
 // closeState represents all the possible states the channel closer state
@@ -89,6 +102,11 @@ type ChanCloseCfg struct {
 	// Disconnect will disconnect from the remote peer in this close.
 	Disconnect func() error
 
+	// MaxFee, if non-zero, is the highest fee rate that we're willing to
+	// pay for the closing transaction as the initiator of the channel. If
+	// zero, a multiple of our ideal fee is used instead.
+	MaxFee chainfee.SatPerKWeight
+
 	// Quit is a channel that should be sent upon in the occasion the state
 	// machine should cease all progress and shutdown.
 	Quit chan struct{}
@@ -122,6 +140,11 @@ type ChanCloser struct {
 	// offer when starting negotiation. This will be used as a baseline.
 	idealFeeSat btcutil.Amount
 
+	// maxFee is the highest total fee that we'll accept for the closing
+	// transaction if we're the initiator of the channel, and as such pay
+	// the fee.
+	maxFee btcutil.Amount
+
 	// lastFeeProposal is the last fee that we proposed to the remote party.
 	// We'll use this as a pivot point to ratchet our next offer up, down, or
 	// simply accept the remote party's prior offer.
@@ -181,8 +204,16 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 		idealFeeSat = channelCommitFee
 	}
 
-	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v sat",
-		cfg.Channel.ChannelPoint(), int64(idealFeeSat))
+	// Unless an explicit max fee rate was specified, we'll cap the fee
+	// we're willing to pay at a multiple of our ideal fee.
+	maxFee := idealFeeSat * DefaultMaxFeeMultiplier
+	if cfg.MaxFee > 0 {
+		maxFee = cfg.Channel.CalcFee(cfg.MaxFee)
+	}
+
+	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v "+
+		"sat, max fee is: %v sat", cfg.Channel.ChannelPoint(),
+		int64(idealFeeSat), int64(maxFee))
 
 	cid := lnwire.NewChanIDFromOutPoint(cfg.Channel.ChannelPoint())
 	return &ChanCloser{
@@ -193,6 +224,7 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 		cfg:                 cfg,
 		negotiationHeight:   negotiationHeight,
 		idealFeeSat:         idealFeeSat,
+		maxFee:              maxFee,
 		localDeliveryScript: deliveryScript,
 		priorFeeOffers:      make(map[btcutil.Amount]*lnwire.ClosingSigned),
 		locallyInitiated:    locallyInitiated,
@@ -242,6 +274,15 @@ func (c *ChanCloser) ShutdownChan() (*lnwire.Shutdown, error) {
 		return nil, ErrChanAlreadyClosing
 	}
 
+	// As the initiator of the channel, we'll open the fee negotiation at
+	// our ideal fee. If that's already above our max fee, there's no point
+	// in shutting down the channel.
+	if c.cfg.Channel.IsInitiator() && c.idealFeeSat > c.maxFee {
+		return nil, fmt.Errorf("%w: ideal fee of %v is above max fee "+
+			"of %v", ErrProposalExceedsMaxFee, c.idealFeeSat,
+			c.maxFee)
+	}
+
 	chancloserLog.Infof("ChannelPoint(%v): initiating shutdown", c.chanPoint)
 
 	shutdownMsg, err := c.initChanShutdown()
@@ -486,7 +527,9 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 
 			// With our new fee proposal calculated, we'll craft a new close
 			// signed signature to send to the other party so we can continue
-			// the fee negotiation process.
+			// the fee negotiation process. As the initiator, we'll
+			// refuse to go above our max fee, so the remote party
+			// will need to come down to a fee we accept.
 			closeSigned, err := c.proposeCloseSigned(feeProposal)
 			if err != nil {
 				return nil, false, err
@@ -584,6 +627,13 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 // transaction for a channel based on the prior fee negotiations and our current
 // compromise fee.
 func (c *ChanCloser) proposeCloseSigned(fee btcutil.Amount) (*lnwire.ClosingSigned, error) {
+	// As the initiator, we're the one paying the fee, so we'll never
+	// propose more than our max fee.
+	if c.cfg.Channel.IsInitiator() && fee > c.maxFee {
+		return nil, fmt.Errorf("%w: %v > %v", ErrProposalExceedsMaxFee,
+			fee, c.maxFee)
+	}
+
 	rawSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
 		fee, c.localDeliveryScript, c.remoteDeliveryScript,
 	)
@@ -709,7 +759,9 @@ func calcCompromiseFee(chanPoint wire.OutPoint, ourIdealFee, lastSentFee,
 
 // ParseUpfrontShutdownAddress attempts to parse an upfront shutdown address.
 // If the address is empty, it returns nil. If it successfully decoded the
-// address, it returns a script that pays out to the address.
+// address, it returns a script that pays out to the address. An error is
+// returned if the address belongs to another network or if it is not of one
+// of the types that BOLT 2 allows as an upfront shutdown script.
 func ParseUpfrontShutdownAddress(address string,
 	params *chaincfg.Params) (lnwire.DeliveryAddress, error) {
 
@@ -724,5 +776,25 @@ func ParseUpfrontShutdownAddress(address string,
 		return nil, fmt.Errorf("invalid address: %v", err)
 	}
 
-	return txscript.PayToAddrScript(addr)
+	// DecodeAddress accepts segwit addresses of any known network, so
+	// make sure we don't commit to an address we can't be paid out to.
+	if !addr.IsForNet(params) {
+		return nil, fmt.Errorf("address %v is not valid for network "+
+			"%v", address, params.Name)
+	}
+
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The remote peer would fail the funding flow if we sent them a script
+	// that isn't allowed, so reject it right away.
+	if !lnwallet.ValidateUpfrontShutdown(script) {
+		return nil, fmt.Errorf("address %v is not a valid upfront "+
+			"shutdown address, only p2pkh, p2sh, p2wpkh and p2wsh "+
+			"are supported", address)
+	}
+
+	return script, nil
 }
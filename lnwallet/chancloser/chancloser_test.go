@@ -4,7 +4,14 @@ import (
 	"crypto/rand"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
 )
 
 // randDeliveryAddress generates a random delivery address for testing.
@@ -74,3 +81,201 @@ func TestMaybeMatchScript(t *testing.T) {
 		})
 	}
 }
+
+// TestMaxFeeClamp tests that as the initiator of the channel, we refuse to
+// propose a closing fee above our max fee, regardless of what the responder
+// asks for.
+func TestMaxFeeClamp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default max fee", func(t *testing.T) {
+		t.Parallel()
+		testMaxFeeClamp(t, 0)
+	})
+	t.Run("explicit max fee", func(t *testing.T) {
+		t.Parallel()
+		testMaxFeeClamp(t, 2*chainfee.FeePerKwFloor)
+	})
+}
+
+func testMaxFeeClamp(t *testing.T, maxFeeRate chainfee.SatPerKWeight) {
+	const idealFeeRate = chainfee.FeePerKwFloor
+
+	aliceChannel, _, cleanUp, err := lnwallet.CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	require.True(t, aliceChannel.IsInitiator())
+
+	cfg := ChanCloseCfg{
+		Channel: aliceChannel,
+		DisableChannel: func(wire.OutPoint) error {
+			return nil
+		},
+		MaxFee: maxFeeRate,
+	}
+	chanCloser := NewChanCloser(
+		cfg, randDeliveryAddress(t), idealFeeRate, 0, nil, true,
+	)
+
+	idealFee := aliceChannel.CalcFee(idealFeeRate)
+	maxFee := idealFee * DefaultMaxFeeMultiplier
+	if maxFeeRate != 0 {
+		maxFee = aliceChannel.CalcFee(maxFeeRate)
+	}
+
+	_, err = chanCloser.ShutdownChan()
+	require.NoError(t, err)
+
+	// Once the remote party responds with their shutdown, we'll send our
+	// initial proposal at our ideal fee.
+	msgs, _, err := chanCloser.ProcessCloseMsg(
+		lnwire.NewShutdown(chanCloser.cid, randDeliveryAddress(t)),
+	)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	closeSigned, ok := msgs[0].(*lnwire.ClosingSigned)
+	require.True(t, ok)
+	require.Equal(t, idealFee, closeSigned.FeeSatoshis)
+
+	// The remote party now keeps asking for a fee well above our max fee.
+	// We'll ratchet our proposal up until we'd exceed our max fee, at
+	// which point we bail out.
+	remoteProposal := &lnwire.ClosingSigned{
+		ChannelID:   chanCloser.cid,
+		FeeSatoshis: maxFee * 10,
+	}
+	for {
+		msgs, _, err = chanCloser.ProcessCloseMsg(remoteProposal)
+		if err != nil {
+			break
+		}
+
+		require.Len(t, msgs, 1)
+		closeSigned, ok = msgs[0].(*lnwire.ClosingSigned)
+		require.True(t, ok)
+		require.LessOrEqual(t, closeSigned.FeeSatoshis, maxFee)
+	}
+	require.ErrorIs(t, err, ErrProposalExceedsMaxFee)
+}
+
+// TestParseUpfrontShutdownAddress tests that only addresses of the active
+// network and of the types allowed by BOLT 2 are accepted as upfront shutdown
+// addresses.
+func TestParseUpfrontShutdownAddress(t *testing.T) {
+	t.Parallel()
+
+	var (
+		hash20 [20]byte
+		hash32 [32]byte
+		params = &chaincfg.TestNet3Params
+	)
+
+	p2wkh, err := btcutil.NewAddressWitnessPubKeyHash(hash20[:], params)
+	require.NoError(t, err)
+
+	mainnetP2wkh, err := btcutil.NewAddressWitnessPubKeyHash(
+		hash20[:], &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	p2tr, err := btcutil.NewAddressTaproot(hash32[:], params)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		address   string
+		expectErr bool
+	}{
+		{
+			name:    "no address",
+			address: "",
+		},
+		{
+			name:    "p2wkh",
+			address: p2wkh.String(),
+		},
+		{
+			name:      "wrong network",
+			address:   mainnetP2wkh.String(),
+			expectErr: true,
+		},
+		{
+			name:      "unsupported type",
+			address:   p2tr.String(),
+			expectErr: true,
+		},
+		{
+			name:      "invalid address",
+			address:   "invalid",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			script, err := ParseUpfrontShutdownAddress(
+				test.address, params,
+			)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, lnwallet.ValidateUpfrontShutdown(script))
+		})
+	}
+}
+
+// TestMaxFeeBelowIdealFee tests that as the initiator of the channel, we don't
+// start a cooperative close if our max fee is below our ideal fee.
+func TestMaxFeeBelowIdealFee(t *testing.T) {
+	t.Parallel()
+
+	const idealFeeRate = 2 * chainfee.FeePerKwFloor
+
+	aliceChannel, _, cleanUp, err := lnwallet.CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	require.True(t, aliceChannel.IsInitiator())
+
+	cfg := ChanCloseCfg{
+		Channel: aliceChannel,
+		DisableChannel: func(wire.OutPoint) error {
+			return nil
+		},
+		MaxFee: chainfee.FeePerKwFloor,
+	}
+
+	// Shutting down the channel ourselves is refused right away, before
+	// the channel is marked as closing.
+	chanCloser := NewChanCloser(
+		cfg, randDeliveryAddress(t), idealFeeRate, 0, nil, true,
+	)
+	_, err = chanCloser.ShutdownChan()
+	require.ErrorIs(t, err, ErrProposalExceedsMaxFee)
+	require.False(t, aliceChannel.State().HasChanStatus(
+		channeldb.ChanStatusCoopBroadcasted,
+	))
+
+	// If the remote party shuts down the channel, our initial proposal
+	// is checked against our max fee as well.
+	chanCloser = NewChanCloser(
+		cfg, randDeliveryAddress(t), idealFeeRate, 0, nil, false,
+	)
+	_, _, err = chanCloser.ProcessCloseMsg(
+		lnwire.NewShutdown(chanCloser.cid, randDeliveryAddress(t)),
+	)
+	require.ErrorIs(t, err, ErrProposalExceedsMaxFee)
+}
@@ -71,6 +71,27 @@ type Request struct {
 	// account.
 	SubtractFees bool
 
+	// FundUpToMaxAmt should be set to the maximum size of the channel if
+	// we intend to fund it with as much of our eligible coins as
+	// possible, subtracting the fees from the funding output. LocalAmt is
+	// ignored in this case.
+	FundUpToMaxAmt btcutil.Amount
+
+	// MinFundAmt is the smallest funding output that is acceptable when
+	// FundUpToMaxAmt is set.
+	MinFundAmt btcutil.Amount
+
+	// WalletReserve is the amount that must remain in the wallet when
+	// FundUpToMaxAmt is set, for instance to be able to fee bump anchor
+	// channels.
+	WalletReserve btcutil.Amount
+
+	// Outpoints is an optional list of the coins that must be used to
+	// fund the channel. If set, coin selection will only consider these
+	// coins, in the given order and regardless of the coin selection
+	// strategy.
+	Outpoints []wire.OutPoint
+
 	// FeeRate is the fee rate in sat/kw that the funding transaction
 	// should carry.
 	FeeRate chainfee.SatPerKWeight
@@ -2,7 +2,10 @@ package chanfunding
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
 
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -64,6 +67,90 @@ func selectInputs(amt btcutil.Amount, coins []Coin) (btcutil.Amount, []Coin, err
 	return 0, nil, &ErrInsufficientFunds{amt, satSelected}
 }
 
+// CoinSelectionStrategy decides in which order the available coins are added
+// to a transaction during coin selection.
+type CoinSelectionStrategy interface {
+	// ArrangeCoins returns the coins that should be considered for coin
+	// selection at the given fee rate, in the order they should be
+	// selected.
+	ArrangeCoins(coins []Coin, feeRate chainfee.SatPerKWeight) []Coin
+}
+
+// LargestFirstCoinSelection is a coin selection strategy that always selects
+// the largest available coin next. This minimizes the number of inputs, and
+// thereby the fees of a transaction.
+type LargestFirstCoinSelection struct{}
+
+// ArrangeCoins returns the coins sorted by value in descending order.
+//
+// NOTE: This is part of the CoinSelectionStrategy interface.
+func (LargestFirstCoinSelection) ArrangeCoins(coins []Coin,
+	_ chainfee.SatPerKWeight) []Coin {
+
+	sorted := make([]Coin, len(coins))
+	copy(sorted, coins)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+
+	return sorted
+}
+
+// RandomCoinSelection is a coin selection strategy that selects coins in
+// random order. Unlike always spending the largest coins, this prevents the
+// creation of ever smaller coins over time that may never become economical to
+// spend.
+type RandomCoinSelection struct{}
+
+// ArrangeCoins returns the coins in random order, skipping any coin that
+// costs more to spend at the given fee rate than it's worth.
+//
+// NOTE: This is part of the CoinSelectionStrategy interface.
+func (RandomCoinSelection) ArrangeCoins(coins []Coin,
+	feeRate chainfee.SatPerKWeight) []Coin {
+
+	eligible := make([]Coin, 0, len(coins))
+	for _, coin := range coins {
+		if !coinYieldsPositively(coin, feeRate) {
+			continue
+		}
+
+		eligible = append(eligible, coin)
+	}
+
+	rand.Shuffle(len(eligible), func(i, j int) {
+		eligible[i], eligible[j] = eligible[j], eligible[i]
+	})
+
+	return eligible
+}
+
+// coinYieldsPositively returns true if the value of the coin exceeds the fee
+// required to spend it at the given fee rate.
+func coinYieldsPositively(coin Coin, feeRate chainfee.SatPerKWeight) bool {
+	var inputWeight int64
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(coin.PkScript):
+		inputWeight = input.InputSize*blockchain.WitnessScaleFactor +
+			input.P2WKHWitnessSize
+
+	case txscript.IsPayToScriptHash(coin.PkScript):
+		inputWeight = (input.InputSize+input.NestedP2WPKHSize)*
+			blockchain.WitnessScaleFactor + input.P2WKHWitnessSize
+
+	case txscript.IsPayToTaproot(coin.PkScript):
+		inputWeight = input.InputSize*blockchain.WitnessScaleFactor +
+			input.TaprootKeyPathWitnessSize
+
+	// We don't know the cost of spending any other coin, so we leave it to
+	// coin selection to reject it.
+	default:
+		return true
+	}
+
+	return btcutil.Amount(coin.Value) > feeRate.FeeForWeight(inputWeight)
+}
+
 // calculateFees returns for the specified utxos and fee rate two fee
 // estimates, one calculated using a change output and one without. The weight
 // added to the estimator from a change output is for a P2WKH output.
@@ -79,6 +166,11 @@ func calculateFees(utxos []Coin, feeRate chainfee.SatPerKWeight) (btcutil.Amount
 		case txscript.IsPayToScriptHash(utxo.PkScript):
 			weightEstimate.AddNestedP2WKHInput()
 
+		case txscript.IsPayToTaproot(utxo.PkScript):
+			weightEstimate.AddTaprootKeySpendInput(
+				txscript.SigHashDefault,
+			)
+
 		default:
 			return 0, 0, &errUnsupportedInput{utxo.PkScript}
 		}
@@ -187,6 +279,76 @@ func CoinSelect(feeRate chainfee.SatPerKWeight, amt, dustLimit btcutil.Amount,
 	}
 }
 
+// CoinSelectUpToAmount attempts to select coins such that we'll fund an output
+// of up to maxAmount, but at least minAmount, leaving at least reserved in the
+// wallet. If the coins add up to more than that, a regular coin selection for
+// maxAmount is performed. Otherwise all coins are spent except for the
+// reserve, and the fees are subtracted from the output. The selected coins,
+// the final output and change values are returned.
+func CoinSelectUpToAmount(feeRate chainfee.SatPerKWeight, minAmount,
+	maxAmount, reserved, dustLimit btcutil.Amount, coins []Coin) ([]Coin,
+	btcutil.Amount, btcutil.Amount, error) {
+
+	var totalSat btcutil.Amount
+	for _, coin := range coins {
+		totalSat += btcutil.Amount(coin.Value)
+	}
+
+	if totalSat <= reserved {
+		return nil, 0, 0, &ErrInsufficientFunds{
+			amountAvailable: reserved,
+			amountSelected:  totalSat,
+		}
+	}
+
+	// If we have more than enough, we'll fund the maximum amount and
+	// return the rest as change, as long as what remains in the wallet
+	// still covers the reserve.
+	if totalSat-reserved > maxAmount {
+		selectedUtxos, changeAmt, err := CoinSelect(
+			feeRate, maxAmount, dustLimit, coins,
+		)
+		if err == nil {
+			var selectedSat btcutil.Amount
+			for _, coin := range selectedUtxos {
+				selectedSat += btcutil.Amount(coin.Value)
+			}
+
+			remaining := totalSat - selectedSat + changeAmt
+			if remaining >= reserved {
+				return selectedUtxos, maxAmount, changeAmt, nil
+			}
+		}
+	}
+
+	// Otherwise we'll spend everything but the reserve, and subtract the
+	// fees from the output. We get here if the coins don't exceed the
+	// maximum amount plus the reserve, or if a regular selection for the
+	// maximum amount failed or wouldn't leave the reserve, which means the
+	// coins don't cover the maximum amount, its fees and the reserve. In
+	// either case the output after fees shouldn't exceed the maximum, which
+	// we still check for below. An output below the minimum is rejected.
+	selectedUtxos, outputAmt, changeAmt, err := CoinSelectSubtractFees(
+		feeRate, totalSat-reserved, dustLimit, coins,
+	)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if outputAmt > maxAmount {
+		return nil, 0, 0, fmt.Errorf("output amount(%v) above maximum "+
+			"amount(%v)", outputAmt, maxAmount)
+	}
+
+	if outputAmt < minAmount {
+		return nil, 0, 0, fmt.Errorf("output amount(%v) after "+
+			"subtracting fees is below the minimum amount(%v)",
+			outputAmt, minAmount)
+	}
+
+	return selectedUtxos, outputAmt, changeAmt, nil
+}
+
 // CoinSelectSubtractFees attempts to select coins such that we'll spend up to
 // amt in total after fees, adhering to the specified fee rate. The selected
 // coins, the final output and change values are returned.
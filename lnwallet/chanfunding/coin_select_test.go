@@ -21,6 +21,11 @@ var (
 		"a914f7bd5b8077b9549653dacf96f824af9d931663e687",
 	)
 
+	p2trScript, _ = hex.DecodeString(
+		"5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc68809" +
+			"49dc684c",
+	)
+
 	p2khScript, _ = hex.DecodeString(
 		"76a91411034bdcb6ccb7744fdfdeea958a6fb0b415a03288ac",
 	)
@@ -53,7 +58,7 @@ func fundingFee(feeRate chainfee.SatPerKWeight, numInput int, // nolint:unparam
 
 // TestCalculateFees tests that the helper function to calculate the fees
 // both with and without applying a change output is done correctly for
-// (N)P2WKH inputs, and should raise an error otherwise.
+// (N)P2WKH and P2TR inputs, and should raise an error otherwise.
 func TestCalculateFees(t *testing.T) {
 	t.Parallel()
 
@@ -101,6 +106,22 @@ func TestCalculateFees(t *testing.T) {
 			expectedErr:           nil,
 		},
 
+		{
+			name: "one P2TR input",
+			utxos: []Coin{
+				{
+					TxOut: wire.TxOut{
+						PkScript: p2trScript,
+						Value:    1,
+					},
+				},
+			},
+
+			expectedFeeNoChange:   444,
+			expectedFeeWithChange: 568,
+			expectedErr:           nil,
+		},
+
 		{
 			name: "not supported P2KH input",
 			utxos: []Coin{
@@ -133,6 +154,44 @@ func TestCalculateFees(t *testing.T) {
 	}
 }
 
+// TestCoinSelectionStrategies tests that the coin selection strategies arrange
+// the coins as expected.
+func TestCoinSelectionStrategies(t *testing.T) {
+	t.Parallel()
+
+	// At this fee rate, spending a P2WKH input costs 273 satoshis, which
+	// makes the smallest coin uneconomical to spend.
+	const feeRate = chainfee.SatPerKWeight(1000)
+
+	coin := func(value int64, index uint32) Coin {
+		return Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    value,
+			},
+			OutPoint: wire.OutPoint{Index: index},
+		}
+	}
+	coins := []Coin{
+		coin(2000, 0), coin(200, 1), coin(5000, 2), coin(1000, 3),
+	}
+
+	// The largest coins should be selected first, including the
+	// uneconomical one, without modifying the passed coins.
+	largest := LargestFirstCoinSelection{}.ArrangeCoins(coins, feeRate)
+	require.Equal(t, []Coin{
+		coin(5000, 2), coin(2000, 0), coin(1000, 3), coin(200, 1),
+	}, largest)
+	require.Equal(t, coin(2000, 0), coins[0])
+
+	// The random strategy should skip the uneconomical coin, and return
+	// the others in any order.
+	random := RandomCoinSelection{}.ArrangeCoins(coins, feeRate)
+	require.ElementsMatch(t, []Coin{
+		coin(2000, 0), coin(5000, 2), coin(1000, 3),
+	}, random)
+}
+
 // TestCoinSelect tests that we pick coins adding up to the expected amount
 // when creating a funding transaction, and that the calculated change is the
 // expected amount.
@@ -530,3 +589,120 @@ func TestCoinSelectSubtractFees(t *testing.T) {
 		})
 	}
 }
+
+// TestCoinSelectUpToAmount tests that we fund up to the maximum amount,
+// spending all coins if necessary, while keeping the requested reserve.
+func TestCoinSelectUpToAmount(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = chainfee.SatPerKWeight(100)
+	const dustLimit = btcutil.Amount(1000)
+
+	oneBtcCoins := []Coin{
+		{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    1 * btcutil.SatoshiPerBitcoin,
+			},
+		},
+	}
+
+	type testCase struct {
+		name      string
+		coins     []Coin
+		minAmount btcutil.Amount
+		maxAmount btcutil.Amount
+		reserved  btcutil.Amount
+
+		expectedFundingAmt btcutil.Amount
+		expectedChange     btcutil.Amount
+		expectErr          bool
+	}
+
+	testCases := []testCase{
+		{
+			// We have more than the maximum amount available, so
+			// we fund exactly that and get the rest as change.
+			name:      "fund max amount",
+			coins:     oneBtcCoins,
+			maxAmount: 0.5 * btcutil.SatoshiPerBitcoin,
+
+			expectedFundingAmt: 0.5 * btcutil.SatoshiPerBitcoin,
+			expectedChange: 0.5*btcutil.SatoshiPerBitcoin -
+				fundingFee(feeRate, 1, true),
+		},
+		{
+			// The maximum is above our balance, so everything is
+			// spent and the fees are subtracted from the output.
+			name:      "fund all coins",
+			coins:     oneBtcCoins,
+			maxAmount: 2 * btcutil.SatoshiPerBitcoin,
+
+			expectedFundingAmt: 1*btcutil.SatoshiPerBitcoin -
+				fundingFee(feeRate, 1, false),
+		},
+		{
+			// The reserve is kept in the wallet as change.
+			name:      "fund all coins but the reserve",
+			coins:     oneBtcCoins,
+			maxAmount: 2 * btcutil.SatoshiPerBitcoin,
+			reserved:  0.1 * btcutil.SatoshiPerBitcoin,
+
+			expectedFundingAmt: 0.9*btcutil.SatoshiPerBitcoin -
+				fundingFee(feeRate, 1, true),
+			expectedChange: 0.1 * btcutil.SatoshiPerBitcoin,
+		},
+		{
+			// Funding the maximum amount would dip into the
+			// reserve, so the output is reduced instead.
+			name:      "max amount would use reserve",
+			coins:     oneBtcCoins,
+			maxAmount: 0.95 * btcutil.SatoshiPerBitcoin,
+			reserved:  0.1 * btcutil.SatoshiPerBitcoin,
+
+			expectedFundingAmt: 0.9*btcutil.SatoshiPerBitcoin -
+				fundingFee(feeRate, 1, true),
+			expectedChange: 0.1 * btcutil.SatoshiPerBitcoin,
+		},
+		{
+			// We can't reach the minimum amount.
+			name:      "below minimum amount",
+			coins:     oneBtcCoins,
+			minAmount: 1 * btcutil.SatoshiPerBitcoin,
+			maxAmount: 2 * btcutil.SatoshiPerBitcoin,
+			expectErr: true,
+		},
+		{
+			// Nothing is left once the reserve is accounted for.
+			name:      "reserve not covered",
+			coins:     oneBtcCoins,
+			maxAmount: 2 * btcutil.SatoshiPerBitcoin,
+			reserved:  1 * btcutil.SatoshiPerBitcoin,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, fundingAmt, changeAmt, err :=
+				CoinSelectUpToAmount(
+					feeRate, test.minAmount,
+					test.maxAmount, test.reserved,
+					dustLimit, test.coins,
+				)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.coins, selected)
+			require.Equal(t, test.expectedFundingAmt, fundingAmt)
+			require.Equal(t, test.expectedChange, changeAmt)
+		})
+	}
+}
@@ -227,18 +227,24 @@ func (i *PsbtIntent) Verify(packet *psbt.Packet, skipFinalize bool) error {
 	if err != nil {
 		return fmt.Errorf("funding output cannot be created: %v", err)
 	}
-	outputFound := false
+	numFundingOutputs := 0
 	outputSum := int64(0)
 	for _, out := range packet.UnsignedTx.TxOut {
 		outputSum += out.Value
 		if psbt.TxOutsEqual(out, expectedOutput) {
-			outputFound = true
+			numFundingOutputs++
 		}
 	}
-	if !outputFound {
+	if numFundingOutputs == 0 {
 		return fmt.Errorf("funding output not found in PSBT")
 	}
 
+	// Only the first funding output would be used as the channel point,
+	// any duplicate would lock up funds that can never be spent again.
+	if numFundingOutputs > 1 {
+		return fmt.Errorf("funding output found more than once in PSBT")
+	}
+
 	// At least one input needs to be specified and it must be large enough
 	// to pay for all outputs. We don't want to dive into fee estimation
 	// here so we just assume that if the input amount exceeds the output
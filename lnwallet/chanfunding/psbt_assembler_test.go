@@ -320,6 +320,19 @@ func TestPsbtVerify(t *testing.T) {
 				return i.Verify(p, false)
 			},
 		},
+		{
+			name:          "duplicate funding output",
+			shouldPublish: true,
+			expectedErr: "funding output found more than once " +
+				"in PSBT",
+			doVerify: func(amt int64, p *psbt.Packet,
+				i *PsbtIntent) error {
+
+				txOuts := p.UnsignedTx.TxOut
+				p.UnsignedTx.TxOut = append(txOuts, txOuts[0])
+				return i.Verify(p, false)
+			},
+		},
 		{
 			name:          "no inputs",
 			shouldPublish: true,
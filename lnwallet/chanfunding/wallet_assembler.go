@@ -217,6 +217,10 @@ type WalletConfig struct {
 	// DustLimit is the current dust limit. We'll use this to ensure that
 	// we don't make dust outputs on the funding transaction.
 	DustLimit btcutil.Amount
+
+	// CoinSelectionStrategy is the strategy used to pick the coins that
+	// fund the channel. If not set, the largest coins are selected first.
+	CoinSelectionStrategy CoinSelectionStrategy
 }
 
 // WalletAssembler is an instance of the Assembler interface that is backed by
@@ -256,9 +260,7 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			"sat/kw as fee rate", int64(r.FeeRate))
 
 		// Find all unlocked unspent witness outputs that satisfy the
-		// minimum number of confirmations required. Coin selection in
-		// this function currently ignores the configured coin selection
-		// strategy.
+		// minimum number of confirmations required.
 		coins, err := w.cfg.CoinSource.ListCoins(
 			r.MinConfs, math.MaxInt32,
 		)
@@ -266,6 +268,11 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			return err
 		}
 
+		coins, err = w.arrangeCoins(coins, r)
+		if err != nil {
+			return err
+		}
+
 		var (
 			selectedCoins        []Coin
 			localContributionAmt btcutil.Amount
@@ -279,9 +286,21 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 		// If there's no funding amount at all (receiving an inbound
 		// single funder request), then we don't need to perform any
 		// coin selection at all.
-		case r.LocalAmt == 0:
+		case r.LocalAmt == 0 && r.FundUpToMaxAmt == 0:
 			break
 
+		// If we're asked to fund the channel with as much as we can,
+		// we'll spend all eligible coins up to the maximum amount.
+		case r.FundUpToMaxAmt > 0:
+			dustLimit := w.cfg.DustLimit
+			selectedCoins, localContributionAmt, changeAmt, err = CoinSelectUpToAmount(
+				r.FeeRate, r.MinFundAmt, r.FundUpToMaxAmt,
+				r.WalletReserve, dustLimit, coins,
+			)
+			if err != nil {
+				return err
+			}
+
 		// In case this request want the fees subtracted from the local
 		// amount, we'll call the specialized method for that. This
 		// ensures that we won't deduct more that the specified balance
@@ -370,6 +389,58 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 	return intent, nil
 }
 
+// arrangeCoins returns the coins that coin selection should consider for the
+// given request, in the order they should be selected. If the caller selected
+// the coins to fund the channel with, coin selection is restricted to them in
+// the order they were given. The configured strategy isn't applied in that
+// case, as it may skip coins the caller explicitly asked to spend.
+func (w *WalletAssembler) arrangeCoins(coins []Coin, r *Request) ([]Coin,
+	error) {
+
+	if len(r.Outpoints) > 0 {
+		return filterCoins(coins, r.Outpoints)
+	}
+
+	var strategy CoinSelectionStrategy = LargestFirstCoinSelection{}
+	if w.cfg.CoinSelectionStrategy != nil {
+		strategy = w.cfg.CoinSelectionStrategy
+	}
+
+	return strategy.ArrangeCoins(coins, r.FeeRate), nil
+}
+
+// filterCoins returns the coins with the given outpoints. An error is returned
+// if any of the outpoints isn't among the passed coins, which means that it's
+// either unknown, locked or lacking confirmations.
+func filterCoins(coins []Coin, outpoints []wire.OutPoint) ([]Coin, error) {
+	coinsByOutpoint := make(map[wire.OutPoint]Coin, len(coins))
+	for _, coin := range coins {
+		coinsByOutpoint[coin.OutPoint] = coin
+	}
+
+	filtered := make([]Coin, 0, len(outpoints))
+	selected := make(map[wire.OutPoint]struct{}, len(outpoints))
+	for _, outpoint := range outpoints {
+		// Make sure we don't count a coin twice if it was requested
+		// more than once.
+		if _, ok := selected[outpoint]; ok {
+			return nil, fmt.Errorf("outpoint %v selected more "+
+				"than once", outpoint)
+		}
+		selected[outpoint] = struct{}{}
+
+		coin, ok := coinsByOutpoint[outpoint]
+		if !ok {
+			return nil, fmt.Errorf("outpoint %v is not available "+
+				"for funding", outpoint)
+		}
+
+		filtered = append(filtered, coin)
+	}
+
+	return filtered, nil
+}
+
 // FundingTxAvailable is an empty method that an assembler can implement to
 // signal to callers that its able to provide the funding transaction for the
 // channel via the intent it returns.
@@ -0,0 +1,76 @@
+package chanfunding
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFilterCoins tests that coin selection can be restricted to a set of
+// outpoints selected by the caller.
+func TestFilterCoins(t *testing.T) {
+	t.Parallel()
+
+	coins := []Coin{
+		{OutPoint: wire.OutPoint{Index: 0}},
+		{OutPoint: wire.OutPoint{Index: 1}},
+		{OutPoint: wire.OutPoint{Index: 2}},
+	}
+
+	// The coins are returned in the order of the outpoints.
+	filtered, err := filterCoins(coins, []wire.OutPoint{
+		{Index: 2}, {Index: 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Coin{coins[2], coins[0]}, filtered)
+
+	// Outpoints that aren't eligible for funding are rejected.
+	_, err = filterCoins(coins, []wire.OutPoint{{Index: 3}})
+	require.Error(t, err)
+
+	// And so is selecting the same coin twice.
+	_, err = filterCoins(coins, []wire.OutPoint{{Index: 1}, {Index: 1}})
+	require.Error(t, err)
+}
+
+// TestArrangeCoinsOutpoints tests that coins explicitly selected by the caller
+// are never skipped by the configured coin selection strategy.
+func TestArrangeCoinsOutpoints(t *testing.T) {
+	t.Parallel()
+
+	// At this fee rate, spending a P2WKH input costs 273 satoshis, which
+	// makes the smallest coin uneconomical to spend.
+	const feeRate = chainfee.SatPerKWeight(1000)
+
+	coin := func(value int64, index uint32) Coin {
+		return Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    value,
+			},
+			OutPoint: wire.OutPoint{Index: index},
+		}
+	}
+	coins := []Coin{coin(2000, 0), coin(200, 1), coin(5000, 2)}
+
+	w := NewWalletAssembler(WalletConfig{
+		CoinSelectionStrategy: RandomCoinSelection{},
+	})
+
+	// Without explicit outpoints, the random strategy skips the
+	// uneconomical coin.
+	arranged, err := w.arrangeCoins(coins, &Request{FeeRate: feeRate})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Coin{coin(2000, 0), coin(5000, 2)}, arranged)
+
+	// If the caller selected it, it's kept, and the coins are returned
+	// in the order of the outpoints.
+	arranged, err = w.arrangeCoins(coins, &Request{
+		FeeRate:   feeRate,
+		Outpoints: []wire.OutPoint{{Index: 1}, {Index: 0}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Coin{coin(200, 1), coin(2000, 0)}, arranged)
+}
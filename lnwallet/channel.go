@@ -1460,6 +1460,11 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 	// PaymentDescriptor that SettleHTLC would produce.
 	case *lnwire.UpdateFulfillHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		pd = &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1477,6 +1482,11 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 	// removal height for the remote commitment.
 	case *lnwire.UpdateFailHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		pd = &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1492,7 +1502,11 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 	// way as regular HTLC fails.
 	case *lnwire.UpdateFailMalformedHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
-		// TODO(roasbeef): err if nil?
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		pd = &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1547,6 +1561,11 @@ func (lc *LightningChannel) localLogUpdateToPayDesc(logUpdate *channeldb.LogUpda
 	// ReceiveHTLCSettle would produce.
 	case *lnwire.UpdateFulfillHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1563,6 +1582,11 @@ func (lc *LightningChannel) localLogUpdateToPayDesc(logUpdate *channeldb.LogUpda
 	// HTLC we're failing.
 	case *lnwire.UpdateFailHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1578,6 +1602,11 @@ func (lc *LightningChannel) localLogUpdateToPayDesc(logUpdate *channeldb.LogUpda
 	// way as regular HTLC fails.
 	case *lnwire.UpdateFailMalformedHTLC:
 		ogHTLC := remoteUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                   ogHTLC.Amount,
@@ -1644,6 +1673,11 @@ func (lc *LightningChannel) remoteLogUpdateToPayDesc(logUpdate *channeldb.LogUpd
 	// PaymentDescriptor that ReceiveHTLCSettle would produce.
 	case *lnwire.UpdateFulfillHTLC:
 		ogHTLC := localUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                  ogHTLC.Amount,
@@ -1660,6 +1694,11 @@ func (lc *LightningChannel) remoteLogUpdateToPayDesc(logUpdate *channeldb.LogUpd
 	// the original HTLC we're failing.
 	case *lnwire.UpdateFailHTLC:
 		ogHTLC := localUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                  ogHTLC.Amount,
@@ -1675,6 +1714,11 @@ func (lc *LightningChannel) remoteLogUpdateToPayDesc(logUpdate *channeldb.LogUpd
 	// way as regular HTLC fails.
 	case *lnwire.UpdateFailMalformedHTLC:
 		ogHTLC := localUpdateLog.lookupHtlc(wireMsg.ID)
+		if ogHTLC == nil {
+			return nil, ErrUnknownHtlcIndex{
+				lc.ShortChanID(), wireMsg.ID,
+			}
+		}
 
 		return &PaymentDescriptor{
 			Amount:                  ogHTLC.Amount,
@@ -6940,6 +6984,16 @@ func (lc *LightningChannel) ReceiveUpdateFee(feePerKw chainfee.SatPerKWeight) er
 		EntryType: FeeUpdate,
 	}
 
+	// Ensure that the remote party can afford the new fee rate on our
+	// commitment without dipping below their channel reserve.
+	localACKedIndex := lc.remoteCommitChain.tail().ourMessageIndex
+	err := lc.validateCommitmentSanity(
+		lc.remoteUpdateLog.logIndex, localACKedIndex, false, nil, pd,
+	)
+	if err != nil {
+		return err
+	}
+
 	lc.remoteUpdateLog.appendUpdate(pd)
 
 	return nil
@@ -7120,7 +7174,7 @@ func (lc *LightningChannel) IdealCommitFeeRate(netFeeRate, minRelayFeeRate,
 	// To give the transaction the best chance, we use the absolute
 	// maximum fee we have available and we log an error.
 	lc.log.Errorf("The commitment fee rate of %s is below the current "+
-		"minimum relay fee rate of %s. The max fee rate of %s will be"+
+		"minimum relay fee rate of %s. The max fee rate of %s will be "+
 		"used.", commitFeeRate, minRelayFeeRate, absoluteMaxFee)
 
 	return absoluteMaxFee
@@ -6938,6 +6938,40 @@ func TestChanReserveLocalInitiatorDustHtlc(t *testing.T) {
 	}
 }
 
+// TestChanReserveRemoteFeeUpdate tests that a fee update from the initiator is
+// rejected if the initiator can't afford the new fee without dipping below
+// its channel reserve.
+func TestChanReserveRemoteFeeUpdate(t *testing.T) {
+	t.Parallel()
+
+	// We start out with a channel where both parties have 5 BTC.
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	// Set Alice's channel reserve to be 5 BTC-commitfee, such that she has
+	// just enough balance to cover the current commitment fee.
+	commitFee := aliceChannel.channelState.LocalCommitment.CommitFee
+	aliceMinReserve := 5*btcutil.SatoshiPerBitcoin - commitFee
+
+	aliceChannel.channelState.LocalChanCfg.ChanReserve = aliceMinReserve
+	bobChannel.channelState.RemoteChanCfg.ChanReserve = aliceMinReserve
+
+	// Bob should refuse a fee update that increases the fee rate, as it
+	// would take Alice below her channel reserve.
+	feePerKw := chainfee.SatPerKWeight(
+		aliceChannel.channelState.LocalCommitment.FeePerKw,
+	)
+	err = bobChannel.ReceiveUpdateFee(feePerKw * 2)
+	require.ErrorIs(t, err, ErrBelowChanReserve)
+
+	// Lowering the fee rate increases Alice's balance, so that update is
+	// accepted.
+	require.NoError(t, bobChannel.ReceiveUpdateFee(feePerKw/2))
+}
+
 // TestMinHTLC tests that the ErrBelowMinHTLC error is thrown if an HTLC is added
 // that is below the minimm allowed value for HTLCs.
 func TestMinHTLC(t *testing.T) {
@@ -7500,6 +7534,50 @@ func TestChannelRestoreUpdateLogsFailedHTLC(t *testing.T) {
 	restoreAndAssert(t, aliceChannel, 0, 0, 0, 0)
 }
 
+// TestChannelRestoreUnknownHtlc asserts that restoring a log update that
+// removes an HTLC we don't know of results in an error rather than a panic.
+func TestChannelRestoreUnknownHtlc(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, _, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	const unknownIndex = 1337
+	updates := []lnwire.Message{
+		&lnwire.UpdateFulfillHTLC{ID: unknownIndex},
+		&lnwire.UpdateFailHTLC{ID: unknownIndex},
+		&lnwire.UpdateFailMalformedHTLC{ID: unknownIndex},
+	}
+
+	for _, msg := range updates {
+		logUpdate := &channeldb.LogUpdate{
+			LogIndex:  0,
+			UpdateMsg: msg,
+		}
+		expectedErr := ErrUnknownHtlcIndex{
+			aliceChannel.ShortChanID(), unknownIndex,
+		}
+
+		_, err := aliceChannel.logUpdateToPayDesc(
+			logUpdate, aliceChannel.remoteUpdateLog, 1, 0, nil, 0,
+		)
+		require.Equal(t, expectedErr, err)
+
+		_, err = aliceChannel.localLogUpdateToPayDesc(
+			logUpdate, aliceChannel.remoteUpdateLog, 1,
+		)
+		require.Equal(t, expectedErr, err)
+
+		_, err = aliceChannel.remoteLogUpdateToPayDesc(
+			logUpdate, aliceChannel.localUpdateLog, 1,
+		)
+		require.Equal(t, expectedErr, err)
+	}
+}
+
 // TestDuplicateFailRejection tests that if either party attempts to fail an
 // HTLC twice, then we'll reject the second fail attempt.
 func TestDuplicateFailRejection(t *testing.T) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -85,6 +86,15 @@ func ErrNonZeroPushAmount() ReservationError {
 	return ReservationError{errors.New("non-zero push amounts are disabled")}
 }
 
+// ErrUnknownPeer is returned by a remote peer that receives a FundingOpen
+// request from a peer it has no open channels with while it has
+// 'rejectunknownpeers' enabled.
+func ErrUnknownPeer() ReservationError {
+	return ReservationError{
+		errors.New("channels from unknown peers are disabled"),
+	}
+}
+
 // ErrMinHtlcTooLarge returns an error indicating that the MinHTLC value the
 // remote required is too large to be accepted.
 func ErrMinHtlcTooLarge(minHtlc,
@@ -152,6 +162,18 @@ func ErrChanTooLarge(chanSize, maxChanSize btcutil.Amount) ReservationError {
 	}
 }
 
+// ErrMinFundAmtExceedsMax returns an error indicating that the minimum amount
+// of a channel that is funded up to a maximum amount exceeds that maximum, so
+// the channel can never be funded.
+func ErrMinFundAmtExceedsMax(minFundAmt,
+	maxFundAmt btcutil.Amount) ReservationError {
+
+	return ReservationError{
+		fmt.Errorf("min funding amount of %v exceeds max funding "+
+			"amount of %v", minFundAmt, maxFundAmt),
+	}
+}
+
 // ErrInvalidDustLimit returns an error indicating that a proposed DustLimit
 // was rejected.
 func ErrInvalidDustLimit(dustLimit btcutil.Amount) ReservationError {
@@ -160,6 +182,28 @@ func ErrInvalidDustLimit(dustLimit btcutil.Amount) ReservationError {
 	}
 }
 
+// ErrCommitFeeTooLow returns an error indicating that the fee rate proposed
+// for the initial commitment transaction is below the minimum relay fee rate,
+// which would prevent the commitment from being broadcast.
+func ErrCommitFeeTooLow(feePerKw,
+	minFeePerKw chainfee.SatPerKWeight) ReservationError {
+
+	return ReservationError{
+		fmt.Errorf("commitment fee rate %v is below minimum fee rate "+
+			"of %v", feePerKw, minFeePerKw),
+	}
+}
+
+// ErrInvalidUpfrontShutdown returns an error indicating that the upfront
+// shutdown script the remote party provided isn't of a standard form.
+func ErrInvalidUpfrontShutdown(
+	shutdown lnwire.DeliveryAddress) ReservationError {
+
+	return ReservationError{
+		fmt.Errorf("upfront shutdown script %x is invalid", shutdown),
+	}
+}
+
 // ErrHtlcIndexAlreadyFailed is returned when the HTLC index has already been
 // failed, but has not been committed by our commitment state.
 type ErrHtlcIndexAlreadyFailed uint64
@@ -15,6 +15,7 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/lightningnetwork/lnd/keychain"
@@ -257,26 +258,32 @@ type WalletController interface {
 	// The address type can usually be inferred from the key's version, but
 	// in the case of legacy versions (xpub, tpub), an address type must be
 	// specified as we intend to not support importing BIP-44 keys into the
-	// wallet using the legacy pay-to-pubkey-hash (P2PKH) scheme.
+	// wallet using the legacy pay-to-pubkey-hash (P2PKH) scheme. If the
+	// rescan start height is non-zero, the chain is rescanned in the
+	// background from that height to detect past events of the key.
 	ImportPublicKey(pubKey *btcec.PublicKey,
-		addrType waddrmgr.AddressType) error
+		addrType waddrmgr.AddressType, rescanStartHeight uint32) error
 
 	// SendOutputs funds, signs, and broadcasts a Bitcoin transaction paying
 	// out to the specified outputs. In the case the wallet has insufficient
 	// funds, or the outputs are non-standard, an error should be returned.
 	// This method also takes the target fee expressed in sat/kw that should
-	// be used when crafting the transaction.
+	// be used when crafting the transaction. An optional coin selection
+	// strategy can be passed, otherwise the wallet's configured strategy
+	// is used.
 	//
 	// NOTE: This method requires the global coin selection lock to be held.
 	SendOutputs(outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight,
-		minConfs int32, label string) (*wire.MsgTx, error)
+		minConfs int32, label string,
+		strategy *wallet.CoinSelectionStrategy) (*wire.MsgTx, error)
 
 	// CreateSimpleTx creates a Bitcoin transaction paying to the specified
 	// outputs. The transaction is not broadcasted to the network. In the
 	// case the wallet has insufficient funds, or the outputs are
 	// non-standard, an error should be returned. This method also takes
 	// the target fee expressed in sat/kw that should be used when crafting
-	// the transaction.
+	// the transaction. An optional coin selection strategy can be passed,
+	// otherwise the wallet's configured strategy is used.
 	//
 	// NOTE: The dryRun argument can be set true to create a tx that
 	// doesn't alter the database. A tx created with this set to true
@@ -284,7 +291,9 @@ type WalletController interface {
 	//
 	// NOTE: This method requires the global coin selection lock to be held.
 	CreateSimpleTx(outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight,
-		minConfs int32, dryRun bool) (*txauthor.AuthoredTx, error)
+		minConfs int32, dryRun bool,
+		strategy *wallet.CoinSelectionStrategy) (*txauthor.AuthoredTx,
+		error)
 
 	// ListUnspentWitness returns all unspent outputs which are version 0
 	// witness programs. The 'minConfs' and 'maxConfs' parameters
@@ -384,8 +393,10 @@ type WalletController interface {
 	// is returned.
 	//
 	// NOTE: If the packet doesn't contain any inputs, coin selection is
-	// performed automatically. The account parameter must be non-empty as
-	// it determines which set of coins are eligible for coin selection. If
+	// performed automatically with the optional coin selection strategy,
+	// or the wallet's configured strategy if none is passed. The account
+	// parameter must be non-empty as it determines which set of coins are
+	// eligible for coin selection. If
 	// the packet does contain any inputs, it is assumed that full coin
 	// selection happened externally and no additional inputs are added. If
 	// the specified inputs aren't enough to fund the outputs with the given
@@ -393,7 +404,8 @@ type WalletController interface {
 	// the selected/validated inputs. It is in the caller's responsibility
 	// to lock the inputs before handing them out.
 	FundPsbt(packet *psbt.Packet, minConfs int32,
-		feeRate chainfee.SatPerKWeight, account string) (int32, error)
+		feeRate chainfee.SatPerKWeight, account string,
+		strategy *wallet.CoinSelectionStrategy) (int32, error)
 
 	// SignPsbt expects a partial transaction with all inputs and outputs
 	// fully declared and tries to sign all unsigned inputs that have all
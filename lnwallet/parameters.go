@@ -3,6 +3,7 @@ package lnwallet
 import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -80,3 +81,23 @@ func DustLimitForSize(scriptSize int) btcutil.Amount {
 
 	return dustlimit
 }
+
+// ValidateUpfrontShutdown returns true if the passed upfront shutdown script
+// is of one of the forms allowed by BOLT 2: p2pkh, p2sh, p2wpkh or p2wsh. An
+// empty script, which means that no upfront shutdown script is set, is valid
+// as well.
+func ValidateUpfrontShutdown(shutdown lnwire.DeliveryAddress) bool {
+	if len(shutdown) == 0 {
+		return true
+	}
+
+	switch txscript.GetScriptClass(shutdown) {
+	case txscript.PubKeyHashTy, txscript.ScriptHashTy,
+		txscript.WitnessV0PubKeyHashTy, txscript.WitnessV0ScriptHashTy:
+
+		return true
+
+	default:
+		return false
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,74 @@ func TestDustLimitForSize(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateUpfrontShutdown asserts that only the upfront shutdown scripts
+// allowed by BOLT 2 are accepted.
+func TestValidateUpfrontShutdown(t *testing.T) {
+	t.Parallel()
+
+	p2wpkh, err := input.WitnessPubKeyHash(make([]byte, 33))
+	require.NoError(t, err)
+
+	p2wsh, err := input.WitnessScriptHash([]byte{txscript.OP_TRUE})
+	require.NoError(t, err)
+
+	p2sh, err := input.GenerateP2SH([]byte{txscript.OP_TRUE})
+	require.NoError(t, err)
+
+	p2pkh, err := input.GenerateP2PKH(make([]byte, 33))
+	require.NoError(t, err)
+
+	unknownWitness, err := input.GenerateUnknownWitness()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		shutdown lnwire.DeliveryAddress
+		valid    bool
+	}{
+		{
+			name:  "no script",
+			valid: true,
+		},
+		{
+			name:     "p2wpkh",
+			shutdown: p2wpkh,
+			valid:    true,
+		},
+		{
+			name:     "p2wsh",
+			shutdown: p2wsh,
+			valid:    true,
+		},
+		{
+			name:     "p2sh",
+			shutdown: p2sh,
+			valid:    true,
+		},
+		{
+			name:     "p2pkh",
+			shutdown: p2pkh,
+			valid:    true,
+		},
+		{
+			name:     "unknown witness",
+			shutdown: unknownWitness,
+			valid:    false,
+		},
+		{
+			name:     "non-standard script",
+			shutdown: lnwire.DeliveryAddress{txscript.OP_TRUE},
+			valid:    false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			valid := ValidateUpfrontShutdown(test.shutdown)
+			require.Equal(t, test.valid, valid)
+		})
+	}
+}
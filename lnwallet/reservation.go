@@ -555,6 +555,11 @@ func (r *ChannelReservation) OurContribution() *ChannelContribution {
 // will generate a signature to the counterparty's version of the commitment
 // transaction.
 func (r *ChannelReservation) ProcessContribution(theirContribution *ChannelContribution) error {
+	shutdown := theirContribution.UpfrontShutdown
+	if !ValidateUpfrontShutdown(shutdown) {
+		return ErrInvalidUpfrontShutdown(shutdown)
+	}
+
 	errChan := make(chan error, 1)
 
 	r.wallet.msgChan <- &addContributionMsg{
@@ -609,6 +614,11 @@ func (r *ChannelReservation) RemoteCanceled() {
 // taken other than recording the initiator's contribution to the single funder
 // channel.
 func (r *ChannelReservation) ProcessSingleContribution(theirContribution *ChannelContribution) error {
+	shutdown := theirContribution.UpfrontShutdown
+	if !ValidateUpfrontShutdown(shutdown) {
+		return ErrInvalidUpfrontShutdown(shutdown)
+	}
+
 	errChan := make(chan error, 1)
 
 	r.wallet.msgChan <- &addSingleContributionMsg{
@@ -179,7 +179,7 @@ func sendCoins(t *testing.T, miner *rpctest.Harness,
 	t.Helper()
 
 	tx, err := sender.SendOutputs(
-		[]*wire.TxOut{output}, feeRate, minConf, labels.External,
+		[]*wire.TxOut{output}, feeRate, minConf, labels.External, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to send transaction: %v", err)
@@ -1291,7 +1291,7 @@ func testListTransactionDetails(miner *rpctest.Harness,
 	}
 	burnOutput := wire.NewTxOut(outputAmt, outputScript)
 	burnTX, err := alice.SendOutputs(
-		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External,
+		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to create burn tx: %v", err)
@@ -1567,7 +1567,7 @@ func testTransactionSubscriptions(miner *rpctest.Harness,
 	}
 	burnOutput := wire.NewTxOut(outputAmt, outputScript)
 	tx, err := alice.SendOutputs(
-		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External,
+		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to create burn tx: %v", err)
@@ -1763,7 +1763,7 @@ func newTx(t *testing.T, r *rpctest.Harness, pubKey *btcec.PublicKey,
 		PkScript: keyScript,
 	}
 	tx, err := alice.SendOutputs(
-		[]*wire.TxOut{newOutput}, 2500, 1, labels.External,
+		[]*wire.TxOut{newOutput}, 2500, 1, labels.External, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to create output: %v", err)
@@ -2053,7 +2053,7 @@ func testSignOutputUsingTweaks(r *rpctest.Harness,
 			PkScript: keyScript,
 		}
 		tx, err := alice.SendOutputs(
-			[]*wire.TxOut{newOutput}, 2500, 1, labels.External,
+			[]*wire.TxOut{newOutput}, 2500, 1, labels.External, nil,
 		)
 		if err != nil {
 			t.Fatalf("unable to create output: %v", err)
@@ -2181,7 +2181,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 		PkScript: script,
 	}
 	tx, err := w.SendOutputs(
-		[]*wire.TxOut{output}, 2500, 1, labels.External,
+		[]*wire.TxOut{output}, 2500, 1, labels.External, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to send outputs: %v", err)
@@ -2435,7 +2435,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 		PkScript: alicePkScript,
 	}
 	_, err = bob.SendOutputs(
-		[]*wire.TxOut{output}, txFeeRate, 0, labels.External,
+		[]*wire.TxOut{output}, txFeeRate, 0, labels.External, nil,
 	)
 	if err == nil {
 		t.Fatalf("should have not been able to pay due to insufficient balance: %v", err)
@@ -2461,7 +2461,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 	// First, verify that we don't have enough balance to send the coins
 	// using confirmed outputs only.
 	_, err = bob.SendOutputs(
-		[]*wire.TxOut{output}, txFeeRate, 1, labels.External,
+		[]*wire.TxOut{output}, txFeeRate, 1, labels.External, nil,
 	)
 	if err == nil {
 		t.Fatalf("should have not been able to pay due to insufficient balance: %v", err)
@@ -2706,7 +2706,7 @@ func testCreateSimpleTx(r *rpctest.Harness, w *lnwallet.LightningWallet,
 
 		// Now try creating a tx spending to these outputs.
 		createTx, createErr := w.CreateSimpleTx(
-			outputs, feeRate, minConfs, true,
+			outputs, feeRate, minConfs, true, nil,
 		)
 		switch {
 		case test.valid && createErr != nil:
@@ -2723,7 +2723,9 @@ func testCreateSimpleTx(r *rpctest.Harness, w *lnwallet.LightningWallet,
 		// _very_ similar to the one we just created being sent. The
 		// only difference is that the dry run tx is not signed, and
 		// that the change output position might be different.
-		tx, sendErr := w.SendOutputs(outputs, feeRate, minConfs, labels.External)
+		tx, sendErr := w.SendOutputs(
+			outputs, feeRate, minConfs, labels.External, nil,
+		)
 		switch {
 		case test.valid && sendErr != nil:
 			t.Fatalf("got unexpected error when sending tx: %v",
@@ -117,6 +117,31 @@ type InitFundingReserveMsg struct {
 	// channel.
 	LocalFundingAmt btcutil.Amount
 
+	// FundUpToMaxAmt should be set if we want to fund the channel with as
+	// much of our wallet balance as possible, up to this amount. The fees
+	// are subtracted from the funding output and the reserved value for
+	// anchor channels is left in the wallet. LocalFundingAmt must be zero
+	// if this is set.
+	FundUpToMaxAmt btcutil.Amount
+
+	// MinFundAmt is the minimum amount the funding output must have when
+	// FundUpToMaxAmt is set.
+	MinFundAmt btcutil.Amount
+
+	// Outpoints is an optional set of wallet outpoints that must be used
+	// to fund the channel. If empty, coins are chosen by the wallet's
+	// coin selection.
+	Outpoints []wire.OutPoint
+
+	// Account is the name of the wallet account the funding inputs are
+	// selected from and the change is sent to. If empty, the default
+	// account is used.
+	Account string
+
+	// CoinSelectionStrategy optionally overrides the wallet's default coin
+	// selection strategy for this channel.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
 	// RemoteFundingAmnt is the amount of funds the remote will contribute
 	// to this channel.
 	RemoteFundingAmt btcutil.Amount
@@ -688,7 +713,9 @@ func (l *LightningWallet) CancelFundingIntent(pid [32]byte) error {
 // validate a funding reservation request.
 func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg) {
 	// It isn't possible to create a channel with zero funds committed.
-	if req.LocalFundingAmt+req.RemoteFundingAmt == 0 {
+	if req.LocalFundingAmt+req.RemoteFundingAmt == 0 &&
+		req.FundUpToMaxAmt == 0 {
+
 		err := ErrZeroCapacity()
 		req.err <- err
 		req.resp <- nil
@@ -712,6 +739,27 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	// The enforcement still happens at the last step - in PsbtFundingVerify
 	enforceNewReservedValue := true
 
+	// In fundmax mode the amount we commit depends on the coins the
+	// wallet holds, so the caller can't pick a local amount as well.
+	if req.FundUpToMaxAmt > 0 && req.LocalFundingAmt != 0 {
+		req.err <- fmt.Errorf("local funding amount must be zero " +
+			"when funding up to a maximum amount")
+		req.resp <- nil
+		return
+	}
+
+	// Unless the caller selected an account, we'll fund the channel from
+	// the default account.
+	account := DefaultAccountName
+	if req.Account != "" {
+		account = req.Account
+	}
+
+	coinSelectionStrategy := l.Cfg.CoinSelectionStrategy
+	if req.CoinSelectionStrategy != nil {
+		coinSelectionStrategy = req.CoinSelectionStrategy
+	}
+
 	// If no chanFunder was provided, then we'll assume the default
 	// assembler, which is backed by the wallet's internal coin selection.
 	if req.ChanFunder == nil {
@@ -719,11 +767,13 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 		// P2WPKH dust limit and to avoid threading through two
 		// different dust limits.
 		cfg := chanfunding.WalletConfig{
-			CoinSource:       &CoinSource{l},
+			CoinSource:       newAccountCoinSource(l, account),
 			CoinSelectLocker: l,
 			CoinLocker:       l,
 			Signer:           l.Cfg.Signer,
 			DustLimit:        DustLimitForSize(input.P2WSHSize),
+
+			CoinSelectionStrategy: coinSelectionStrategy,
 		}
 		req.ChanFunder = chanfunding.NewWalletAssembler(cfg)
 	} else {
@@ -752,18 +802,39 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	// funder in the attached request to provision the inputs/outputs
 	// that'll ultimately be used to construct the funding transaction.
 	if !ok {
+		// When funding up to a maximum amount, we need to leave enough
+		// in the wallet to cover the reserved value of our anchor
+		// channels, including the one being opened.
+		var walletReserve btcutil.Amount
+		if req.FundUpToMaxAmt > 0 {
+			isPublic := req.Flags&lnwire.FFAnnounceChannel != 0
+			hasAnchors := req.CommitType.HasAnchors()
+
+			walletReserve, err = l.requiredReserve(
+				isPublic, hasAnchors,
+			)
+			if err != nil {
+				req.err <- err
+				req.resp <- nil
+				return
+			}
+		}
+
 		// Coin selection is done on the basis of sat/kw, so we'll use
 		// the fee rate passed in to perform coin selection.
-		var err error
 		fundingReq := &chanfunding.Request{
-			RemoteAmt:    req.RemoteFundingAmt,
-			LocalAmt:     req.LocalFundingAmt,
-			MinConfs:     req.MinConfs,
-			SubtractFees: req.SubtractFees,
-			FeeRate:      req.FundingFeePerKw,
+			RemoteAmt:      req.RemoteFundingAmt,
+			LocalAmt:       req.LocalFundingAmt,
+			FundUpToMaxAmt: req.FundUpToMaxAmt,
+			MinFundAmt:     req.MinFundAmt,
+			WalletReserve:  walletReserve,
+			Outpoints:      req.Outpoints,
+			MinConfs:       req.MinConfs,
+			SubtractFees:   req.SubtractFees,
+			FeeRate:        req.FundingFeePerKw,
 			ChangeAddr: func() (btcutil.Address, error) {
 				return l.NewAddress(
-					WitnessPubKey, true, DefaultAccountName,
+					WitnessPubKey, true, account,
 				)
 			},
 		}
@@ -919,6 +990,42 @@ func (l *LightningWallet) enforceNewReservedValue(fundingIntent chanfunding.Inte
 	})
 }
 
+// requiredReserve returns the value that must be left in the wallet to fee
+// bump our anchor channels once a channel with the given properties has been
+// opened.
+func (l *LightningWallet) requiredReserve(isPublic,
+	hasAnchors bool) (btcutil.Amount, error) {
+
+	// Private channels are not counted, so opening one doesn't require a
+	// larger reserve.
+	if !isPublic {
+		return 0, nil
+	}
+
+	numAnchors, err := l.currentNumAnchorChans()
+	if err != nil {
+		return 0, err
+	}
+
+	// Add the to-be-opened channel.
+	if hasAnchors {
+		numAnchors++
+	}
+
+	return anchorReservedValue(numAnchors), nil
+}
+
+// anchorReservedValue returns the value we keep in the wallet to be able to
+// fee bump the given number of anchor channels.
+func anchorReservedValue(numAnchorChans int) btcutil.Amount {
+	reserved := btcutil.Amount(numAnchorChans) * anchorChanReservedValue
+	if reserved > maxAnchorChanReservedValue {
+		reserved = maxAnchorChanReservedValue
+	}
+
+	return reserved
+}
+
 // currentNumAnchorChans returns the current number of non-private anchor
 // channels the wallet should be ready to fee bump if needed.
 func (l *LightningWallet) currentNumAnchorChans() (int, error) {
@@ -976,7 +1083,7 @@ func (l *LightningWallet) currentNumAnchorChans() (int, error) {
 // CheckReservedValue checks whether publishing a transaction with the given
 // inputs and outputs would violate the value we reserve in the wallet for
 // bumping the fee of anchor channels. The numAnchorChans argument should be
-// set the the number of open anchor channels controlled by the wallet after
+// set to the number of open anchor channels controlled by the wallet after
 // the transaction has been published.
 //
 // If the reserved value is violated, the returned error will be
@@ -1046,10 +1153,7 @@ func (l *LightningWallet) CheckReservedValue(in []wire.OutPoint,
 	}
 
 	// We reserve a given amount for each anchor channel.
-	reserved := btcutil.Amount(numAnchorChans) * anchorChanReservedValue
-	if reserved > maxAnchorChanReservedValue {
-		reserved = maxAnchorChanReservedValue
-	}
+	reserved := anchorReservedValue(numAnchorChans)
 
 	if walletBalance < reserved {
 		walletLog.Debugf("Reserved value=%v above final "+
@@ -2168,11 +2272,22 @@ func (l *LightningWallet) ValidateChannel(channelState *channeldb.OpenChannel,
 // chanfunding.CoinSource interface.
 type CoinSource struct {
 	wallet *LightningWallet
+
+	// account is the name of the wallet account the coins are listed
+	// from.
+	account string
 }
 
-// NewCoinSource creates a new instance of the CoinSource wrapper struct.
+// NewCoinSource creates a new instance of the CoinSource wrapper struct that
+// lists the coins of the default wallet account.
 func NewCoinSource(w *LightningWallet) *CoinSource {
-	return &CoinSource{wallet: w}
+	return newAccountCoinSource(w, DefaultAccountName)
+}
+
+// newAccountCoinSource creates a new instance of the CoinSource wrapper struct
+// that lists the coins of the given wallet account.
+func newAccountCoinSource(w *LightningWallet, account string) *CoinSource {
+	return &CoinSource{wallet: w, account: account}
 }
 
 // ListCoins returns all UTXOs from the source that have between
@@ -2180,8 +2295,8 @@ func NewCoinSource(w *LightningWallet) *CoinSource {
 func (c *CoinSource) ListCoins(minConfs int32,
 	maxConfs int32) ([]chanfunding.Coin, error) {
 
-	utxos, err := c.wallet.ListUnspentWitnessFromDefaultAccount(
-		minConfs, maxConfs,
+	utxos, err := c.wallet.ListUnspentWitness(
+		minConfs, maxConfs, c.account,
 	)
 	if err != nil {
 		return nil, err
@@ -155,6 +155,17 @@ const (
 	// TODO: Decide on actual feature bit value.
 	ExplicitChannelTypeOptional = 45
 
+	// ScidAliasRequired is a required feature bit that signals that the
+	// node requires understanding of ShortChannelID aliases in the
+	// FundingLocked message, and won't reveal the real short channel id
+	// of unannounced channels.
+	ScidAliasRequired FeatureBit = 46
+
+	// ScidAliasOptional is an optional feature bit that signals that the
+	// node understands ShortChannelID aliases in the FundingLocked
+	// message.
+	ScidAliasOptional FeatureBit = 47
+
 	// ScriptEnforcedLeaseOptional is an optional feature bit that signals
 	// that the node requires channels having zero-fee second-level HTLC
 	// transactions, which also imply anchor commitments, along with an
@@ -220,6 +231,8 @@ var Features = map[FeatureBit]string{
 	AMPOptional:                   "amp",
 	ExplicitChannelTypeOptional:   "explicit-commitment-type",
 	ExplicitChannelTypeRequired:   "explicit-commitment-type",
+	ScidAliasRequired:             "scid-alias",
+	ScidAliasOptional:             "scid-alias",
 	ScriptEnforcedLeaseRequired:   "script-enforced-lease",
 	ScriptEnforcedLeaseOptional:   "script-enforced-lease",
 }
@@ -21,6 +21,12 @@ type FundingLocked struct {
 	// next commitment transaction for the channel.
 	NextPerCommitmentPoint *btcec.PublicKey
 
+	// AliasScid is an alias ShortChannelID for this channel. If set, the
+	// sender will recognize it as referring to the channel when
+	// forwarding HTLCs, and it should be used in place of the real short
+	// channel id in route hints.
+	AliasScid *ShortChannelID
+
 	// ExtraData is the set of data that was appended to this message to
 	// fill out the full maximum transport message size. These fields can
 	// be used to specify optional data such as custom TLV fields.
@@ -47,11 +53,33 @@ var _ Message = (*FundingLocked)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *FundingLocked) Decode(r io.Reader, pver uint32) error {
-	return ReadElements(r,
+	// Read all the mandatory fields in the message.
+	var tlvRecords ExtraOpaqueData
+	err := ReadElements(r,
 		&c.ChanID,
 		&c.NextPerCommitmentPoint,
-		&c.ExtraData,
+		&tlvRecords,
 	)
+	if err != nil {
+		return err
+	}
+
+	// Next we'll parse out the set of known records, keeping the raw tlv
+	// bytes untouched to ensure we don't drop any bytes erroneously.
+	var aliasScid ShortChannelID
+	typeMap, err := tlvRecords.ExtractRecords(&aliasScid)
+	if err != nil {
+		return err
+	}
+
+	// Set the corresponding TLV types if they were included in the stream.
+	if val, ok := typeMap[AliasScidRecordType]; ok && val == nil {
+		c.AliasScid = &aliasScid
+	}
+
+	c.ExtraData = tlvRecords
+
+	return nil
 }
 
 // Encode serializes the target FundingLocked message into the passed io.Writer
@@ -68,6 +96,14 @@ func (c *FundingLocked) Encode(w *bytes.Buffer, pver uint32) error {
 		return err
 	}
 
+	// We'll only encode the AliasScid in a TLV segment if it exists.
+	if c.AliasScid != nil {
+		err := EncodeMessageExtraData(&c.ExtraData, c.AliasScid)
+		if err != nil {
+			return err
+		}
+	}
+
 	return WriteBytes(w, c.ExtraData)
 }
 
@@ -519,6 +519,12 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			req := NewFundingLocked(ChannelID(c), pubKey)
 
+			// 1/2 chance of including an alias.
+			if r.Intn(2) == 0 {
+				alias := NewShortChanIDFromInt(uint64(r.Int63()))
+				req.AliasScid = &alias
+			}
+
 			v[0] = reflect.ValueOf(*req)
 		},
 		MsgClosingSigned: func(v []reflect.Value, r *rand.Rand) {
@@ -438,7 +438,10 @@ func newMsgFundingLocked(t testing.TB, r io.Reader) *lnwire.FundingLocked {
 
 	pubKey := randPubKey(t)
 
+	alias := lnwire.NewShortChanIDFromInt(16_000_000 << 40)
+
 	msg := lnwire.NewFundingLocked(lnwire.ChannelID(c), pubKey)
+	msg.AliasScid = &alias
 	msg.ExtraData = createExtraData(t, r)
 
 	return msg
@@ -333,6 +333,10 @@ type Config struct {
 	// from the peer.
 	HandleCustomMessage func(peer [33]byte, msg *lnwire.Custom) error
 
+	// GetAliases returns the aliases that we assigned to the channel with
+	// the passed confirmed short channel id, if any.
+	GetAliases func(base lnwire.ShortChannelID) []lnwire.ShortChannelID
+
 	// PongBuf is a slice we'll reuse instead of allocating memory on the
 	// heap. Since only reads will occur and no writes, there is no need
 	// for any synchronization primitives. As a result, it's safe to share
@@ -640,6 +644,18 @@ func (p *Brontide) initGossipSync() {
 		// bootstrapper to ensure we can find and connect to non-channel
 		// peers.
 		p.cfg.AuthGossiper.InitSyncState(p)
+
+		return
+	}
+
+	// Otherwise, if the peer requested an initial routing dump, we'll
+	// send it our entire view of the graph, as a legacy peer has no other
+	// way to bootstrap it.
+	if p.remoteFeatures.HasFeature(lnwire.InitialRoutingSync) {
+		peerLog.Infof("Sending initial routing dump to %x",
+			p.cfg.PubKeyBytes[:])
+
+		p.cfg.AuthGossiper.SendInitialRoutingDump(p)
 	}
 }
 
@@ -874,6 +890,7 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 		NotifyActiveChannel:     p.cfg.ChannelNotifier.NotifyActiveChannelEvent,
 		NotifyInactiveChannel:   p.cfg.ChannelNotifier.NotifyInactiveChannelEvent,
 		HtlcNotifier:            p.cfg.HtlcNotifier,
+		GetAliases:              p.cfg.GetAliases,
 	}
 
 	// Before adding our new link, purge the switch of any pending or live
@@ -2602,7 +2619,8 @@ func (p *Brontide) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 				Disconnect: func() error {
 					return p.cfg.DisconnectPeer(p.IdentityKey())
 				},
-				Quit: p.quit,
+				MaxFee: req.MaxFee,
+				Quit:   p.quit,
 			},
 			deliveryScript,
 			req.TargetFeePerKw,
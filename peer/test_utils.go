@@ -368,7 +368,7 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 		ChanActiveTimeout: chanActiveTimeout,
 		InterceptSwitch: htlcswitch.NewInterceptableSwitch(
-			nil, false,
+			&htlcswitch.InterceptableSwitchConfig{},
 		),
 
 		ChannelDB:      dbAlice.ChannelStateDB(),
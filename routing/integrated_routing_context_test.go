@@ -82,8 +82,9 @@ func newIntegratedRoutingContext(t *testing.T) *integratedRoutingContext {
 		},
 
 		pathFindingCfg: PathFindingConfig{
-			AttemptCost:    1000,
-			MinProbability: 0.01,
+			AttemptCost:          1000,
+			MinProbability:       0.01,
+			RiskFactorBillionths: DefaultRiskFactorBillionths,
 		},
 
 		source: source,
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -61,6 +62,20 @@ const (
 	// have passed since the previously recorded failure before the failure
 	// amount may be raised.
 	DefaultMinFailureRelaxInterval = time.Minute
+
+	// AprioriEstimatorName is the name of the apriori probability
+	// estimator, which estimates probabilities from a fixed apriori hop
+	// probability and the time since the last failure.
+	AprioriEstimatorName = "apriori"
+
+	// BimodalEstimatorName is the name of the bimodal probability
+	// estimator, which models channel liquidity as being concentrated at
+	// either end of a channel.
+	BimodalEstimatorName = "bimodal"
+
+	// DefaultEstimator is the probability estimator that is used if none
+	// is configured.
+	DefaultEstimator = AprioriEstimatorName
 )
 
 var (
@@ -72,6 +87,10 @@ var (
 	// ErrInvalidFailureInterval is returned if we get an invalid failure
 	// interval.
 	ErrInvalidFailureInterval = errors.New("failure interval must be >= 0")
+
+	// ErrUnknownEstimator is returned if we get an unknown probability
+	// estimator name.
+	ErrUnknownEstimator = errors.New("unknown probability estimator")
 )
 
 // NodeResults contains previous results from a node to its peers.
@@ -102,7 +121,21 @@ type MissionControl struct {
 
 	// estimator is the probability estimator that is used with the payment
 	// results that mission control collects.
-	estimator *probabilityEstimator
+	estimator estimator
+
+	// estimatorName is the name of the estimator that is currently in
+	// use.
+	estimatorName string
+
+	// aprioriCfg is the configuration of the apriori estimator. It is
+	// kept even if another estimator is active so that it can be reported
+	// and switched back to.
+	aprioriCfg ProbabilityEstimatorCfg
+
+	// bimodalCfg is the configuration of the bimodal estimator. It is
+	// kept even if another estimator is active so that it can be reported
+	// and switched back to.
+	bimodalCfg BimodalEstimatorCfg
 
 	sync.Mutex
 
@@ -115,10 +148,19 @@ type MissionControl struct {
 // MissionControlConfig defines parameters that control mission control
 // behaviour.
 type MissionControlConfig struct {
+	// Estimator is the name of the probability estimator that is used for
+	// pathfinding. It is either AprioriEstimatorName or
+	// BimodalEstimatorName. If empty, DefaultEstimator is used.
+	Estimator string
+
 	// ProbabilityEstimatorConfig is the config we will use for probability
-	// calculations.
+	// calculations with the apriori estimator.
 	ProbabilityEstimatorCfg
 
+	// BimodalEstimatorCfg is the config we will use for probability
+	// calculations with the bimodal estimator.
+	BimodalEstimatorCfg
+
 	// MaxMcHistory defines the maximum number of payment results that are
 	// held on disk.
 	MaxMcHistory int
@@ -134,8 +176,35 @@ type MissionControlConfig struct {
 }
 
 func (c *MissionControlConfig) validate() error {
-	if err := c.ProbabilityEstimatorCfg.validate(); err != nil {
-		return err
+	// The config of the selected estimator must be valid. The config of
+	// the other estimator is kept around for when it's selected later on,
+	// so we reject invalid values for it too. Only an unset bimodal config
+	// is accepted while the apriori estimator is selected, as its zero
+	// values aren't valid.
+	switch c.estimatorName() {
+	case AprioriEstimatorName:
+		if err := c.ProbabilityEstimatorCfg.validate(); err != nil {
+			return err
+		}
+
+		if c.BimodalEstimatorCfg != (BimodalEstimatorCfg{}) {
+			err := c.BimodalEstimatorCfg.validate()
+			if err != nil {
+				return err
+			}
+		}
+
+	case BimodalEstimatorName:
+		if err := c.BimodalEstimatorCfg.validate(); err != nil {
+			return err
+		}
+
+		if err := c.ProbabilityEstimatorCfg.validate(); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownEstimator, c.Estimator)
 	}
 
 	if c.MaxMcHistory < 0 {
@@ -149,12 +218,46 @@ func (c *MissionControlConfig) validate() error {
 	return nil
 }
 
+// estimatorName returns the name of the configured estimator, falling back to
+// the default if none is set.
+func (c *MissionControlConfig) estimatorName() string {
+	if c.Estimator == "" {
+		return DefaultEstimator
+	}
+
+	return c.Estimator
+}
+
+// newEstimator creates the probability estimator that is selected by the
+// config.
+func (c *MissionControlConfig) newEstimator() (estimator, error) {
+	switch c.estimatorName() {
+	case AprioriEstimatorName:
+		return &probabilityEstimator{
+			ProbabilityEstimatorCfg: c.ProbabilityEstimatorCfg,
+			prevSuccessProbability:  prevSuccessProbability,
+		}, nil
+
+	case BimodalEstimatorName:
+		return &bimodalEstimator{
+			BimodalEstimatorCfg: c.BimodalEstimatorCfg,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnknownEstimator,
+			c.Estimator)
+	}
+}
+
 // String returns a string representation of a mission control config.
 func (c *MissionControlConfig) String() string {
-	return fmt.Sprintf("Penalty Half Life: %v, Apriori Hop "+
-		"Probablity: %v, Maximum History: %v, Apriori Weight: %v, "+
-		"Minimum Failure Relax Interval: %v", c.PenaltyHalfLife,
-		c.AprioriHopProbability, c.MaxMcHistory, c.AprioriWeight,
+	return fmt.Sprintf("Estimator: %v, Penalty Half Life: %v, Apriori "+
+		"Hop Probablity: %v, Maximum History: %v, Apriori Weight: %v, "+
+		"Bimodal Scale: %v, Bimodal Node Weight: %v, Bimodal Decay "+
+		"Time: %v, Minimum Failure Relax Interval: %v",
+		c.estimatorName(), c.PenaltyHalfLife, c.AprioriHopProbability,
+		c.MaxMcHistory, c.AprioriWeight, c.BimodalScaleMsat,
+		c.BimodalNodeWeight, c.BimodalDecayTime,
 		c.MinFailureRelaxInterval)
 }
 
@@ -224,17 +327,20 @@ func NewMissionControl(db kvdb.Backend, self route.Vertex,
 		return nil, err
 	}
 
-	estimator := &probabilityEstimator{
-		ProbabilityEstimatorCfg: cfg.ProbabilityEstimatorCfg,
-		prevSuccessProbability:  prevSuccessProbability,
+	estimator, err := cfg.newEstimator()
+	if err != nil {
+		return nil, err
 	}
 
 	mc := &MissionControl{
-		state:     newMissionControlState(cfg.MinFailureRelaxInterval),
-		now:       time.Now,
-		selfNode:  self,
-		store:     store,
-		estimator: estimator,
+		state:         newMissionControlState(cfg.MinFailureRelaxInterval),
+		now:           time.Now,
+		selfNode:      self,
+		store:         store,
+		estimator:     estimator,
+		estimatorName: cfg.estimatorName(),
+		aprioriCfg:    cfg.ProbabilityEstimatorCfg,
+		bimodalCfg:    cfg.BimodalEstimatorCfg,
 	}
 
 	if err := mc.init(); err != nil {
@@ -283,7 +389,9 @@ func (m *MissionControl) GetConfig() *MissionControlConfig {
 	defer m.Unlock()
 
 	return &MissionControlConfig{
-		ProbabilityEstimatorCfg: m.estimator.ProbabilityEstimatorCfg,
+		Estimator:               m.estimatorName,
+		ProbabilityEstimatorCfg: m.aprioriCfg,
+		BimodalEstimatorCfg:     m.bimodalCfg,
 		MaxMcHistory:            m.store.maxRecords,
 		McFlushInterval:         m.store.flushInterval,
 		MinFailureRelaxInterval: m.state.minFailureRelaxInterval,
@@ -301,6 +409,11 @@ func (m *MissionControl) SetConfig(cfg *MissionControlConfig) error {
 		return err
 	}
 
+	estimator, err := cfg.newEstimator()
+	if err != nil {
+		return err
+	}
+
 	m.Lock()
 	defer m.Unlock()
 
@@ -308,7 +421,10 @@ func (m *MissionControl) SetConfig(cfg *MissionControlConfig) error {
 
 	m.store.maxRecords = cfg.MaxMcHistory
 	m.state.minFailureRelaxInterval = cfg.MinFailureRelaxInterval
-	m.estimator.ProbabilityEstimatorCfg = cfg.ProbabilityEstimatorCfg
+	m.estimator = estimator
+	m.estimatorName = cfg.estimatorName()
+	m.aprioriCfg = cfg.ProbabilityEstimatorCfg
+	m.bimodalCfg = cfg.BimodalEstimatorCfg
 
 	return nil
 }
@@ -331,9 +447,9 @@ func (m *MissionControl) ResetHistory() error {
 }
 
 // GetProbability is expected to return the success probability of a payment
-// from fromNode along edge.
+// from fromNode along edge. The capacity of the edge is zero if it's unknown.
 func (m *MissionControl) GetProbability(fromNode, toNode route.Vertex,
-	amt lnwire.MilliSatoshi) float64 {
+	amt lnwire.MilliSatoshi, capacity btcutil.Amount) float64 {
 
 	m.Lock()
 	defer m.Unlock()
@@ -346,7 +462,9 @@ func (m *MissionControl) GetProbability(fromNode, toNode route.Vertex,
 		return m.estimator.getLocalPairProbability(now, results, toNode)
 	}
 
-	return m.estimator.getPairProbability(now, results, toNode, amt)
+	return m.estimator.getPairProbability(
+		now, results, toNode, amt, capacity,
+	)
 }
 
 // GetHistorySnapshot takes a snapshot from the current mission control state
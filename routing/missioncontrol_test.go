@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
@@ -38,6 +39,7 @@ var (
 	testPenaltyHalfLife       = 30 * time.Minute
 	testAprioriHopProbability = 0.9
 	testAprioriWeight         = 0.5
+	testCapacity              = btcutil.Amount(100_000)
 )
 
 type mcTestContext struct {
@@ -112,7 +114,9 @@ func (ctx *mcTestContext) cleanup() {
 func (ctx *mcTestContext) expectP(amt lnwire.MilliSatoshi, expected float64) {
 	ctx.t.Helper()
 
-	p := ctx.mc.GetProbability(mcTestNode1, mcTestNode2, amt)
+	p := ctx.mc.GetProbability(
+		mcTestNode1, mcTestNode2, amt, testCapacity,
+	)
 	if p != expected {
 		ctx.t.Fatalf("expected probability %v but got %v", expected, p)
 	}
@@ -151,7 +155,9 @@ func TestMissionControl(t *testing.T) {
 
 	// For local channels, we expect a higher probability than our a prior
 	// test probability.
-	selfP := ctx.mc.GetProbability(mcTestSelf, mcTestNode1, 100)
+	selfP := ctx.mc.GetProbability(
+		mcTestSelf, mcTestNode1, 100, testCapacity,
+	)
 	if selfP != prevSuccessProbability {
 		t.Fatalf("expected prev success prob for untried local chans")
 	}
@@ -223,3 +229,61 @@ func TestMissionControlChannelUpdate(t *testing.T) {
 	)
 	ctx.expectP(100, 0)
 }
+
+// TestMissionControlEstimatorSelection tests that the probability estimator
+// can be selected via the config and switched at runtime.
+func TestMissionControlEstimatorSelection(t *testing.T) {
+	ctx := createMcTestContext(t)
+	defer ctx.cleanup()
+
+	// The apriori estimator is used by default.
+	cfg := ctx.mc.GetConfig()
+	require.Equal(t, AprioriEstimatorName, cfg.Estimator)
+	require.IsType(t, &probabilityEstimator{}, ctx.mc.estimator)
+
+	// An unknown estimator is rejected.
+	cfg.Estimator = "unknown"
+	require.ErrorIs(t, ctx.mc.SetConfig(cfg), ErrUnknownEstimator)
+
+	// An invalid bimodal config is rejected when selecting the bimodal
+	// estimator.
+	cfg.Estimator = BimodalEstimatorName
+	require.ErrorIs(t, ctx.mc.SetConfig(cfg), ErrInvalidDecayTime)
+
+	// Switch to the bimodal estimator.
+	cfg.BimodalEstimatorCfg = BimodalEstimatorCfg{
+		BimodalScaleMsat:  DefaultBimodalScaleMsat,
+		BimodalNodeWeight: DefaultBimodalNodeWeight,
+		BimodalDecayTime:  DefaultBimodalDecayTime,
+	}
+	require.NoError(t, ctx.mc.SetConfig(cfg))
+	require.IsType(t, &bimodalEstimator{}, ctx.mc.estimator)
+	require.Equal(t, cfg, ctx.mc.GetConfig())
+
+	// The apriori config is still validated while the bimodal estimator
+	// is selected.
+	invalidCfg := *cfg
+	invalidCfg.AprioriHopProbability = 2
+	require.ErrorIs(
+		t, ctx.mc.SetConfig(&invalidCfg), ErrInvalidHopProbability,
+	)
+
+	// And vice versa.
+	invalidCfg = *cfg
+	invalidCfg.Estimator = AprioriEstimatorName
+	invalidCfg.BimodalNodeWeight = 2
+	require.ErrorIs(
+		t, ctx.mc.SetConfig(&invalidCfg), ErrInvalidNodeWeight,
+	)
+	require.Equal(t, cfg, ctx.mc.GetConfig())
+
+	// Without any history, a payment of half the capacity has a success
+	// probability of one half because the liquidity distribution is
+	// symmetric.
+	p := ctx.mc.GetProbability(
+		mcTestNode1, mcTestNode2, lnwire.NewMSatFromSatoshis(
+			testCapacity/2,
+		), testCapacity,
+	)
+	require.InDelta(t, 0.5, p, 0.01)
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
@@ -297,6 +298,10 @@ type ChannelEdgeUpdate struct {
 	// Disabled, if true, signals that the channel is unavailable to relay
 	// payments.
 	Disabled bool
+
+	// LastUpdate is the timestamp of the channel update that this edge
+	// update was created from.
+	LastUpdate time.Time
 }
 
 // appendTopologyChange appends the passed update message to the passed
@@ -375,6 +380,7 @@ func addToTopologyChange(graph *channeldb.ChannelGraph, update *TopologyChange,
 			AdvertisingNode: aNode,
 			ConnectingNode:  cNode,
 			Disabled:        m.ChannelFlags&lnwire.ChanUpdateDisabled != 0,
+			LastUpdate:      m.LastUpdate,
 		}
 
 		// TODO(roasbeef): add bit to toggle
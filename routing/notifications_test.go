@@ -504,6 +504,11 @@ func TestEdgeUpdateNotification(t *testing.T) {
 				"expected %v, got %v", edgeAnn.TimeLockDelta,
 				edgeUpdate.TimeLockDelta)
 		}
+		if !edgeUpdate.LastUpdate.Equal(edgeAnn.LastUpdate) {
+			t.Fatalf("last update of edge doesn't match: "+
+				"expected %v, got %v", edgeAnn.LastUpdate,
+				edgeUpdate.LastUpdate)
+		}
 	}
 
 	// Create lookup map for notifications we are intending to receive. Entries
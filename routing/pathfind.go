@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
 	sphinx "github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/feature"
@@ -19,8 +20,8 @@ const (
 	// infinity is used as a starting distance in our shortest path search.
 	infinity = math.MaxInt64
 
-	// RiskFactorBillionths controls the influence of time lock delta
-	// of a channel on route selection. It is expressed as billionths
+	// DefaultRiskFactorBillionths is the default influence of time lock
+	// delta of a channel on route selection. It is expressed as billionths
 	// of msat per msat sent through the channel per time lock delta
 	// block. See edgeWeight function below for more details.
 	// The chosen value is based on the previous incorrect weight function
@@ -28,9 +29,8 @@ const (
 	// diminishes the time lock penalty for all but the smallest amounts.
 	// To not change the behaviour of path finding too drastically, a
 	// relatively small value is chosen which is still big enough to give
-	// some effect with smaller time lock values. The value may need
-	// tweaking and/or be made configurable in the future.
-	RiskFactorBillionths = 15
+	// some effect with smaller time lock values.
+	DefaultRiskFactorBillionths = 15
 
 	// estimatedNodeCount is used to preallocate the path finding structures
 	// to avoid resizing and copies. It should be number on the same order as
@@ -258,16 +258,15 @@ func newRoute(sourceVertex route.Vertex,
 // for the shortest path within the channel graph between two nodes. Weight is
 // is the fee itself plus a time lock penalty added to it. This benefits
 // channels with shorter time lock deltas and shorter (hops) routes in general.
-// RiskFactor controls the influence of time lock on route selection. This is
-// currently a fixed value, but might be configurable in the future.
+// The risk factor controls the influence of time lock on route selection.
 func edgeWeight(lockedAmt lnwire.MilliSatoshi, fee lnwire.MilliSatoshi,
-	timeLockDelta uint16) int64 {
+	timeLockDelta uint16, riskFactorBillionths int64) int64 {
 	// timeLockPenalty is the penalty for the time lock delta of this channel.
-	// It is controlled by RiskFactorBillionths and scales proportional
+	// It is controlled by riskFactorBillionths and scales proportional
 	// to the amount that will pass through channel. Rationale is that it if
 	// a twice as large amount gets locked up, it is twice as bad.
 	timeLockPenalty := int64(lockedAmt) * int64(timeLockDelta) *
-		RiskFactorBillionths / 1000000000
+		riskFactorBillionths / 1000000000
 
 	return int64(fee) + timeLockPenalty
 }
@@ -296,9 +295,10 @@ type graphParams struct {
 // found path must adhere to.
 type RestrictParams struct {
 	// ProbabilitySource is a callback that is expected to return the
-	// success probability of traversing the channel from the node.
+	// success probability of traversing the channel from the node. The
+	// capacity of the channel is zero if it's unknown.
 	ProbabilitySource func(route.Vertex, route.Vertex,
-		lnwire.MilliSatoshi) float64
+		lnwire.MilliSatoshi, btcutil.Amount) float64
 
 	// FeeLimit is a maximum fee amount allowed to be used on the path from
 	// the source to the target.
@@ -349,6 +349,12 @@ type PathFindingConfig struct {
 	// MinProbability defines the minimum success probability of the
 	// returned route.
 	MinProbability float64
+
+	// RiskFactorBillionths controls the influence of the time lock delta
+	// of a channel on route selection. It is expressed as billionths of
+	// msat per msat sent through the channel per time lock delta block. A
+	// value of zero makes path finding ignore time locks altogether.
+	RiskFactorBillionths int64
 }
 
 // getOutgoingBalance returns the maximum available balance in any of the
@@ -585,7 +591,8 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 	// satisfy our specific requirements.
 	processEdge := func(fromVertex route.Vertex,
 		fromFeatures *lnwire.FeatureVector,
-		edge *channeldb.CachedEdgePolicy, toNodeDist *nodeWithDist) {
+		edge *channeldb.CachedEdgePolicy, capacity btcutil.Amount,
+		toNodeDist *nodeWithDist) {
 
 		edgesExpanded++
 
@@ -595,7 +602,7 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 
 		// Request the success probability for this edge.
 		edgeProbability := r.ProbabilitySource(
-			fromVertex, toNodeDist.node, amountToSend,
+			fromVertex, toNodeDist.node, amountToSend, capacity,
 		)
 
 		log.Trace(newLogClosure(func() string {
@@ -664,7 +671,10 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 		// weight composed of the fee that this node will charge and
 		// the amount that will be locked for timeLockDelta blocks in
 		// the HTLC that is handed out to fromVertex.
-		weight := edgeWeight(amountToReceive, fee, timeLockDelta)
+		weight := edgeWeight(
+			amountToReceive, fee, timeLockDelta,
+			cfg.RiskFactorBillionths,
+		)
 
 		// Compute the tentative weight to this new channel/edge
 		// which is the weight from our toNode to the target node
@@ -860,7 +870,10 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 
 			// Check if this candidate node is better than what we
 			// already have.
-			processEdge(fromNode, fromFeatures, policy, partialPath)
+			processEdge(
+				fromNode, fromFeatures, policy,
+				unifiedPolicy.maxCapacity(), partialPath,
+			)
 		}
 
 		if nodeHeap.Len() == 0 {
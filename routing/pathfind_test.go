@@ -54,7 +54,9 @@ var (
 		CltvLimit:         math.MaxUint32,
 	}
 
-	testPathFindingConfig = &PathFindingConfig{}
+	testPathFindingConfig = &PathFindingConfig{
+		RiskFactorBillionths: DefaultRiskFactorBillionths,
+	}
 
 	tlvFeatures = lnwire.NewFeatureVector(
 		lnwire.NewRawFeatureVector(
@@ -105,7 +107,9 @@ var (
 
 // noProbabilitySource is used in testing to return the same probability 1 for
 // all edges.
-func noProbabilitySource(route.Vertex, route.Vertex, lnwire.MilliSatoshi) float64 {
+func noProbabilitySource(route.Vertex, route.Vertex, lnwire.MilliSatoshi,
+	btcutil.Amount) float64 {
+
 	return 1
 }
 
@@ -834,6 +838,9 @@ func TestPathFinding(t *testing.T) {
 	}, {
 		name: "equal cost route selection",
 		fn:   runEqualCostRouteSelection,
+	}, {
+		name: "time lock risk factor",
+		fn:   runRiskFactor,
 	}, {
 		name: "no cycle",
 		fn:   runNoCycle,
@@ -2810,7 +2817,7 @@ func testProbabilityRouting(t *testing.T, useCache bool,
 
 	// Configure a probability source with the test parameters.
 	ctx.restrictParams.ProbabilitySource = func(fromNode, toNode route.Vertex,
-		amt lnwire.MilliSatoshi) float64 {
+		amt lnwire.MilliSatoshi, _ btcutil.Amount) float64 {
 
 		if amt == 0 {
 			t.Fatal("expected non-zero amount")
@@ -2855,6 +2862,68 @@ func testProbabilityRouting(t *testing.T, useCache bool,
 	}
 }
 
+// runRiskFactor asserts that the configured risk factor controls the trade-off
+// between fees and time locks during path finding.
+func runRiskFactor(t *testing.T, useCache bool) {
+	// Set up a test graph with two paths to the target. The path through a
+	// has a low time lock but higher fees, the path through b is cheaper
+	// but locks up funds for much longer.
+	testChannels := []*testChannel{
+		symmetricTestChannel("roasbeef", "a", 1000000, &testChannelPolicy{
+			Expiry:  144,
+			MinHTLC: 1,
+		}, 1),
+		symmetricTestChannel("a", "target", 1000000, &testChannelPolicy{
+			Expiry:      144,
+			FeeBaseMsat: lnwire.NewMSatFromSatoshis(10),
+			MinHTLC:     1,
+		}, 2),
+		symmetricTestChannel("roasbeef", "b", 1000000, &testChannelPolicy{
+			Expiry:  144,
+			MinHTLC: 1,
+		}, 3),
+		symmetricTestChannel("b", "target", 1000000, &testChannelPolicy{
+			Expiry:      1000,
+			FeeBaseMsat: lnwire.NewMSatFromSatoshis(5),
+			MinHTLC:     1,
+		}, 4),
+	}
+
+	ctx := newPathFindingTestContext(t, useCache, testChannels, "roasbeef")
+	defer ctx.cleanup()
+
+	target := ctx.keyFromAlias("target")
+	paymentAmt := lnwire.NewMSatFromSatoshis(100000)
+
+	testCases := []struct {
+		riskFactor   int64
+		expectedPath []uint64
+	}{
+		// Without a risk factor only fees matter.
+		{riskFactor: 0, expectedPath: []uint64{3, 4}},
+
+		// The default risk factor isn't large enough to outweigh the
+		// fee difference.
+		{
+			riskFactor:   DefaultRiskFactorBillionths,
+			expectedPath: []uint64{3, 4},
+		},
+
+		// A large risk factor makes the lower time lock path win.
+		{riskFactor: 1000000, expectedPath: []uint64{1, 2}},
+	}
+
+	for _, test := range testCases {
+		ctx.pathFindingConfig.RiskFactorBillionths = test.riskFactor
+
+		path, err := ctx.findPath(target, paymentAmt)
+		if err != nil {
+			t.Fatalf("unable to find path: %v", err)
+		}
+		ctx.assertPath(path, test.expectedPath)
+	}
+}
+
 // runEqualCostRouteSelection asserts that route probability will be used as a
 // tie breaker in case the path finding probabilities are equal.
 func runEqualCostRouteSelection(t *testing.T, useCache bool) {
@@ -2891,7 +2960,7 @@ func runEqualCostRouteSelection(t *testing.T, useCache bool) {
 	target := ctx.testGraphInstance.aliasMap["target"]
 
 	ctx.restrictParams.ProbabilitySource = func(fromNode, toNode route.Vertex,
-		amt lnwire.MilliSatoshi) float64 {
+		amt lnwire.MilliSatoshi, _ btcutil.Amount) float64 {
 
 		switch {
 		case fromNode == alias["source"] && toNode == alias["a"]:
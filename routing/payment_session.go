@@ -72,6 +72,51 @@ var (
 	DefaultShardMinAmt = lnwire.NewMSatFromSatoshis(10000)
 )
 
+// SplitStrategy determines how the payment session picks the amount of the
+// next shard when no route could be found for the current one.
+type SplitStrategy uint8
+
+const (
+	// SplitStrategyHalving halves the shard amount on every unsuccessful
+	// path finding attempt.
+	SplitStrategyHalving SplitStrategy = iota
+
+	// SplitStrategyLiquidity first clamps the shard amount to the largest
+	// balance that is available in one of our outgoing channels. Only when
+	// the shard already fits into our local liquidity and path finding
+	// still fails, the amount is halved.
+	SplitStrategyLiquidity
+)
+
+// String returns a human readable representation of the split strategy.
+func (s SplitStrategy) String() string {
+	switch s {
+	case SplitStrategyHalving:
+		return "halving"
+
+	case SplitStrategyLiquidity:
+		return "liquidity"
+
+	default:
+		return fmt.Sprintf("unknown<%d>", uint8(s))
+	}
+}
+
+// ParseSplitStrategy parses the string representation of a split strategy as
+// used in the config.
+func ParseSplitStrategy(strategy string) (SplitStrategy, error) {
+	switch strategy {
+	case "halving":
+		return SplitStrategyHalving, nil
+
+	case "liquidity":
+		return SplitStrategyLiquidity, nil
+
+	default:
+		return 0, fmt.Errorf("unknown split strategy: %v", strategy)
+	}
+}
+
 // Error returns the string representation of the noRouteError.
 func (e noRouteError) Error() string {
 	switch e {
@@ -186,6 +231,10 @@ type paymentSession struct {
 	// will happen and this value remains unused.
 	minShardAmt lnwire.MilliSatoshi
 
+	// splitStrategy determines how the amount of the next shard is picked
+	// if no route can be found for the current amount.
+	splitStrategy SplitStrategy
+
 	// log is a payment session-specific logger.
 	log btclog.Logger
 }
@@ -307,6 +356,22 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 			maxAmt, finalHtlcExpiry,
 		)
 
+		// If we didn't find a path and split based on our local
+		// liquidity, we'll determine the largest amount that we can
+		// send out through any of our channels while we still hold the
+		// routing graph.
+		var maxLocalAmt lnwire.MilliSatoshi
+		if err == errNoPathFound &&
+			p.splitStrategy == SplitStrategyLiquidity {
+
+			maxLocalAmt, err = p.maxOutgoingBandwidth(
+				routingGraph, bandwidthHints,
+			)
+			if err == nil {
+				err = errNoPathFound
+			}
+		}
+
 		// Close routing graph.
 		cleanup()
 
@@ -348,8 +413,18 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 			}
 
 			// This is where the magic happens. If we can't find a
-			// route, try it for half the amount.
-			maxAmt /= 2
+			// route, try it for a smaller amount. Unless we're
+			// bound by our local liquidity, that is half the
+			// amount.
+			if maxLocalAmt > 0 && maxLocalAmt < maxAmt {
+				p.log.Debugf("Clamping shard amount %v to "+
+					"max local bandwidth %v", maxAmt,
+					maxLocalAmt)
+
+				maxAmt = maxLocalAmt
+			} else {
+				maxAmt /= 2
+			}
 
 			// Put a lower bound on the minimum shard size.
 			if maxAmt < p.minShardAmt {
@@ -398,6 +473,47 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 	}
 }
 
+// maxOutgoingBandwidth returns the largest bandwidth that is currently
+// available in one of our channels that may be used for this payment.
+func (p *paymentSession) maxOutgoingBandwidth(graph routingGraph,
+	bandwidthHints bandwidthHints) (lnwire.MilliSatoshi, error) {
+
+	var maxBandwidth lnwire.MilliSatoshi
+	err := graph.forEachNodeChannel(graph.sourceNode(),
+		func(channel *channeldb.DirectedChannel) error {
+			// Skip channels that the payment is not allowed to
+			// leave through.
+			outgoingChans := p.payment.OutgoingChannelIDs
+			if len(outgoingChans) > 0 {
+				found := false
+				for _, chanID := range outgoingChans {
+					if chanID == channel.ChannelID {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return nil
+				}
+			}
+
+			bandwidth, ok := bandwidthHints.availableChanBandwidth(
+				channel.ChannelID, 0,
+			)
+			if ok && bandwidth > maxBandwidth {
+				maxBandwidth = bandwidth
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return maxBandwidth, nil
+}
+
 // UpdateAdditionalEdge updates the channel edge policy for a private edge. It
 // validates the message signature and checks it's up to date, then applies the
 // updates to the supplied policy. It returns a boolean to indicate whether
@@ -41,6 +41,14 @@ type SessionSource struct {
 	// PathFindingConfig defines global parameters that control the
 	// trade-off in path finding between fees and probabiity.
 	PathFindingConfig PathFindingConfig
+
+	// MinShardAmt is the amount beyond which we won't try to further split
+	// a payment if no route is found. If zero, DefaultShardMinAmt is used.
+	MinShardAmt lnwire.MilliSatoshi
+
+	// SplitStrategy determines how the amount of the next shard is picked
+	// when no route can be found for a payment.
+	SplitStrategy SplitStrategy
 }
 
 // getRoutingGraph returns a routing graph and a clean-up function for
@@ -79,6 +87,11 @@ func (m *SessionSource) NewPaymentSession(p *LightningPayment) (
 		return nil, err
 	}
 
+	if m.MinShardAmt != 0 {
+		session.minShardAmt = m.MinShardAmt
+	}
+	session.splitStrategy = m.SplitStrategy
+
 	return session, nil
 }
 
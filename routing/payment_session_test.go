@@ -256,3 +256,155 @@ type sessionGraph struct {
 func (g *sessionGraph) sourceNode() route.Vertex {
 	return route.Vertex{}
 }
+
+// TestRequestRouteSplitStrategy tests that the payment session picks the
+// amount of the next shard according to the configured split strategy.
+func TestRequestRouteSplitStrategy(t *testing.T) {
+	const height = 10
+
+	testCases := []struct {
+		name          string
+		strategy      SplitStrategy
+		expectedAmts  []lnwire.MilliSatoshi
+		bandwidthHint map[uint64]lnwire.MilliSatoshi
+	}{
+		{
+			name:     "halving",
+			strategy: SplitStrategyHalving,
+			bandwidthHint: map[uint64]lnwire.MilliSatoshi{
+				1: 300_000, 2: 600_000,
+			},
+			expectedAmts: []lnwire.MilliSatoshi{
+				1_000_000, 500_000,
+			},
+		},
+		{
+			name:     "liquidity",
+			strategy: SplitStrategyLiquidity,
+			bandwidthHint: map[uint64]lnwire.MilliSatoshi{
+				1: 300_000, 2: 600_000,
+			},
+			expectedAmts: []lnwire.MilliSatoshi{
+				1_000_000, 600_000,
+			},
+		},
+		{
+			// If the shard already fits into our local liquidity,
+			// the liquidity strategy falls back to halving.
+			name:     "liquidity fallback",
+			strategy: SplitStrategyLiquidity,
+			bandwidthHint: map[uint64]lnwire.MilliSatoshi{
+				1: 2_000_000,
+			},
+			expectedAmts: []lnwire.MilliSatoshi{
+				1_000_000, 500_000,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			var paymentAddr [32]byte
+			payment := &LightningPayment{
+				CltvLimit:      30,
+				FinalCLTVDelta: 8,
+				Amount:         1_000_000,
+				FeeLimit:       1000,
+				MaxParts:       16,
+				PaymentAddr:    &paymentAddr,
+				DestFeatures: lnwire.NewFeatureVector(
+					lnwire.NewRawFeatureVector(
+						lnwire.TLVOnionPayloadOptional,
+						lnwire.PaymentAddrOptional,
+						lnwire.MPPOptional,
+					), lnwire.Features,
+				),
+			}
+
+			var paymentHash [32]byte
+			err := payment.SetPaymentHash(paymentHash)
+			require.NoError(t, err)
+
+			session, err := newPaymentSession(
+				payment,
+				func(routingGraph) (bandwidthHints, error) {
+					return &mockBandwidthHints{
+						hints: testCase.bandwidthHint,
+					}, nil
+				},
+				func() (routingGraph, func(), error) {
+					return &splitGraph{
+						chans: testCase.bandwidthHint,
+					}, func() {}, nil
+				},
+				&MissionControl{},
+				PathFindingConfig{},
+			)
+			require.NoError(t, err)
+
+			session.minShardAmt = 1
+			session.splitStrategy = testCase.strategy
+
+			// Only allow the route to be found once the amount
+			// matches the last expected shard amount.
+			expectedAmts := testCase.expectedAmts
+			finalAmt := expectedAmts[len(expectedAmts)-1]
+
+			var amts []lnwire.MilliSatoshi
+			session.pathFinder = func(
+				g *graphParams, r *RestrictParams,
+				cfg *PathFindingConfig,
+				source, target route.Vertex,
+				amt lnwire.MilliSatoshi,
+				finalHtlcExpiry int32) (
+				[]*channeldb.CachedEdgePolicy, error) {
+
+				amts = append(amts, amt)
+				if amt != finalAmt {
+					return nil, errNoPathFound
+				}
+
+				path := []*channeldb.CachedEdgePolicy{
+					{
+						ToNodePubKey: func() route.Vertex {
+							return route.Vertex{}
+						},
+						ToNodeFeatures: payment.DestFeatures,
+					},
+				}
+
+				return path, nil
+			}
+
+			route, err := session.RequestRoute(
+				payment.Amount, payment.FeeLimit, 0, height,
+			)
+			require.NoError(t, err)
+			require.Equal(t, expectedAmts, amts)
+			require.Equal(t, finalAmt, route.ReceiverAmt())
+		})
+	}
+}
+
+type splitGraph struct {
+	sessionGraph
+
+	chans map[uint64]lnwire.MilliSatoshi
+}
+
+func (g *splitGraph) forEachNodeChannel(nodePub route.Vertex,
+	cb func(channel *channeldb.DirectedChannel) error) error {
+
+	for chanID := range g.chans {
+		err := cb(&channeldb.DirectedChannel{
+			ChannelID: chanID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
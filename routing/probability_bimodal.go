@@ -0,0 +1,499 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// DefaultBimodalScaleMsat is the default value for BimodalScaleMsat in
+	// BimodalEstimatorCfg. It describes the distribution of funds in the
+	// LN based on empirical findings. We assume an unbalanced network by
+	// default.
+	DefaultBimodalScaleMsat = lnwire.MilliSatoshi(300_000_000)
+
+	// DefaultBimodalNodeWeight is the default value for the
+	// BimodalNodeWeight in BimodalEstimatorCfg. It is chosen such that
+	// past forwardings on other channels of a router are only slightly
+	// taken into account.
+	DefaultBimodalNodeWeight = 0.2
+
+	// DefaultBimodalDecayTime is the default value for BimodalDecayTime.
+	// We will forget about previous learnings about channel liquidity on
+	// the timescale of about a week.
+	DefaultBimodalDecayTime = 7 * 24 * time.Hour
+
+	// unknownCapacity is the capacity we assume for a channel if its
+	// actual capacity is unknown, e.g. for private channels that we only
+	// know about from route hints. We assume a large channel, so that the
+	// estimate is mostly determined by the previous payment results.
+	unknownCapacity = btcutil.Amount(10 * btcutil.SatoshiPerBitcoin)
+)
+
+var (
+	// ErrInvalidScale is returned when we get a scale below or equal
+	// zero.
+	ErrInvalidScale = errors.New("scale must be >= 0 and sane")
+
+	// ErrInvalidNodeWeight is returned when we get a node weight that is
+	// out of range.
+	ErrInvalidNodeWeight = errors.New("node weight must be in [0, 1]")
+
+	// ErrInvalidDecayTime is returned when we get a decay time below zero.
+	ErrInvalidDecayTime = errors.New("decay time must be larger than zero")
+)
+
+// BimodalEstimatorCfg contains the configuration of the bimodal probability
+// estimator.
+type BimodalEstimatorCfg struct {
+	// BimodalNodeWeight defines how strongly other previous forwardings on
+	// channels of a router should be taken into account when computing a
+	// channel's probability to route. The allowed values are in the range
+	// [0, 1], where a value of 0 means that only direct information about
+	// a channel is taken into account.
+	BimodalNodeWeight float64
+
+	// BimodalScaleMsat describes the scale over which channels
+	// statistically have some liquidity left. The value determines how
+	// quickly the bimodal distribution drops off from the edges of a
+	// channel. A larger value (compared to typical channel capacities)
+	// means that the distribution is less bimodal, meaning that liquidity
+	// is evenly distributed within a channel.
+	BimodalScaleMsat lnwire.MilliSatoshi
+
+	// BimodalDecayTime is the scale for the exponential information decay
+	// over time for previous successes or failures.
+	BimodalDecayTime time.Duration
+}
+
+func (p BimodalEstimatorCfg) validate() error {
+	if p.BimodalNodeWeight < 0 || p.BimodalNodeWeight > 1 {
+		return ErrInvalidNodeWeight
+	}
+
+	if p.BimodalDecayTime <= 0 {
+		return ErrInvalidDecayTime
+	}
+
+	if p.BimodalScaleMsat == 0 {
+		return ErrInvalidScale
+	}
+
+	return nil
+}
+
+// bimodalEstimator returns node and pair probabilities based on historical
+// payment results, assuming that the liquidity of a channel is most likely
+// located at either of its ends.
+type bimodalEstimator struct {
+	// BimodalEstimatorCfg contains configuration options for our
+	// estimator.
+	BimodalEstimatorCfg
+}
+
+// A compile-time check to ensure bimodalEstimator implements the estimator
+// interface.
+var _ estimator = (*bimodalEstimator)(nil)
+
+// getPairProbability estimates the probability of successfully traversing to
+// toNode based on historical payment outcomes for the from node. Those outcomes
+// are passed in via the results parameter.
+//
+// NOTE: This is part of the estimator interface.
+func (p *bimodalEstimator) getPairProbability(now time.Time,
+	results NodeResults, toNode route.Vertex, amt lnwire.MilliSatoshi,
+	capacity btcutil.Amount) float64 {
+
+	if capacity == 0 {
+		capacity = unknownCapacity
+	}
+
+	// We first compute the probability for the desired hop taking into
+	// account previous knowledge.
+	directProbability := p.directProbability(
+		now, results, toNode, amt, lnwire.NewMSatFromSatoshis(capacity),
+	)
+
+	// The final probability is computed by taking into account other
+	// channels of the from node.
+	return p.calculateProbability(directProbability, now, results, toNode)
+}
+
+// getLocalPairProbability computes the probability to reach toNode given a set
+// of previous learnings.
+//
+// NOTE: This is part of the estimator interface.
+func (p *bimodalEstimator) getLocalPairProbability(now time.Time,
+	results NodeResults, toNode route.Vertex) float64 {
+
+	// For direct local probabilities we assume to know exactly how much
+	// we can send over a channel, which assumes that channels are active
+	// and have enough liquidity.
+	directProbability := 1.0
+
+	// If we had an unexpected failure for this node, we reduce the
+	// probability for some time to avoid infinite retries.
+	result, ok := results[toNode]
+	if !ok || result.FailTime.IsZero() {
+		return directProbability
+	}
+
+	timeAgo := now.Sub(result.FailTime)
+
+	// We only expect results in the past to get a probability between 0
+	// and 1.
+	if timeAgo < 0 {
+		timeAgo = 0
+	}
+	exponent := -float64(timeAgo) / float64(p.BimodalDecayTime)
+	directProbability -= math.Exp(exponent)
+
+	return directProbability
+}
+
+// calculateProbability computes the total hop probability combining the
+// channel probability and historic forwarding data of other channels of the
+// node we try to send from.
+//
+// Goals:
+// * We want to incentivize good routing nodes: the more routable channels a
+// node has, the more we want to incentivize (vice versa for failures).
+// -> We reduce/increase the direct probability depending on past
+// failures/successes for other channels of the node.
+//
+// * We want to be forgiving/give other nodes a chance as well: we want to
+// forget about (non-)routable channels over time.
+// -> We weight the successes/failures with a time decay such that they will
+// not influence the total probability if a long time went by.
+//
+// * If we don't have other info, we want to solely rely on the direct
+// probability.
+//
+// * We want to be able to specify how important the other channels are
+// compared to the direct channel.
+// -> Introduce a node weight factor that weights the direct probability
+// against the node-wide average. The larger the node weight, the more
+// important other channels of the node are.
+func (p *bimodalEstimator) calculateProbability(directProbability float64,
+	now time.Time, results NodeResults, toNode route.Vertex) float64 {
+
+	// If we don't take other channels into account, we can return early.
+	if p.BimodalNodeWeight == 0.0 {
+		return directProbability
+	}
+
+	// If we have up-to-date information about the channel we want to use,
+	// i.e. the info stems from results not longer ago than the decay
+	// time, we will only use the direct probability. This is needed in
+	// order to avoid that other previous results (on all other channels
+	// of the same routing node) will distort and pin the calculated
+	// probability even if we have accurate direct information.
+	if result, ok := results[toNode]; ok {
+		fresh := func(t time.Time) bool {
+			return !t.IsZero() && now.Sub(t) < p.BimodalDecayTime
+		}
+
+		if fresh(result.FailTime) || fresh(result.SuccessTime) {
+			return directProbability
+		}
+	}
+
+	// We use the results of the other channels of the node to compute a
+	// time-weighted average of their success. A success is counted with a
+	// probability of one, a failure with a probability of zero.
+	var totalWeight, totalProbability float64
+	for peer, result := range results {
+		// We don't include the direct hop probability here because it
+		// is already included in directProbability.
+		if peer == toNode {
+			continue
+		}
+
+		if !result.SuccessTime.IsZero() {
+			weight := p.decayWeight(now, result.SuccessTime)
+			totalWeight += weight
+			totalProbability += weight
+		}
+
+		if !result.FailTime.IsZero() {
+			totalWeight += p.decayWeight(now, result.FailTime)
+		}
+	}
+
+	// Combine the direct probability with the weighted node-wide results.
+	// Without any other results, this reduces to the direct probability.
+	nodeWeight := p.BimodalNodeWeight
+	probability := (directProbability + nodeWeight*totalProbability) /
+		(1 + nodeWeight*totalWeight)
+
+	return probability
+}
+
+// decayWeight returns a weight in the range [0, 1] for a result that was
+// obtained at the given time. Fresh results have a weight of one, which decays
+// exponentially over time.
+func (p *bimodalEstimator) decayWeight(now, resultTime time.Time) float64 {
+	timeAgo := now.Sub(resultTime)
+
+	// We only expect results in the past to get a weight between 0 and 1.
+	if timeAgo < 0 {
+		timeAgo = 0
+	}
+
+	return math.Exp(-float64(timeAgo) / float64(p.BimodalDecayTime))
+}
+
+// directProbability computes the probability to reach a node based on the
+// liquidity distribution in the LN.
+func (p *bimodalEstimator) directProbability(now time.Time,
+	results NodeResults, toNode route.Vertex, amt lnwire.MilliSatoshi,
+	capacity lnwire.MilliSatoshi) float64 {
+
+	// We first determine the time-adjusted success and failure amounts to
+	// then compute a probability. We know that we can send a zero amount.
+	successAmount := lnwire.MilliSatoshi(0)
+
+	// We know that we cannot send the full capacity.
+	failAmount := capacity
+
+	// If we have information about past successes or failures, we modify
+	// them with a time decay.
+	result, ok := results[toNode]
+	if ok {
+		// Apply a time decay for the amount we cannot send.
+		if !result.FailTime.IsZero() {
+			failAmount = cannotSend(
+				result.FailAmt, capacity, now, result.FailTime,
+				p.BimodalDecayTime,
+			)
+		}
+
+		// Apply a time decay for the amount we can send.
+		if !result.SuccessTime.IsZero() {
+			successAmount = canSend(
+				result.SuccessAmt, now, result.SuccessTime,
+				p.BimodalDecayTime,
+			)
+		}
+	}
+
+	// Compute the direct channel probability.
+	probability, err := p.probabilityFormula(
+		capacity, successAmount, failAmount, amt,
+	)
+	if err != nil {
+		log.Errorf("error computing probability: %v", err)
+
+		return 0.0
+	}
+
+	return probability
+}
+
+// cannotSend returns the sent amount back in time divided by the exponential
+// decay with the decay time. The amount returned is the capacity of the
+// channel if the failure is long ago.
+func cannotSend(failAmount, capacity lnwire.MilliSatoshi, now,
+	failTime time.Time, decayTime time.Duration) lnwire.MilliSatoshi {
+
+	// The fail amount can't be larger than the capacity.
+	if failAmount > capacity {
+		failAmount = capacity
+	}
+
+	timeAgo := now.Sub(failTime)
+
+	// We only expect results in the past.
+	if timeAgo < 0 {
+		timeAgo = 0
+	}
+
+	// With time, the fail amount will increase such that it approaches
+	// the capacity.
+	exponent := -float64(timeAgo) / float64(decayTime)
+	weight := math.Exp(exponent)
+	cannotSend := capacity - lnwire.MilliSatoshi(
+		weight*float64(capacity-failAmount),
+	)
+
+	return cannotSend
+}
+
+// canSend returns the sent amount back in time divided by the exponential
+// decay with the decay time. The amount returned is zero if the success is
+// long ago.
+func canSend(successAmount lnwire.MilliSatoshi, now, successTime time.Time,
+	decayTime time.Duration) lnwire.MilliSatoshi {
+
+	timeAgo := now.Sub(successTime)
+
+	// We only expect results in the past.
+	if timeAgo < 0 {
+		timeAgo = 0
+	}
+
+	// With time, the success amount will decrease such that it approaches
+	// zero.
+	exponent := -float64(timeAgo) / float64(decayTime)
+	weight := math.Exp(exponent)
+
+	return lnwire.MilliSatoshi(weight * float64(successAmount))
+}
+
+// primitive computes the indefinite integral of our assumed (normalized)
+// liquidity probability distribution. The distribution of liquidity x here is
+// the function P(x) ~ exp(-x/s) + exp((x-c)/s), i.e., two exponentials
+// residing at the ends of channels. This means that we expect liquidity to be
+// at either side of the channel with capacity c. The s parameter (scale)
+// defines how far the liquidity leaks into the channel. A very low scale
+// assumes completely unbalanced channels, a very high scale assumes a random
+// distribution. More details can be found in
+// https://github.com/lightningnetwork/lnd/issues/5988#issuecomment-1131234858.
+func (p *bimodalEstimator) primitive(c, x float64) float64 {
+	s := float64(p.BimodalScaleMsat)
+
+	// The indefinite integral of P(x) is given by
+	// Int P(x) dx = H(x) = s * (-e(-x/s) + e((x-c)/s)),
+	// and its norm from 0 to c can be computed from it,
+	// norm = [H(x)]_0^c = s * (-e(-c/s) + 1 -(-1 + e(-c/s))).
+	ecs := math.Exp(-c / s)
+	exs := math.Exp(-x / s)
+
+	// It would be possible to split the next term and reuse the factors
+	// from before, but this can lead to numerical issues with large
+	// numbers.
+	excs := math.Exp((x - c) / s)
+
+	// norm can only become zero, if c is zero, which we sorted out before
+	// calling this method.
+	norm := -2*ecs + 2
+
+	// We end up with the primitive function of the normalized P(x).
+	return (-exs + excs) / norm
+}
+
+// integral computes the integral of our liquidity distribution from the lower
+// to the upper value.
+func (p *bimodalEstimator) integral(capacity, lower, upper float64) float64 {
+	if lower < 0 || lower > upper {
+		log.Errorf("probability integral limits nonsensical: capacity: "+
+			"%v lower: %v upper: %v", capacity, lower, upper)
+
+		return 0.0
+	}
+
+	return p.primitive(capacity, upper) - p.primitive(capacity, lower)
+}
+
+// probabilityFormula computes the expected probability for a payment of
+// amountMsat given prior learnings for a channel of certain capacity.
+// successAmountMsat and failAmountMsat stand for the unsettled success and
+// failure amounts, respectively. The formula is derived using the formalism
+// presented in Pickhardt et al., https://arxiv.org/abs/2103.08576.
+func (p *bimodalEstimator) probabilityFormula(capacityMsat, successAmountMsat,
+	failAmountMsat, amountMsat lnwire.MilliSatoshi) (float64, error) {
+
+	// Convert to positive-valued floats.
+	capacity := float64(capacityMsat)
+	successAmount := float64(successAmountMsat)
+	failAmount := float64(failAmountMsat)
+	amount := float64(amountMsat)
+
+	// In order for this formula to give reasonable results, we need to
+	// have an estimate of the capacity of a channel (or edge between
+	// nodes).
+	if capacity == 0.0 {
+		return 0, errors.New("capacity must be non-zero")
+	}
+
+	// We cannot send more than the capacity.
+	if amount > capacity {
+		return 0.0, nil
+	}
+
+	// Mission control may have some outdated values, so we correct them
+	// here. The fail and success amounts can be the capacity at most.
+	if failAmount > capacity {
+		failAmount = capacity
+	}
+	if successAmount > capacity {
+		successAmount = capacity
+	}
+
+	// The next statement is a safety check against an illogical
+	// condition, otherwise the renormalization integral would become
+	// zero. This may happen if a large channel gets closed and smaller
+	// ones remain, but it should recover with the time decay.
+	if failAmount <= successAmount {
+		log.Tracef("fail amount (%v) is smaller than or equal the "+
+			"success amount (%v) for capacity (%v)",
+			failAmountMsat, successAmountMsat, capacityMsat)
+
+		return 0.0, nil
+	}
+
+	// We cannot send more than the fail amount.
+	if amount >= failAmount {
+		return 0.0, nil
+	}
+
+	// The success probability for payment amount a is the integral over
+	// the prior distribution P(x), the probability to find liquidity
+	// between the amount a and channel capacity c (or failAmount a_f):
+	// P(X >= a | X < a_f) = Integral_{a}^{a_f} P(x) dx
+	prob := p.integral(capacity, amount, failAmount)
+	if math.IsNaN(prob) {
+		return 0.0, fmt.Errorf("non-normalized probability is NaN, "+
+			"capacity: %v, amount: %v, fail amount: %v",
+			capacity, amount, failAmount)
+	}
+
+	// If we have payment information, we need to adjust the prior
+	// distribution P(x) and get the posterior distribution by
+	// renormalizing the prior distribution in such a way that the
+	// probability mass lies between a_s and a_f.
+	reNorm := p.integral(capacity, successAmount, failAmount)
+	if math.IsNaN(reNorm) {
+		return 0.0, fmt.Errorf("normalization factor is NaN, "+
+			"capacity: %v, success amount: %v, fail amount: %v",
+			capacity, successAmount, failAmount)
+	}
+
+	// The normalization factor can only be zero if the success amount is
+	// equal or larger than the fail amount. This should not happen as we
+	// have checked this scenario above.
+	if reNorm == 0.0 {
+		return 0.0, fmt.Errorf("normalization factor is zero, "+
+			"capacity: %v, success amount: %v, fail amount: %v",
+			capacity, successAmount, failAmount)
+	}
+
+	prob /= reNorm
+
+	// Note that for payment amounts smaller than successAmount, we can
+	// get a value larger than unity, which we cap here to get a proper
+	// probability.
+	if prob > 1.0 {
+		if amount > successAmount {
+			return 0.0, fmt.Errorf("unexpected large probability "+
+				"(%v) capacity: %v, amount: %v, success "+
+				"amount: %v, fail amount: %v", prob, capacity,
+				amount, successAmount, failAmount)
+		}
+
+		return 1.0, nil
+	} else if prob < 0.0 {
+		return 0.0, fmt.Errorf("negative probability "+
+			"(%v) capacity: %v, amount: %v, success "+
+			"amount: %v, fail amount: %v", prob, capacity,
+			amount, successAmount, failAmount)
+	}
+
+	return prob, nil
+}
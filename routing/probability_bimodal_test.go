@@ -0,0 +1,275 @@
+package routing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	smallAmount = lnwire.MilliSatoshi(400_000)
+	largeAmount = lnwire.MilliSatoshi(5_000_000)
+	capacity    = lnwire.MilliSatoshi(10_000_000)
+	scale       = lnwire.MilliSatoshi(400_000)
+)
+
+// TestBimodalProbabilityFormula tests the probability formula of the bimodal
+// estimator for different prior knowledge about a channel.
+func TestBimodalProbabilityFormula(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		capacity      lnwire.MilliSatoshi
+		scale         lnwire.MilliSatoshi
+		successAmount lnwire.MilliSatoshi
+		failAmount    lnwire.MilliSatoshi
+		amount        lnwire.MilliSatoshi
+		expected      float64
+	}{
+		// A very large scale compared to the capacity corresponds to
+		// a uniform liquidity distribution.
+		{
+			name:       "uniform no info",
+			capacity:   capacity,
+			scale:      1_000 * capacity,
+			failAmount: capacity,
+			amount:     capacity / 4,
+			expected:   0.75,
+		},
+		{
+			name:          "uniform with info",
+			capacity:      capacity,
+			scale:         1_000 * capacity,
+			successAmount: capacity / 5,
+			failAmount:    4 * capacity / 5,
+			amount:        capacity / 2,
+			expected:      0.5,
+		},
+		// With a small scale, liquidity is located at either end of
+		// the channel, so any amount in the middle of the channel has
+		// a success probability of one half.
+		{
+			name:       "bimodal no info",
+			capacity:   capacity,
+			scale:      scale,
+			failAmount: capacity,
+			amount:     largeAmount,
+			expected:   0.5,
+		},
+		{
+			name:       "bimodal small amount",
+			capacity:   capacity,
+			scale:      scale,
+			failAmount: capacity,
+			amount:     smallAmount,
+			expected:   0.684,
+		},
+		{
+			name:          "amount below success amount",
+			capacity:      capacity,
+			scale:         scale,
+			successAmount: largeAmount,
+			failAmount:    capacity,
+			amount:        smallAmount,
+			expected:      1.0,
+		},
+		{
+			name:       "amount at fail amount",
+			capacity:   capacity,
+			scale:      scale,
+			failAmount: largeAmount,
+			amount:     largeAmount,
+			expected:   0.0,
+		},
+		{
+			name:       "amount above capacity",
+			capacity:   capacity,
+			scale:      scale,
+			failAmount: capacity,
+			amount:     capacity + 1,
+			expected:   0.0,
+		},
+		{
+			name:          "fail amount below success amount",
+			capacity:      capacity,
+			scale:         scale,
+			successAmount: largeAmount,
+			failAmount:    smallAmount,
+			amount:        smallAmount / 2,
+			expected:      0.0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			estimator := bimodalEstimator{
+				BimodalEstimatorCfg: BimodalEstimatorCfg{
+					BimodalScaleMsat: test.scale,
+				},
+			}
+
+			p, err := estimator.probabilityFormula(
+				test.capacity, test.successAmount,
+				test.failAmount, test.amount,
+			)
+			require.NoError(t, err)
+			require.InDelta(t, test.expected, p, 0.001)
+		})
+	}
+
+	// A zero capacity can't be used to compute a probability.
+	estimator := bimodalEstimator{
+		BimodalEstimatorCfg: BimodalEstimatorCfg{BimodalScaleMsat: scale},
+	}
+	_, err := estimator.probabilityFormula(0, 0, 0, smallAmount)
+	require.Error(t, err)
+}
+
+// TestBimodalPairProbability tests the time decay of previous results and the
+// influence of results on other channels of the source node.
+func TestBimodalPairProbability(t *testing.T) {
+	t.Parallel()
+
+	const decayTime = time.Hour
+
+	var (
+		now      = time.Unix(1_000_000, 0)
+		toNode   = route.Vertex{node1}
+		peerNode = route.Vertex{node2}
+	)
+
+	estimator := &bimodalEstimator{
+		BimodalEstimatorCfg: BimodalEstimatorCfg{
+			BimodalScaleMsat:  scale,
+			BimodalNodeWeight: 0.2,
+			BimodalDecayTime:  decayTime,
+		},
+	}
+	capacitySat := capacity.ToSatoshis()
+
+	// Without any results, we get the prior probability.
+	p := estimator.getPairProbability(
+		now, NodeResults{}, toNode, largeAmount, capacitySat,
+	)
+	require.InDelta(t, 0.5, p, 0.001)
+
+	// A fresh failure for the amount rules the channel out.
+	results := NodeResults{
+		toNode: {FailTime: now, FailAmt: largeAmount},
+	}
+	p = estimator.getPairProbability(
+		now, results, toNode, largeAmount, capacitySat,
+	)
+	require.Zero(t, p)
+
+	// Long after the failure, we are back at the prior probability.
+	p = estimator.getPairProbability(
+		now.Add(100*decayTime), results, toNode, largeAmount,
+		capacitySat,
+	)
+	require.InDelta(t, 0.5, p, 0.001)
+
+	// A fresh success for a larger amount guarantees success.
+	results = NodeResults{
+		toNode: {SuccessTime: now, SuccessAmt: 3 * largeAmount / 2},
+	}
+	p = estimator.getPairProbability(
+		now, results, toNode, largeAmount, capacitySat,
+	)
+	require.Equal(t, 1.0, p)
+
+	// A success on another channel of the node raises the probability of
+	// an untried channel.
+	results = NodeResults{
+		peerNode: {SuccessTime: now, SuccessAmt: largeAmount},
+	}
+	p = estimator.getPairProbability(
+		now, results, toNode, largeAmount, capacitySat,
+	)
+	require.InDelta(t, (0.5+0.2)/(1+0.2), p, 0.001)
+
+	// A failure on another channel of the node lowers it.
+	results = NodeResults{
+		peerNode: {FailTime: now, FailAmt: largeAmount},
+	}
+	p = estimator.getPairProbability(
+		now, results, toNode, largeAmount, capacitySat,
+	)
+	require.InDelta(t, 0.5/(1+0.2), p, 0.001)
+
+	// If the capacity is unknown, we assume a large channel.
+	p = estimator.getPairProbability(
+		now, NodeResults{}, toNode, largeAmount, 0,
+	)
+	require.InDelta(t, 0.5, p, 0.001)
+}
+
+// TestBimodalLocalPairProbability tests that failures on local channels are
+// forgotten over time.
+func TestBimodalLocalPairProbability(t *testing.T) {
+	t.Parallel()
+
+	const decayTime = time.Hour
+
+	var (
+		now    = time.Unix(1_000_000, 0)
+		toNode = route.Vertex{node1}
+	)
+
+	estimator := &bimodalEstimator{
+		BimodalEstimatorCfg: BimodalEstimatorCfg{
+			BimodalScaleMsat:  scale,
+			BimodalNodeWeight: 0.2,
+			BimodalDecayTime:  decayTime,
+		},
+	}
+
+	// Without a failure, local channels are assumed to work.
+	p := estimator.getLocalPairProbability(now, NodeResults{}, toNode)
+	require.Equal(t, 1.0, p)
+
+	results := NodeResults{
+		toNode: {FailTime: now, FailAmt: largeAmount},
+	}
+	p = estimator.getLocalPairProbability(now, results, toNode)
+	require.Zero(t, p)
+
+	p = estimator.getLocalPairProbability(
+		now.Add(decayTime), results, toNode,
+	)
+	require.InDelta(t, 1-math.Exp(-1), p, 0.001)
+}
+
+// TestBimodalEstimatorCfgValidate tests the validation of the bimodal estimator
+// config.
+func TestBimodalEstimatorCfgValidate(t *testing.T) {
+	t.Parallel()
+
+	cfg := BimodalEstimatorCfg{
+		BimodalScaleMsat:  DefaultBimodalScaleMsat,
+		BimodalNodeWeight: DefaultBimodalNodeWeight,
+		BimodalDecayTime:  DefaultBimodalDecayTime,
+	}
+	require.NoError(t, cfg.validate())
+
+	invalid := cfg
+	invalid.BimodalScaleMsat = 0
+	require.ErrorIs(t, invalid.validate(), ErrInvalidScale)
+
+	invalid = cfg
+	invalid.BimodalNodeWeight = 1.1
+	require.ErrorIs(t, invalid.validate(), ErrInvalidNodeWeight)
+
+	invalid = cfg
+	invalid.BimodalDecayTime = 0
+	require.ErrorIs(t, invalid.validate(), ErrInvalidDecayTime)
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
 )
@@ -58,8 +59,25 @@ func (p ProbabilityEstimatorCfg) validate() error {
 	return nil
 }
 
+// estimator estimates the success probabilities of node pairs based on the
+// historical payment results that mission control collects.
+type estimator interface {
+	// getPairProbability estimates the probability of successfully
+	// traversing to toNode based on historical payment outcomes for the
+	// from node. The capacity of the channel is zero if it's unknown.
+	getPairProbability(now time.Time, results NodeResults,
+		toNode route.Vertex, amt lnwire.MilliSatoshi,
+		capacity btcutil.Amount) float64
+
+	// getLocalPairProbability estimates the probability of successfully
+	// traversing our own local channels to toNode.
+	getLocalPairProbability(now time.Time, results NodeResults,
+		toNode route.Vertex) float64
+}
+
 // probabilityEstimator returns node and pair probabilities based on historical
-// payment results.
+// payment results. It is the apriori estimator, which assumes a fixed success
+// probability for untried connections.
 type probabilityEstimator struct {
 	// ProbabilityEstimatorCfg contains configuration options for our
 	// estimator.
@@ -148,10 +166,13 @@ func (p *probabilityEstimator) getWeight(age time.Duration) float64 {
 
 // getPairProbability estimates the probability of successfully traversing to
 // toNode based on historical payment outcomes for the from node. Those outcomes
-// are passed in via the results parameter.
+// are passed in via the results parameter. The capacity isn't taken into
+// account by this estimator.
+//
+// NOTE: This is part of the estimator interface.
 func (p *probabilityEstimator) getPairProbability(
-	now time.Time, results NodeResults,
-	toNode route.Vertex, amt lnwire.MilliSatoshi) float64 {
+	now time.Time, results NodeResults, toNode route.Vertex,
+	amt lnwire.MilliSatoshi, _ btcutil.Amount) float64 {
 
 	nodeProbability := p.getNodeProbability(now, results, amt)
 
@@ -162,6 +183,8 @@ func (p *probabilityEstimator) getPairProbability(
 
 // getLocalPairProbability estimates the probability of successfully traversing
 // our own local channels to toNode.
+//
+// NOTE: This is part of the estimator interface.
 func (p *probabilityEstimator) getLocalPairProbability(
 	now time.Time, results NodeResults, toNode route.Vertex) float64 {
 
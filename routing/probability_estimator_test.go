@@ -64,7 +64,9 @@ func (c *estimatorTestContext) assertPairProbability(now time.Time,
 
 	const tolerance = 0.01
 
-	p := c.estimator.getPairProbability(now, results, route.Vertex{toNode}, amt)
+	p := c.estimator.getPairProbability(
+		now, results, route.Vertex{toNode}, amt, 0,
+	)
 	diff := p - expectedProb
 	if diff > tolerance || diff < -tolerance {
 		c.t.Fatalf("expected probability %v for node %v, but got %v",
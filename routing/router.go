@@ -48,6 +48,10 @@ const (
 	// if a channel should be pruned or not.
 	DefaultChannelPruneExpiry = time.Duration(time.Hour * 24 * 14)
 
+	// DefaultGraphPruneInterval is the default interval at which we check
+	// the graph for zombie channels that should be pruned.
+	DefaultGraphPruneInterval = time.Hour
+
 	// DefaultFirstTimePruneDelay is the time we'll wait after startup
 	// before attempting to prune the graph for zombie channels. We don't
 	// do it immediately after startup to allow lnd to start up without
@@ -225,9 +229,10 @@ type MissionController interface {
 	ReportPaymentSuccess(attemptID uint64, rt *route.Route) error
 
 	// GetProbability is expected to return the success probability of a
-	// payment from fromNode along edge.
+	// payment from fromNode along edge. The capacity of the edge is zero
+	// if it's unknown.
 	GetProbability(fromNode, toNode route.Vertex,
-		amt lnwire.MilliSatoshi) float64
+		amt lnwire.MilliSatoshi, capacity btcutil.Amount) float64
 }
 
 // FeeSchema is the set fee configuration for a Lightning Node on the network.
@@ -1132,14 +1137,26 @@ func (r *ChannelRouter) networkHandler() {
 
 			// Update the channel graph to reflect that this block
 			// was disconnected.
-			_, err := r.cfg.Graph.DisconnectBlockAtHeight(blockHeight)
+			removedChans, err := r.cfg.Graph.DisconnectBlockAtHeight(
+				blockHeight,
+			)
 			if err != nil {
 				log.Errorf("unable to prune graph with stale "+
 					"block: %v", err)
 				continue
 			}
 
-			// TODO(halseth): notify client about the reorg?
+			// Any channels that were confirmed in the stale block
+			// no longer exist on the main chain, so we'll let our
+			// clients know that they're gone.
+			if len(removedChans) > 0 {
+				closeSummaries := createCloseSummaries(
+					blockHeight, removedChans...,
+				)
+				r.notifyTopologyChange(&TopologyChange{
+					ClosedChannels: closeSummaries,
+				})
+			}
 
 		// A new block has arrived, so we can prune the channel graph
 		// of any channels which were closed in the block.
@@ -2653,14 +2670,20 @@ func (e ErrNoChannel) Error() string {
 
 // BuildRoute returns a fully specified route based on a list of pubkeys. If
 // amount is nil, the minimum routable amount is used. To force a specific
-// outgoing channel, use the outgoingChan parameter.
+// outgoing channel, use the outgoingChan parameter. Any custom records that
+// are passed in are attached to the final hop.
 func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 	hops []route.Vertex, outgoingChan *uint64,
-	finalCltvDelta int32, payAddr *[32]byte) (*route.Route, error) {
+	finalCltvDelta int32, payAddr *[32]byte,
+	destCustomRecords record.CustomSet) (*route.Route, error) {
 
 	log.Tracef("BuildRoute called: hopsCount=%v, amt=%v",
 		len(hops), amt)
 
+	if len(hops) == 0 {
+		return nil, errors.New("route must contain at least one hop")
+	}
+
 	var outgoingChans map[uint64]struct{}
 	if outgoingChan != nil {
 		outgoingChans = map[uint64]struct{}{
@@ -2797,7 +2820,7 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 			amt:         receiverAmt,
 			totalAmt:    receiverAmt,
 			cltvDelta:   uint16(finalCltvDelta),
-			records:     nil,
+			records:     destCustomRecords,
 			paymentAddr: payAddr,
 		},
 	)
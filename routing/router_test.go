@@ -112,8 +112,9 @@ func createTestCtxFromGraphInstanceAssumeValid(t *testing.T,
 	chainView := newMockChainView(chain)
 
 	pathFindingConfig := PathFindingConfig{
-		MinProbability: 0.01,
-		AttemptCost:    100,
+		MinProbability:       0.01,
+		AttemptCost:          100,
+		RiskFactorBillionths: DefaultRiskFactorBillionths,
 	}
 
 	mcConfig := &MissionControlConfig{
@@ -1957,6 +1958,14 @@ func TestDisconnectedBlocks(t *testing.T) {
 		t.Fatal("edge was marked as zombie")
 	}
 
+	// Subscribe to topology changes, so we can assert that clients are
+	// notified about the channel that gets reorged out.
+	ntfnClient, err := ctx.router.SubscribeTopology()
+	if err != nil {
+		t.Fatalf("unable to subscribe for channel notifications: %v", err)
+	}
+	defer ntfnClient.Cancel()
+
 	// Create a 15 block fork. We first let the chainView notify the router
 	// about stale blocks, before sending the now connected blocks. We do
 	// this because we expect this order from the chainview.
@@ -1969,7 +1978,20 @@ func TestDisconnectedBlocks(t *testing.T) {
 		<-ctx.chainView.notifyStaleBlockAck
 	}
 
-	time.Sleep(time.Second * 2)
+	timeout := time.After(2 * time.Second)
+	for notified := false; !notified; {
+		select {
+		case ntfn := <-ntfnClient.TopologyChanges:
+			for _, closedChan := range ntfn.ClosedChannels {
+				if closedChan.ChanID == chanID2 {
+					notified = true
+				}
+			}
+
+		case <-timeout:
+			t.Fatalf("no close notification for reorged channel")
+		}
+	}
 
 	ctx.chainView.notifyBlockAck = make(chan struct{}, 1)
 	for i := uint32(1); i <= 15; i++ {
@@ -3305,7 +3327,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the given amount.
 	rt, err := ctx.router.BuildRoute(
-		&amt, hops, nil, 40, &payAddr,
+		&amt, hops, nil, 40, &payAddr, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3321,7 +3343,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the minimum amount.
 	rt, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40, &payAddr,
+		nil, hops, nil, 40, &payAddr, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3342,7 +3364,7 @@ func TestBuildRoute(t *testing.T) {
 		ctx.aliases["e"], ctx.aliases["c"],
 	}
 	_, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40, nil,
+		nil, hops, nil, 40, nil, nil,
 	)
 	errNoChannel, ok := err.(ErrNoChannel)
 	if !ok {
@@ -3355,6 +3377,76 @@ func TestBuildRoute(t *testing.T) {
 	if errNoChannel.fromNode != ctx.aliases["a"] {
 		t.Fatalf("unexpected no channel error node")
 	}
+
+	// A route without any hops can't be built.
+	_, err = ctx.router.BuildRoute(nil, nil, nil, 40, nil, nil)
+	require.Error(t, err)
+}
+
+// TestBuildRouteCustomRecords tests that custom records are attached to the
+// final hop of a built route.
+func TestBuildRouteCustomRecords(t *testing.T) {
+	// Setup a three node network in which the final hop understands TLV
+	// payloads.
+	chanCapSat := btcutil.Amount(100000)
+	tlvFeatures := lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(
+			lnwire.TLVOnionPayloadOptional,
+			lnwire.PaymentAddrOptional,
+		),
+		lnwire.Features,
+	)
+	testChannels := []*testChannel{
+		symmetricTestChannel("a", "b", chanCapSat, &testChannelPolicy{
+			Expiry:  144,
+			FeeRate: 20000,
+			MinHTLC: lnwire.NewMSatFromSatoshis(5),
+			MaxHTLC: lnwire.NewMSatFromSatoshis(chanCapSat),
+		}, 1),
+		symmetricTestChannel("b", "c", chanCapSat, &testChannelPolicy{
+			Expiry:   144,
+			FeeRate:  50000,
+			MinHTLC:  lnwire.NewMSatFromSatoshis(20),
+			MaxHTLC:  lnwire.NewMSatFromSatoshis(chanCapSat),
+			Features: tlvFeatures,
+		}, 2),
+	}
+
+	testGraph, err := createTestGraphFromChannels(true, testChannels, "a")
+	require.NoError(t, err, "unable to create graph")
+	defer testGraph.cleanUp()
+
+	const startingBlockHeight = 101
+
+	ctx, cleanUp := createTestCtxFromGraphInstance(
+		t, startingBlockHeight, testGraph, false,
+	)
+	defer cleanUp()
+
+	var payAddr [32]byte
+	_, err = rand.Read(payAddr[:])
+	require.NoError(t, err)
+
+	hops := []route.Vertex{
+		ctx.aliases["b"], ctx.aliases["c"],
+	}
+	amt := lnwire.NewMSatFromSatoshis(100)
+
+	// Custom records that are passed in should be attached to the final
+	// hop only.
+	customRecords := record.CustomSet{65536: []byte{1, 2, 3}}
+	rt, err := ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &payAddr, customRecords,
+	)
+	require.NoError(t, err)
+	require.Len(t, rt.Hops, 2)
+	require.Empty(t, rt.Hops[0].CustomRecords)
+	require.Equal(t, customRecords, rt.Hops[1].CustomRecords)
+
+	// Without any custom records, none should be attached.
+	rt, err = ctx.router.BuildRoute(&amt, hops, nil, 40, &payAddr, nil)
+	require.NoError(t, err)
+	require.Empty(t, rt.Hops[1].CustomRecords)
 }
 
 // edgeCreationModifier is an enum-like type used to modify steps that are
@@ -129,6 +129,19 @@ type unifiedPolicy struct {
 	localChan bool
 }
 
+// maxCapacity returns the largest capacity of all channels that are covered by
+// this unified policy. It is zero if none of the capacities are known.
+func (u *unifiedPolicy) maxCapacity() btcutil.Amount {
+	var capacity btcutil.Amount
+	for _, edge := range u.edges {
+		if edge.capacity > capacity {
+			capacity = edge.capacity
+		}
+	}
+
+	return capacity
+}
+
 // getPolicy returns the optimal policy to use for this connection given a
 // specific amount to send. It differentiates between local and network
 // channels.
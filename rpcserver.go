@@ -27,6 +27,7 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
+	basewallet "github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/davecgh/go-spew/spew"
 	proxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -258,6 +259,35 @@ func calculateFeeRate(satPerByte, satPerVByte uint64, targetConf uint32,
 	return feeRate, nil
 }
 
+// calculateMaxCloseFeeRate converts the max fee rate of a cooperative close
+// from sat/vbyte to sat/kw, making sure it isn't below the target fee rate of
+// the close. Zero is returned if no max fee rate was specified.
+func calculateMaxCloseFeeRate(maxFeePerVByte uint64,
+	feeRate chainfee.SatPerKWeight) (chainfee.SatPerKWeight, error) {
+
+	if maxFeePerVByte == 0 {
+		return 0, nil
+	}
+
+	// The target fee rate was clamped to the relay fee floor, which is
+	// slightly above 1 sat/vbyte, so we'll do the same for the max fee
+	// rate.
+	maxFeeRate := chainfee.SatPerKVByte(
+		maxFeePerVByte * 1000,
+	).FeePerKWeight()
+	if maxFeeRate < chainfee.FeePerKwFloor {
+		maxFeeRate = chainfee.FeePerKwFloor
+	}
+
+	if maxFeeRate < feeRate {
+		return 0, fmt.Errorf("max fee rate of %v sat/vbyte is below "+
+			"the target fee rate of %v sat/vbyte", maxFeePerVByte,
+			int64(feeRate.FeePerKVByte()/1000))
+	}
+
+	return maxFeeRate, nil
+}
+
 // GetAllPermissions returns all the permissions required to interact with lnd.
 func GetAllPermissions() []bakery.Op {
 	allPerms := make([]bakery.Op, 0)
@@ -711,6 +741,7 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		Tower:                  s.controlTower,
 		MaxTotalTimelock:       r.cfg.MaxOutgoingCltvExpiry,
 		DefaultFinalCltvDelta:  uint16(r.cfg.Bitcoin.TimeLockDelta),
+		DefaultMaxParts:        r.cfg.SubRPCServers.RouterRPC.MaxShards,
 		SubscribeHtlcEvents:    s.htlcNotifier.SubscribeHtlcEvents,
 		InterceptableForwarder: s.interceptableSwitch,
 		SetChannelEnabled: func(outpoint wire.OutPoint) error {
@@ -743,9 +774,10 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		r.cfg, s.cc, r.cfg.networkDir, macService, atpl, invoiceRegistry,
 		s.htlcSwitch, r.cfg.ActiveNetParams.Params, s.chanRouter,
 		routerBackend, s.nodeSigner, s.graphDB, s.chanStateDB,
-		s.sweeper, tower, s.towerClient, s.anchorTowerClient,
-		r.cfg.net.ResolveTCPAddr, genInvoiceFeatures,
-		genAmpInvoiceFeatures, rpcsLog,
+		s.sweeper, s.utxoConsolidator, tower, s.towerClient,
+		s.anchorTowerClient, r.cfg.net.ResolveTCPAddr,
+		genInvoiceFeatures, genAmpInvoiceFeatures,
+		s.aliasMgr.GetPeerAlias, rpcsLog,
 	)
 	if err != nil {
 		return err
@@ -1034,10 +1066,11 @@ func allowCORS(handler http.Handler, origins []string) http.Handler {
 
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
+// address to a specified output value to be sent to that address. If no coin
+// selection strategy is given, the wallet's configured strategy is used.
 func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
-	feeRate chainfee.SatPerKWeight, minConfs int32,
-	label string) (*chainhash.Hash, error) {
+	feeRate chainfee.SatPerKWeight, minConfs int32, label string,
+	strategy *basewallet.CoinSelectionStrategy) (*chainhash.Hash, error) {
 
 	outputs, err := addrPairsToOutputs(paymentMap, r.cfg.ActiveNetParams.Params)
 	if err != nil {
@@ -1047,7 +1080,7 @@ func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 	// We first do a dry run, to sanity check we won't spend our wallet
 	// balance below the reserved amount.
 	authoredTx, err := r.server.cc.Wallet.CreateSimpleTx(
-		outputs, feeRate, minConfs, true,
+		outputs, feeRate, minConfs, true, strategy,
 	)
 	if err != nil {
 		return nil, err
@@ -1068,7 +1101,7 @@ func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 	// If that checks out, we're fairly confident that creating sending to
 	// these outputs will keep the wallet balance above the reserve.
 	tx, err := r.server.cc.Wallet.SendOutputs(
-		outputs, feeRate, minConfs, label,
+		outputs, feeRate, minConfs, label, strategy,
 	)
 	if err != nil {
 		return nil, err
@@ -1166,7 +1199,9 @@ func (r *rpcServer) EstimateFee(ctx context.Context,
 	var tx *txauthor.AuthoredTx
 	wallet := r.server.cc.Wallet
 	err = wallet.WithCoinSelectLock(func() error {
-		tx, err = wallet.CreateSimpleTx(outputs, feePerKw, minConfs, true)
+		tx, err = wallet.CreateSimpleTx(
+			outputs, feePerKw, minConfs, true, nil,
+		)
 		return err
 	})
 	if err != nil {
@@ -1250,6 +1285,13 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		return nil, err
 	}
 
+	strategy, err := lnrpc.UnmarshallWalletCoinSelectionStrategy(
+		in.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	var txid *chainhash.Hash
 
 	wallet := r.server.cc.Wallet
@@ -1387,7 +1429,7 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		paymentMap := map[string]int64{targetAddr.String(): in.Amount}
 		err := wallet.WithCoinSelectLock(func() error {
 			newTXID, err := r.sendCoinsOnChain(
-				paymentMap, feePerKw, minConfs, label,
+				paymentMap, feePerKw, minConfs, label, strategy,
 			)
 			if err != nil {
 				return err
@@ -1433,6 +1475,13 @@ func (r *rpcServer) SendMany(ctx context.Context,
 		return nil, err
 	}
 
+	strategy, err := lnrpc.UnmarshallWalletCoinSelectionStrategy(
+		in.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	rpcsLog.Infof("[sendmany] outputs=%v, sat/kw=%v",
 		spew.Sdump(in.AddrToAmount), int64(feePerKw))
 
@@ -1444,7 +1493,7 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	wallet := r.server.cc.Wallet
 	err = wallet.WithCoinSelectLock(func() error {
 		sendManyTXID, err := r.sendCoinsOnChain(
-			in.AddrToAmount, feePerKw, minConfs, label,
+			in.AddrToAmount, feePerKw, minConfs, label, strategy,
 		)
 		if err != nil {
 			return err
@@ -1876,6 +1925,49 @@ func (r *rpcServer) canOpenChannel() error {
 	return nil
 }
 
+// validateFundingAccount makes sure that a channel can be funded from the
+// wallet account with the given name. The funding flow selects P2WKH coins and
+// sends its change to a P2WKH address, so the account must be a BIP84 account.
+// The wallet must also be able to sign for the coins, which rules out imported
+// and watch-only accounts, unless a remote signer holds the wallet's keys.
+func validateFundingAccount(wallet lnwallet.WalletController, name string,
+	remoteSigner bool) error {
+
+	if name == waddrmgr.ImportedAddrAccountName {
+		return fmt.Errorf("unable to fund channel from the imported " +
+			"account")
+	}
+
+	accounts, err := wallet.ListAccounts(name, nil)
+	if err != nil {
+		return fmt.Errorf("unable to find wallet account %v: %v", name,
+			err)
+	}
+
+	// The default account exists in every key scope, so we look for its
+	// BIP84 instance.
+	var account *waddrmgr.AccountProperties
+	for _, a := range accounts {
+		if a.KeyScope == waddrmgr.KeyScopeBIP0084 {
+			account = a
+			break
+		}
+	}
+	if account == nil {
+		return fmt.Errorf("unable to fund channel from account %v, "+
+			"only BIP84 (p2wkh) accounts are supported", name)
+	}
+
+	// If the wallet uses a remote signer, all of its accounts are
+	// watch-only and the remote signer signs for them.
+	if account.IsWatchOnly && !remoteSigner {
+		return fmt.Errorf("unable to fund channel from watch-only "+
+			"account %v", name)
+	}
+
+	return nil
+}
+
 // parseOpenChannelReq parses an OpenChannelRequest message into an InitFundingMsg
 // struct. The logic is abstracted so that it can be shared between OpenChannel
 // and OpenChannelSync.
@@ -1892,34 +1984,58 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 	remoteCsvDelay := uint16(in.RemoteCsvDelay)
 	maxValue := lnwire.MilliSatoshi(in.RemoteMaxValueInFlightMsat)
 	maxHtlcs := uint16(in.RemoteMaxHtlcs)
+	remoteChanReserve := btcutil.Amount(in.RemoteChanReserveSat)
 
 	globalFeatureSet := r.server.featureMgr.Get(feature.SetNodeAnn)
 
+	wumboEnabled := globalFeatureSet.HasFeature(
+		lnwire.WumboChannelsOptional,
+	)
+
+	// In fundmax mode the channel size is decided by the wallet, so the
+	// checks that depend on it are done once the funds are reserved.
+	var fundUpToMaxAmt, minFundAmt btcutil.Amount
+	if in.FundMax {
+		if localFundingAmt != 0 {
+			return nil, fmt.Errorf("local amount cannot be set " +
+				"when using fund max")
+		}
+
+		fundUpToMaxAmt = MaxFundingAmount
+		if wumboEnabled {
+			fundUpToMaxAmt = funding.MaxBtcFundingAmountWumbo
+		}
+		minFundAmt = funding.MinChanFundingSize
+	}
+
+	switch {
+	case in.FundMax:
+		if remoteInitialBalance >= fundUpToMaxAmt {
+			return nil, fmt.Errorf("amount pushed to remote peer "+
+				"for initial state must be below the max "+
+				"channel size of %v", fundUpToMaxAmt)
+		}
+
 	// Ensure that the initial balance of the remote party (if pushing
 	// satoshis) does not exceed the amount the local party has requested
 	// for funding.
 	//
 	// TODO(roasbeef): incorporate base fee?
-	if remoteInitialBalance >= localFundingAmt {
+	case remoteInitialBalance >= localFundingAmt:
 		return nil, fmt.Errorf("amount pushed to remote peer for " +
 			"initial state must be below the local funding amount")
-	}
 
 	// Ensure that the user doesn't exceed the current soft-limit for
 	// channel size. If the funding amount is above the soft-limit, then
 	// we'll reject the request.
-	wumboEnabled := globalFeatureSet.HasFeature(
-		lnwire.WumboChannelsOptional,
-	)
-	if !wumboEnabled && localFundingAmt > MaxFundingAmount {
+	case !wumboEnabled && localFundingAmt > MaxFundingAmount:
 		return nil, fmt.Errorf("funding amount is too large, the max "+
 			"channel size is: %v", MaxFundingAmount)
-	}
 
 	// Restrict the size of the channel we'll actually open. At a later
 	// level, we'll ensure that the output we create after accounting for
 	// fees that a dust output isn't created.
-	if localFundingAmt < funding.MinChanFundingSize {
+	case localFundingAmt < funding.MinChanFundingSize:
 		return nil, fmt.Errorf("channel is too small, the minimum "+
 			"channel size is: %v SAT", int64(funding.MinChanFundingSize))
 	}
@@ -1931,6 +2047,53 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 			"greater than %v", maxHtlcs, input.MaxHTLCNumber/2)
 	}
 
+	// Likewise, a max in-flight value above the channel capacity can never
+	// be reached, so it's most likely a mistake of the user.
+	capacityMSat := lnwire.NewMSatFromSatoshis(localFundingAmt)
+	if !in.FundMax && maxValue > capacityMSat {
+		return nil, fmt.Errorf("remote-max-value-in-flight-msat (%v) "+
+			"cannot be greater than the channel capacity (%v)",
+			maxValue, capacityMSat)
+	}
+
+	// The remote peer won't accept a channel reserve of 20% of the
+	// channel capacity or more.
+	if !in.FundMax && remoteChanReserve >= localFundingAmt/5 {
+		return nil, fmt.Errorf("remote-chan-reserve-sat (%v) must be "+
+			"less than 20%% of the channel capacity (%v)",
+			remoteChanReserve, localFundingAmt)
+	}
+
+	// Parse the outpoints the channel should be funded from, if any.
+	var outpoints []wire.OutPoint
+	for _, rpcOutpoint := range in.Outpoints {
+		outpoint, err := lnrpc.UnmarshallOutPoint(rpcOutpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outpoint: %v", err)
+		}
+
+		outpoints = append(outpoints, *outpoint)
+	}
+
+	// If the channel should be funded from a specific account, make sure
+	// the wallet can fund a channel from it.
+	if in.Account != "" {
+		remoteSigner := r.cfg.RemoteSigner.Enable
+		err := validateFundingAccount(
+			r.server.cc.Wallet, in.Account, remoteSigner,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	coinSelectionStrategy, err := lnrpc.UnmarshallCoinSelectionStrategy(
+		in.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Then, we'll extract the minimum number of confirmations that each
 	// output we use to fund the channel's funding transaction should
 	// satisfy.
@@ -1988,6 +2151,15 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		return nil, err
 	}
 
+	// A fee rate above the cap of the sweeper is most likely a unit
+	// mistake, e.g. sat/kw that were passed as sat/vbyte, so we refuse
+	// to fund the channel with it.
+	if feeRate > sweep.DefaultMaxFeeRate {
+		return nil, fmt.Errorf("funding fee rate %v is above the "+
+			"maximum of %v", feeRate.FeePerKVByte(),
+			sweep.DefaultMaxFeeRate.FeePerKVByte())
+	}
+
 	rpcsLog.Debugf("[openchannel]: using fee of %v sat/kw for funding tx",
 		int64(feeRate))
 
@@ -2038,20 +2210,27 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	return &funding.InitFundingMsg{
-		TargetPubkey:     nodePubKey,
-		ChainHash:        *r.cfg.ActiveNetParams.GenesisHash,
-		LocalFundingAmt:  localFundingAmt,
-		PushAmt:          lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		MinHtlcIn:        minHtlcIn,
-		FundingFeePerKw:  feeRate,
-		Private:          in.Private,
-		RemoteCsvDelay:   remoteCsvDelay,
-		MinConfs:         minConfs,
-		ShutdownScript:   script,
-		MaxValueInFlight: maxValue,
-		MaxHtlcs:         maxHtlcs,
-		MaxLocalCsv:      uint16(in.MaxLocalCsv),
-		ChannelType:      channelType,
+		TargetPubkey:      nodePubKey,
+		ChainHash:         *r.cfg.ActiveNetParams.GenesisHash,
+		LocalFundingAmt:   localFundingAmt,
+		FundUpToMaxAmt:    fundUpToMaxAmt,
+		MinFundAmt:        minFundAmt,
+		Outpoints:         outpoints,
+		Account:           in.Account,
+		PushAmt:           lnwire.NewMSatFromSatoshis(remoteInitialBalance),
+		MinHtlcIn:         minHtlcIn,
+		FundingFeePerKw:   feeRate,
+		Private:           in.Private,
+		RemoteCsvDelay:    remoteCsvDelay,
+		MinConfs:          minConfs,
+		ShutdownScript:    script,
+		MaxValueInFlight:  maxValue,
+		MaxHtlcs:          maxHtlcs,
+		MaxLocalCsv:       uint16(in.MaxLocalCsv),
+		RemoteChanReserve: remoteChanReserve,
+		ChannelType:       channelType,
+
+		CoinSelectionStrategy: coinSelectionStrategy,
 	}, nil
 }
 
@@ -2072,6 +2251,18 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	// If the user has provided a shim, then we'll now augment the based
 	// open channel request with this additional logic.
 	if in.FundingShim != nil {
+		// A shim replaces the wallet's coin selection, so the options
+		// that control it can't be honored.
+		useGlobalStrategy := in.CoinSelectionStrategy ==
+			lnrpc.CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG
+		if in.FundMax || len(in.Outpoints) > 0 || in.Account != "" ||
+			!useGlobalStrategy {
+
+			return fmt.Errorf("fund max, outpoints, account and " +
+				"coin selection strategy cannot be used with " +
+				"a funding shim")
+		}
+
 		switch {
 		// If we have a chan point shim, then this means the funding
 		// transaction was crafted externally. In this case we only
@@ -2443,6 +2634,15 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		rpcsLog.Debugf("Target sat/kw for closing transaction: %v",
 			int64(feeRate))
 
+		// If a max fee rate was specified, we'll convert it to sat/kw
+		// so the close negotiation can be capped at that rate.
+		maxFee, err := calculateMaxCloseFeeRate(
+			in.MaxFeePerVbyte, feeRate,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Before we attempt the cooperative channel closure, we'll
 		// examine the channel to ensure that it doesn't have a
 		// lingering HTLC.
@@ -2477,7 +2677,7 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(
 			chanPoint, contractcourt.CloseRegular, feeRate,
-			deliveryScript,
+			maxFee, deliveryScript,
 		)
 	}
 out:
@@ -2599,6 +2799,14 @@ func (r *rpcServer) abandonChan(chanPoint *wire.OutPoint,
 		return err
 	}
 
+	// A pending channel might still be waiting for its funding transaction
+	// to confirm, so we'll make sure the funding manager stops advancing it
+	// and forgets about its opening state.
+	err = r.server.fundingMgr.AbandonChannel(chanPoint)
+	if err != nil {
+		return err
+	}
+
 	// Finally, notify the backup listeners that the channel can be removed
 	// from any channel backups.
 	r.server.channelNotifier.NotifyClosedChannelEvent(*chanPoint)
@@ -3257,6 +3465,14 @@ func (r *rpcServer) fetchPendingOpenChannels() (pendingOpenChannels, error) {
 		commitBaseWeight := blockchain.GetTransactionWeight(utx)
 		commitWeight := commitBaseWeight + input.WitnessCommitmentTxWeight
 
+		// No updates can have happened before the channel is open, so
+		// the balance of the responder is the amount that was pushed
+		// to them, if any.
+		pushAmt := localCommitment.LocalBalance.ToSatoshis()
+		if pendingChan.IsInitiator {
+			pushAmt = localCommitment.RemoteBalance.ToSatoshis()
+		}
+
 		result[i] = &lnrpc.PendingChannelsResponse_PendingOpenChannel{
 			Channel: &lnrpc.PendingChannelsResponse_PendingChannel{
 				RemoteNodePub:        hex.EncodeToString(pub),
@@ -3269,6 +3485,7 @@ func (r *rpcServer) fetchPendingOpenChannels() (pendingOpenChannels, error) {
 				Initiator:            rpcInitiator(pendingChan.IsInitiator),
 				CommitmentType:       rpcCommitmentType(pendingChan.ChanType),
 				Private:              isPrivate(pendingChan),
+				PushAmountSat:        uint64(pushAmt),
 			},
 			CommitWeight: commitWeight,
 			CommitFee:    int64(localCommitment.CommitFee),
@@ -4151,21 +4368,17 @@ func (r *rpcServer) createRPCClosedChannel(
 	nodePub := dbChannel.RemotePub
 	nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
 
-	var (
-		closeType      lnrpc.ChannelCloseSummary_ClosureType
-		openInit       lnrpc.Initiator
-		closeInitiator lnrpc.Initiator
-		err            error
-	)
+	var closeType lnrpc.ChannelCloseSummary_ClosureType
 
-	// Lookup local and remote cooperative initiators. If these values
-	// are not known they will just return unknown.
-	openInit, closeInitiator, err = r.getInitiators(
-		&dbChannel.ChanPoint,
-	)
+	// Lookup the state of the channel before it was closed, which tells us
+	// the local and remote cooperative initiators and whether the channel
+	// was private. If it is not known, the initiators will just be
+	// unknown.
+	histChan, err := r.fetchHistoricalChannel(&dbChannel.ChanPoint)
 	if err != nil {
 		return nil, err
 	}
+	openInit, closeInitiator := getInitiators(histChan)
 
 	// Convert the close type to rpc type.
 	switch dbChannel.CloseType {
@@ -4198,6 +4411,10 @@ func (r *rpcServer) createRPCClosedChannel(
 		CloseInitiator:    closeInitiator,
 	}
 
+	if histChan != nil {
+		channel.Private = isPrivate(histChan)
+	}
+
 	reports, err := r.server.miscDB.FetchChannelReports(
 		*r.cfg.ActiveNetParams.GenesisHash, &dbChannel.ChanPoint,
 	)
@@ -4258,6 +4475,9 @@ func rpcChannelResolution(report *channeldb.ResolverReport) (*lnrpc.Resolution,
 	case channeldb.ResolverTypeCommit:
 		res.ResolutionType = lnrpc.ResolutionType_COMMIT
 
+	case channeldb.ResolverTypeBreach:
+		res.ResolutionType = lnrpc.ResolutionType_BREACH
+
 	default:
 		return nil, fmt.Errorf("unknown resolver type: %v",
 			report.ResolverType)
@@ -4287,37 +4507,47 @@ func rpcChannelResolution(report *channeldb.ResolverReport) (*lnrpc.Resolution,
 	return res, nil
 }
 
-// getInitiators returns an initiator enum that provides information about the
-// party that initiated channel's open and close. This information is obtained
-// from the historical channel bucket, so unknown values are returned when the
-// channel is not present (which indicates that it was closed before we started
-// writing channels to the historical close bucket).
-func (r *rpcServer) getInitiators(chanPoint *wire.OutPoint) (
-	lnrpc.Initiator,
-	lnrpc.Initiator, error) {
+// fetchHistoricalChannel returns the state of a closed channel from the
+// historical channel bucket. Nil is returned when the channel is not present,
+// which indicates that it was closed before we started writing channels to the
+// historical close bucket.
+func (r *rpcServer) fetchHistoricalChannel(
+	chanPoint *wire.OutPoint) (*channeldb.OpenChannel, error) {
 
-	var (
-		openInitiator  = lnrpc.Initiator_INITIATOR_UNKNOWN
-		closeInitiator = lnrpc.Initiator_INITIATOR_UNKNOWN
-	)
-
-	// To get the close initiator for cooperative closes, we need
-	// to get the channel status from the historical channel bucket.
 	histChan, err := r.server.chanStateDB.FetchHistoricalChannel(chanPoint)
 	switch {
 	// The node has upgraded from a version where we did not store
 	// historical channels, and has not closed a channel since. Do
-	// not return an error, initiator values are unknown.
+	// not return an error, the channel state is unknown.
 	case err == channeldb.ErrNoHistoricalBucket:
-		return openInitiator, closeInitiator, nil
+		return nil, nil
 
 	// The channel was closed before we started storing historical
-	// channels. Do  not return an error, initiator values are unknown.
+	// channels. Do  not return an error, the channel state is unknown.
 	case err == channeldb.ErrChannelNotFound:
-		return openInitiator, closeInitiator, nil
+		return nil, nil
 
 	case err != nil:
-		return 0, 0, err
+		return nil, err
+	}
+
+	return histChan, nil
+}
+
+// getInitiators returns an initiator enum that provides information about the
+// party that initiated channel's open and close. This information is obtained
+// from the historical channel state, so unknown values are returned when it is
+// nil.
+func getInitiators(histChan *channeldb.OpenChannel) (lnrpc.Initiator,
+	lnrpc.Initiator) {
+
+	var (
+		openInitiator  = lnrpc.Initiator_INITIATOR_UNKNOWN
+		closeInitiator = lnrpc.Initiator_INITIATOR_UNKNOWN
+	)
+
+	if histChan == nil {
+		return openInitiator, closeInitiator
 	}
 
 	// If we successfully looked up the channel, determine initiator based
@@ -4350,7 +4580,7 @@ func (r *rpcServer) getInitiators(chanPoint *wire.OutPoint) (
 		closeInitiator = lnrpc.Initiator_INITIATOR_REMOTE
 	}
 
-	return openInitiator, closeInitiator, nil
+	return openInitiator, closeInitiator
 }
 
 // SubscribeChannelEvents returns a uni-directional stream (server -> client)
@@ -4689,6 +4919,13 @@ func (r *rpcServer) extractPaymentIntent(rpcPayReq *rpcPaymentRequest) (rpcPayme
 			return payIntent, err
 		}
 
+		// We also require a payment address to be present to protect
+		// the receiver against probing.
+		err = routerrpc.ValidatePayReqPaymentAddr(payReq)
+		if err != nil {
+			return payIntent, err
+		}
+
 		// If the amount was not included in the invoice, then we let
 		// the payee specify the amount of satoshis they wish to send.
 		// We override the amount to pay with the amount provided from
@@ -5239,6 +5476,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		GenAmpInvoiceFeatures: func() *lnwire.FeatureVector {
 			return r.server.featureMgr.Get(feature.SetInvoiceAmp)
 		},
+		GetAlias: r.server.aliasMgr.GetPeerAlias,
 	}
 
 	value, err := lnrpc.UnmarshallAmt(invoice.Value, invoice.ValueMsat)
@@ -5261,6 +5499,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		Private:         invoice.Private,
 		RouteHints:      routeHints,
 		Amp:             invoice.IsAmp,
+		CustomRecords:   invoice.CustomRecords,
 	}
 
 	if invoice.RPreimage != nil {
@@ -5520,10 +5759,14 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 	includeUnannounced := req.IncludeUnannounced
 
 	// Check to see if the cache is already populated, if so then we can
-	// just return it directly.
+	// just return it directly. The cache only ever holds the public view
+	// of the graph, so requests for unannounced channels bypass it to
+	// make sure we don't hand out private channels to callers that didn't
+	// ask for them and vice versa.
 	//
 	// TODO(roasbeef): move this to an interceptor level feature?
-	graphCacheActive := r.cfg.Caches.RPCGraphCacheDuration != 0
+	graphCacheActive := r.cfg.Caches.RPCGraphCacheDuration != 0 &&
+		!includeUnannounced
 	if graphCacheActive {
 		r.graphCache.Lock()
 		defer r.graphCache.Unlock()
@@ -5723,8 +5966,13 @@ func (r *rpcServer) GetChanInfo(ctx context.Context,
 
 	graph := r.server.graphDB
 
+	// Channels we don't know of or that were marked as zombies are
+	// reported as not found, mirroring the behavior of GetNodeInfo.
 	edgeInfo, edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
-	if err != nil {
+	switch {
+	case err == channeldb.ErrEdgeNotFound || err == channeldb.ErrZombieEdge:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
 		return nil, err
 	}
 
@@ -5885,10 +6133,11 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 			outDegree++
 
 			// If we've already seen this channel, then we'll
-			// return early to ensure that we don't double-count
-			// stats.
+			// skip it to ensure that we don't double-count stats.
+			// We still continue with the remaining channels of
+			// this node so its out degree is computed correctly.
 			if _, ok := seenChans[edge.ChannelID]; ok {
-				return nil
+				continue
 			}
 
 			// Compare the capacity of this channel against the
@@ -5973,6 +6222,12 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 		netInfo.AvgChannelSize = 0
 	}
 
+	// The same applies to the average out degree if we don't know of any
+	// nodes yet.
+	if numNodes == 0 {
+		netInfo.AvgOutDegree = 0
+	}
+
 	return netInfo, nil
 }
 
@@ -6118,6 +6373,9 @@ func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopol
 				FeeBaseMsat:      int64(channelUpdate.BaseFee),
 				FeeRateMilliMsat: int64(channelUpdate.FeeRate),
 				Disabled:         channelUpdate.Disabled,
+				LastUpdate: uint32(
+					channelUpdate.LastUpdate.Unix(),
+				),
 			},
 			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
 			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
@@ -6418,8 +6676,8 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 			// We'll now take the last offset index returned as
 			// part of this response, and modify our query to start
 			// at this index. This has a pagination effect in the
-			// case that our query bounds has more than 100k
-			// entries.
+			// case that our query bounds has more than
+			// NumMaxEvents entries.
 			query.IndexOffset = timeSlice.LastIndexOffset
 		}
 
@@ -6456,7 +6714,7 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 	}
 	weekFees, err := computeFeeSum(weekQuery)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+		return nil, fmt.Errorf("unable to retrieve week fees: %v", err)
 	}
 
 	monthQuery := channeldb.ForwardingEventQuery{
@@ -6466,7 +6724,7 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 	}
 	monthFees, err := computeFeeSum(monthQuery)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+		return nil, fmt.Errorf("unable to retrieve month fees: %v", err)
 	}
 
 	return &lnrpc.FeeReportResponse{
@@ -6540,7 +6798,13 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 		// Because of the inaccurate precision of the IEEE 754
 		// standard, we need to round the product of feerate and
 		// feebase.
-		feeRateFixed = uint32(math.Round(req.FeeRate * feeBase))
+		feeRateProduct := math.Round(req.FeeRate * feeBase)
+		if feeRateProduct > math.MaxUint32 {
+			return nil, fmt.Errorf("fee rate of %v is too large, "+
+				"max fee rate is %v", req.FeeRate,
+				math.MaxUint32/feeBase)
+		}
+		feeRateFixed = uint32(feeRateProduct)
 
 	// Otherwise, we use the fee_rate_ppm parameter.
 	case req.FeeRatePpm != 0:
@@ -6555,6 +6819,14 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 			minTimeLockDelta)
 	}
 
+	// The base fee is advertised as a 32-bit value within channel updates,
+	// so we'll make sure it can be represented without wrapping around.
+	if req.BaseFeeMsat < 0 || req.BaseFeeMsat > math.MaxUint32 {
+		return nil, fmt.Errorf("base fee of %v msat is out of range, "+
+			"must be between 0 and %v", req.BaseFeeMsat,
+			uint32(math.MaxUint32))
+	}
+
 	baseFeeMsat := lnwire.MilliSatoshi(req.BaseFeeMsat)
 	feeSchema := routing.FeeSchema{
 		BaseFee: baseFeeMsat,
@@ -1,9 +1,23 @@
 package lnd
 
 import (
+	"errors"
+	"net"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/chainreg"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/feature"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntest/mock"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllPermissions(t *testing.T) {
@@ -12,3 +26,362 @@ func TestGetAllPermissions(t *testing.T) {
 	// Currently there are there are 16 entity:action pairs in use.
 	assert.Equal(t, len(perms), 16)
 }
+
+// TestParseOpenChannelReqMaxValueInFlight tests that an open channel request
+// is rejected if the remote max value in flight exceeds the channel capacity.
+func TestParseOpenChannelReqMaxValueInFlight(t *testing.T) {
+	t.Parallel()
+
+	featureMgr, err := feature.NewManager(feature.Config{})
+	require.NoError(t, err)
+
+	r := &rpcServer{
+		server: &server{
+			featureMgr: featureMgr,
+		},
+	}
+
+	const fundingAmt = btcutil.Amount(1_000_000)
+	capacityMSat := lnwire.NewMSatFromSatoshis(fundingAmt)
+
+	_, err = r.parseOpenChannelReq(&lnrpc.OpenChannelRequest{
+		LocalFundingAmount:         int64(fundingAmt),
+		RemoteMaxValueInFlightMsat: uint64(capacityMSat + 1),
+	}, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "remote-max-value-in-flight-msat")
+
+	// A value up to the channel capacity passes the check, the request
+	// then fails later on as it doesn't specify a node.
+	_, err = r.parseOpenChannelReq(&lnrpc.OpenChannelRequest{
+		LocalFundingAmount:         int64(fundingAmt),
+		RemoteMaxValueInFlightMsat: uint64(capacityMSat),
+	}, false)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "remote-max-value-in-flight-msat")
+}
+
+// accountsWalletController is a mock wallet controller that returns the
+// accounts matching a name from a fixed set of accounts.
+type accountsWalletController struct {
+	*mock.WalletController
+
+	accounts []*waddrmgr.AccountProperties
+}
+
+// ListAccounts returns the accounts with the given name.
+func (w *accountsWalletController) ListAccounts(name string,
+	_ *waddrmgr.KeyScope) ([]*waddrmgr.AccountProperties, error) {
+
+	var res []*waddrmgr.AccountProperties
+	for _, account := range w.accounts {
+		if account.AccountName == name {
+			res = append(res, account)
+		}
+	}
+	if len(res) == 0 {
+		return nil, errors.New("account not found")
+	}
+
+	return res, nil
+}
+
+// TestValidateFundingAccount tests that channels can only be funded from
+// BIP84 accounts the wallet can sign for.
+func TestValidateFundingAccount(t *testing.T) {
+	t.Parallel()
+
+	wallet := &accountsWalletController{
+		accounts: []*waddrmgr.AccountProperties{{
+			AccountName: lnwallet.DefaultAccountName,
+			KeyScope:    waddrmgr.KeyScopeBIP0049Plus,
+		}, {
+			AccountName: lnwallet.DefaultAccountName,
+			KeyScope:    waddrmgr.KeyScopeBIP0084,
+		}, {
+			AccountName: "custom",
+			KeyScope:    waddrmgr.KeyScopeBIP0084,
+		}, {
+			AccountName: "nested",
+			KeyScope:    waddrmgr.KeyScopeBIP0049Plus,
+		}, {
+			AccountName: "taproot",
+			KeyScope:    waddrmgr.KeyScopeBIP0086,
+		}, {
+			AccountName: "watch-only",
+			KeyScope:    waddrmgr.KeyScopeBIP0084,
+			IsWatchOnly: true,
+		}},
+	}
+
+	testCases := []struct {
+		name         string
+		account      string
+		remoteSigner bool
+		valid        bool
+	}{
+		{
+			name:    "default account",
+			account: lnwallet.DefaultAccountName,
+			valid:   true,
+		},
+		{
+			name:    "custom account",
+			account: "custom",
+			valid:   true,
+		},
+		{
+			name:    "unknown account",
+			account: "unknown",
+		},
+		{
+			name:    "imported account",
+			account: waddrmgr.ImportedAddrAccountName,
+		},
+		{
+			name:    "nested witness account",
+			account: "nested",
+		},
+		{
+			name:    "taproot account",
+			account: "taproot",
+		},
+		{
+			name:    "watch-only account",
+			account: "watch-only",
+		},
+		{
+			name:         "watch-only account with remote signer",
+			account:      "watch-only",
+			remoteSigner: true,
+			valid:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateFundingAccount(
+				wallet, tc.account, tc.remoteSigner,
+			)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestCalculateMaxCloseFeeRate tests that the max fee rate of a cooperative
+// close is converted to sat/kw and can't be below the target fee rate.
+func TestCalculateMaxCloseFeeRate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		maxFeePerVByte uint64
+		feeRate        chainfee.SatPerKWeight
+		expectedRate   chainfee.SatPerKWeight
+		valid          bool
+	}{{
+		name:           "no max fee rate",
+		maxFeePerVByte: 0,
+		feeRate:        chainfee.FeePerKwFloor,
+		expectedRate:   0,
+		valid:          true,
+	}, {
+		name:           "max fee rate above target",
+		maxFeePerVByte: 10,
+		feeRate:        chainfee.FeePerKwFloor,
+		expectedRate:   2500,
+		valid:          true,
+	}, {
+		name:           "max fee rate equal to target",
+		maxFeePerVByte: 10,
+		feeRate:        2500,
+		expectedRate:   2500,
+		valid:          true,
+	}, {
+		name:           "max fee rate of 1 sat/vbyte",
+		maxFeePerVByte: 1,
+		feeRate:        chainfee.FeePerKwFloor,
+		expectedRate:   chainfee.FeePerKwFloor,
+		valid:          true,
+	}, {
+		name:           "max fee rate below target",
+		maxFeePerVByte: 9,
+		feeRate:        2500,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			maxFeeRate, err := calculateMaxCloseFeeRate(
+				testCase.maxFeePerVByte, testCase.feeRate,
+			)
+			if !testCase.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedRate, maxFeeRate)
+		})
+	}
+}
+
+// createTestPendingChannel stores one side of a new test channel as pending
+// in the given database and returns its state.
+func createTestPendingChannel(t *testing.T, db *channeldb.DB,
+	initiator, private bool) *channeldb.OpenChannel {
+
+	t.Helper()
+
+	alice, bob, cleanUp, err := lnwallet.CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	t.Cleanup(cleanUp)
+
+	// Alice is the initiator of the test channels.
+	channel := bob.State()
+	if initiator {
+		channel = alice.State()
+	}
+
+	channel.Db = db.ChannelStateDB()
+	channel.IsPending = true
+	if !private {
+		channel.ChannelFlags |= lnwire.FFAnnounceChannel
+	}
+
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18556,
+	}
+	require.NoError(t, channel.SyncPending(addr, 101))
+
+	return channel
+}
+
+// TestFetchPendingOpenChannelsPushAmount tests that the push amount of a
+// pending open channel is reported for both the initiator and the responder.
+func TestFetchPendingOpenChannelsPushAmount(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := channeldb.MakeTestDB()
+	require.NoError(t, err)
+	defer cleanUp()
+
+	// The initiator pays the commitment fee, so its local balance differs
+	// from the pushed amount, while the responder's balance equals it.
+	initiatorChan := createTestPendingChannel(t, db, true, false)
+	responderChan := createTestPendingChannel(t, db, false, true)
+
+	r := &rpcServer{
+		server: &server{
+			chanStateDB: db.ChannelStateDB(),
+		},
+	}
+
+	pendingChans, err := r.fetchPendingOpenChannels()
+	require.NoError(t, err)
+	require.Len(t, pendingChans, 2)
+
+	expected := map[string]struct {
+		pushAmt btcutil.Amount
+		private bool
+	}{
+		initiatorChan.FundingOutpoint.String(): {
+			pushAmt: initiatorChan.LocalCommitment.RemoteBalance.
+				ToSatoshis(),
+			private: false,
+		},
+		responderChan.FundingOutpoint.String(): {
+			pushAmt: responderChan.LocalCommitment.LocalBalance.
+				ToSatoshis(),
+			private: true,
+		},
+	}
+
+	for _, pendingChan := range pendingChans {
+		exp, ok := expected[pendingChan.Channel.ChannelPoint]
+		require.True(t, ok)
+
+		require.NotZero(t, exp.pushAmt)
+		require.EqualValues(
+			t, exp.pushAmt, pendingChan.Channel.PushAmountSat,
+		)
+		require.Equal(t, exp.private, pendingChan.Channel.Private)
+	}
+}
+
+// closeTestChannel closes the given channel cooperatively and returns its close
+// summary.
+func closeTestChannel(t *testing.T,
+	channel *channeldb.OpenChannel) *channeldb.ChannelCloseSummary {
+
+	t.Helper()
+
+	summary := &channeldb.ChannelCloseSummary{
+		ChanPoint:               channel.FundingOutpoint,
+		ChainHash:               channel.ChainHash,
+		RemotePub:               channel.IdentityPub,
+		Capacity:                channel.Capacity,
+		CloseType:               channeldb.CooperativeClose,
+		RemoteCurrentRevocation: channel.RemoteCurrentRevocation,
+		RemoteNextRevocation:    channel.RemoteNextRevocation,
+		LocalChanConfig:         channel.LocalChanCfg,
+	}
+	require.NoError(t, channel.CloseChannel(summary))
+
+	return summary
+}
+
+// TestCreateRPCClosedChannelPrivate tests that a closed channel is reported as
+// private only if it wasn't announced, which requires its historical state.
+func TestCreateRPCClosedChannelPrivate(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := channeldb.MakeTestDB()
+	require.NoError(t, err)
+	defer cleanUp()
+
+	r := &rpcServer{
+		cfg: &Config{
+			ActiveNetParams: chainreg.BitcoinTestNetParams,
+		},
+		server: &server{
+			chanStateDB: db.ChannelStateDB(),
+			miscDB:      db,
+		},
+	}
+
+	privateChan := createTestPendingChannel(t, db, true, true)
+	publicChan := createTestPendingChannel(t, db, true, false)
+
+	rpcChan, err := r.createRPCClosedChannel(
+		closeTestChannel(t, privateChan),
+	)
+	require.NoError(t, err)
+	require.True(t, rpcChan.Private)
+
+	rpcChan, err = r.createRPCClosedChannel(
+		closeTestChannel(t, publicChan),
+	)
+	require.NoError(t, err)
+	require.False(t, rpcChan.Private)
+
+	// Without historical state, the channel can't be reported as private.
+	rpcChan, err = r.createRPCClosedChannel(&channeldb.ChannelCloseSummary{
+		ChanPoint: wire.OutPoint{Index: 1},
+		RemotePub: privateChan.IdentityPub,
+	})
+	require.NoError(t, err)
+	require.False(t, rpcChan.Private)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/go-errors/errors"
 	sphinx "github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/aliasmgr"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/cert"
@@ -249,6 +250,8 @@ type server struct {
 
 	invoices *invoices.InvoiceRegistry
 
+	aliasMgr *aliasmgr.Manager
+
 	channelNotifier *channelnotifier.ChannelNotifier
 
 	peerNotifier *peernotifier.PeerNotifier
@@ -273,6 +276,8 @@ type server struct {
 
 	sweeper *sweep.UtxoSweeper
 
+	utxoConsolidator *sweep.UtxoConsolidator
+
 	chainArb *contractcourt.ChainArbitrator
 
 	sphinx *hop.OnionProcessor
@@ -529,6 +534,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		NoAnchors:                cfg.ProtocolOptions.NoAnchorCommitments(),
 		NoWumbo:                  !cfg.ProtocolOptions.Wumbo(),
 		NoScriptEnforcementLease: cfg.ProtocolOptions.NoScriptEnforcementLease(),
+		NoScidAlias:              !cfg.ProtocolOptions.ScidAlias(),
 	})
 	if err != nil {
 		return nil, err
@@ -543,6 +549,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		GcCanceledInvoicesOnStartup: cfg.GcCanceledInvoicesOnStartup,
 		GcCanceledInvoicesOnTheFly:  cfg.GcCanceledInvoicesOnTheFly,
 		KeysendHoldTime:             cfg.KeysendHoldTime,
+		MaxHtlcHoldTime:             cfg.Invoices.MaxHtlcHoldTime,
+		ChanMaxHtlcHoldTimes:        cfg.Invoices.ChanMaxHtlcHoldTimes,
 	}
 
 	s := &server{
@@ -615,6 +623,11 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	s.htlcNotifier = htlcswitch.NewHtlcNotifier(time.Now)
 
+	s.aliasMgr, err = aliasmgr.NewManager(dbs.ChanStateDB)
+	if err != nil {
+		return nil, err
+	}
+
 	thresholdSats := btcutil.Amount(cfg.DustThreshold)
 	thresholdMSats := lnwire.NewMSatFromSatoshis(thresholdSats)
 
@@ -649,13 +662,23 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		RejectHTLC:             cfg.RejectHTLC,
 		Clock:                  clock.NewDefaultClock(),
 		HTLCExpiry:             htlcswitch.DefaultHTLCExpiry,
+		MaxMailboxAdds:         cfg.MaxMailboxAdds,
 		DustThreshold:          thresholdMSats,
+		GetAliases:             s.aliasMgr.GetAliases,
 	}, uint32(currentHeight))
 	if err != nil {
 		return nil, err
 	}
+	holdTimes := cfg.Invoices.ChanMaxHtlcHoldTimes
 	s.interceptableSwitch = htlcswitch.NewInterceptableSwitch(
-		s.htlcSwitch, s.cfg.RequireInterceptor,
+		&htlcswitch.InterceptableSwitchConfig{
+			Switch:               s.htlcSwitch,
+			Notifier:             s.cc.ChainNotifier,
+			CltvRejectDelta:      lncfg.DefaultFinalCltvRejectDelta,
+			RequireInterceptor:   s.cfg.RequireInterceptor,
+			MaxHtlcHoldTime:      cfg.Invoices.MaxHtlcHoldTime,
+			ChanMaxHtlcHoldTimes: holdTimes,
+		},
 	)
 
 	chanStatusMgrCfg := &netann.ChanStatusConfig{
@@ -844,13 +867,21 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		AprioriWeight:         routingConfig.AprioriWeight,
 	}
 
+	bimodalCfg := routing.BimodalEstimatorCfg{
+		BimodalScaleMsat:  routingConfig.BimodalScaleMsat,
+		BimodalNodeWeight: routingConfig.BimodalNodeWeight,
+		BimodalDecayTime:  routingConfig.BimodalDecayTime,
+	}
+
 	s.missionControl, err = routing.NewMissionControl(
 		dbs.ChanStateDB, selfNode.PubKeyBytes,
 		&routing.MissionControlConfig{
+			Estimator:               routingConfig.ProbabilityEstimatorType,
 			ProbabilityEstimatorCfg: estimatorCfg,
+			BimodalEstimatorCfg:     bimodalCfg,
 			MaxMcHistory:            routingConfig.MaxMcHistory,
 			McFlushInterval:         routingConfig.McFlushInterval,
-			MinFailureRelaxInterval: routing.DefaultMinFailureRelaxInterval,
+			MinFailureRelaxInterval: routingConfig.MinFailureRelaxInterval,
 		},
 	)
 	if err != nil {
@@ -867,8 +898,16 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		AttemptCost: lnwire.NewMSatFromSatoshis(
 			routingConfig.AttemptCost,
 		),
-		AttemptCostPPM: routingConfig.AttemptCostPPM,
-		MinProbability: routingConfig.MinRouteProbability,
+		AttemptCostPPM:       routingConfig.AttemptCostPPM,
+		MinProbability:       routingConfig.MinRouteProbability,
+		RiskFactorBillionths: routingConfig.RiskFactorBillionths,
+	}
+
+	splitStrategy, err := routing.ParseSplitStrategy(
+		routingConfig.SplitStrategy,
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	sourceNode, err := chanGraph.SourceNode()
@@ -881,6 +920,10 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		MissionControl:    s.missionControl,
 		GetLink:           s.htlcSwitch.GetLinkByShortID,
 		PathFindingConfig: pathFindingConfig,
+		MinShardAmt: lnwire.NewMSatFromSatoshis(
+			routingConfig.MinShardAmt,
+		),
+		SplitStrategy: splitStrategy,
 	}
 
 	paymentControl := channeldb.NewPaymentControl(dbs.ChanStateDB)
@@ -898,8 +941,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Control:             s.controlTower,
 		MissionControl:      s.missionControl,
 		SessionSource:       paymentSessionSource,
-		ChannelPruneExpiry:  routing.DefaultChannelPruneExpiry,
-		GraphPruneInterval:  time.Hour,
+		ChannelPruneExpiry:  cfg.Routing.ChannelPruneExpiry,
+		GraphPruneInterval:  cfg.Routing.GraphPruneInterval,
 		FirstTimePruneDelay: routing.DefaultFirstTimePruneDelay,
 		GetLink:             s.htlcSwitch.GetLinkByShortID,
 		AssumeChannelValid:  cfg.Routing.AssumeChannelValid,
@@ -933,22 +976,24 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		SelfNodeAnnouncement: func(refresh bool) (lnwire.NodeAnnouncement, error) {
 			return s.genNodeAnnouncement(refresh)
 		},
-		ProofMatureDelta:        0,
-		TrickleDelay:            time.Millisecond * time.Duration(cfg.TrickleDelay),
-		RetransmitTicker:        ticker.New(time.Minute * 30),
-		RebroadcastInterval:     time.Hour * 24,
-		WaitingProofStore:       waitingProofStore,
-		MessageStore:            gossipMessageStore,
-		AnnSigner:               s.nodeSigner,
-		RotateTicker:            ticker.New(discovery.DefaultSyncerRotationInterval),
-		HistoricalSyncTicker:    ticker.New(cfg.HistoricalSyncInterval),
-		NumActiveSyncers:        cfg.NumGraphSyncPeers,
-		MinimumBatchSize:        10,
-		SubBatchDelay:           time.Second * 5,
-		IgnoreHistoricalFilters: cfg.IgnoreHistoricalGossipFilters,
-		PinnedSyncers:           cfg.Gossip.PinnedSyncers,
-		MaxChannelUpdateBurst:   cfg.Gossip.MaxChannelUpdateBurst,
-		ChannelUpdateInterval:   cfg.Gossip.ChannelUpdateInterval,
+		ProofMatureDelta:          0,
+		TrickleDelay:              time.Millisecond * time.Duration(cfg.TrickleDelay),
+		RetransmitTicker:          ticker.New(time.Minute * 30),
+		RebroadcastInterval:       time.Hour * 24,
+		WaitingProofStore:         waitingProofStore,
+		MessageStore:              gossipMessageStore,
+		AnnSigner:                 s.nodeSigner,
+		RotateTicker:              ticker.New(discovery.DefaultSyncerRotationInterval),
+		HistoricalSyncTicker:      ticker.New(cfg.HistoricalSyncInterval),
+		NumActiveSyncers:          cfg.NumGraphSyncPeers,
+		MinimumBatchSize:          10,
+		SubBatchDelay:             time.Second * 5,
+		IgnoreHistoricalFilters:   cfg.IgnoreHistoricalGossipFilters,
+		PinnedSyncers:             cfg.Gossip.PinnedSyncers,
+		MaxChannelUpdateBurst:     cfg.Gossip.MaxChannelUpdateBurst,
+		ChannelUpdateInterval:     cfg.Gossip.ChannelUpdateInterval,
+		MaxPeerChannelUpdateBurst: cfg.Gossip.MaxPeerChannelUpdateBurst,
+		PeerChannelUpdateInterval: cfg.Gossip.PeerChannelUpdateInterval,
 	}, nodeKeyDesc)
 
 	s.localChanMgr = &localchans.Manager{
@@ -994,6 +1039,41 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		FeeRateBucketSize:    sweep.DefaultFeeRateBucketSize,
 	})
 
+	consolidationCfg := cfg.Consolidation
+	s.utxoConsolidator = sweep.NewUtxoConsolidator(&sweep.ConsolidatorConfig{
+		Active:     consolidationCfg.Active,
+		Ticker:     ticker.New(consolidationCfg.Interval),
+		ConfTarget: consolidationCfg.ConfTarget,
+		MaxFeeRate: chainfee.SatPerKVByte(
+			consolidationCfg.MaxFeeRate * 1000,
+		).FeePerKWeight(),
+		MaxUtxoValue:   btcutil.Amount(consolidationCfg.MaxUtxoValue),
+		MinUtxos:       int(consolidationCfg.MinUtxos),
+		MinConfs:       1,
+		FeeEstimator:   cc.FeeEstimator,
+		Wallet:         cc.Wallet,
+		OutpointLocker: cc.Wallet.WalletController,
+		Signer:         cc.Wallet.Cfg.Signer,
+		BestHeight: func() (uint32, error) {
+			_, height, err := cc.ChainIO.GetBestBlock()
+			return uint32(height), err
+		},
+		NewAddress: func() (btcutil.Address, error) {
+			return cc.Wallet.NewAddress(
+				lnwallet.WitnessPubKey, true,
+				lnwallet.DefaultAccountName,
+			)
+		},
+		CheckReservedValue: func(tx *wire.MsgTx) error {
+			_, err := cc.Wallet.CheckReservedValueTx(
+				lnwallet.CheckReservedValueTxReq{
+					Tx: tx,
+				},
+			)
+			return err
+		},
+	})
+
 	s.utxoNursery = contractcourt.NewUtxoNursery(&contractcourt.NurseryConfig{
 		ChainIO:             cc.ChainIO,
 		ConfDepth:           1,
@@ -1014,7 +1094,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		// Instruct the switch to close the channel.  Provide no close out
 		// delivery script or target fee per kw because user input is not
 		// available when the remote peer closes the channel.
-		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, nil)
+		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0, nil)
 	}
 
 	// We will use the following channel to reliably hand off contract
@@ -1324,11 +1404,16 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			return uint16(input.MaxHTLCNumber / 2)
 		},
 		ZombieSweeperInterval:         1 * time.Minute,
-		ReservationTimeout:            10 * time.Minute,
+		ReservationTimeout:            cfg.ReservationTimeout,
+		MaxWaitNumBlocksFundingConf:   cfg.MaxFundingConfBlocks,
 		MinChanSize:                   btcutil.Amount(cfg.MinChanSize),
 		MaxChanSize:                   btcutil.Amount(cfg.MaxChanSize),
+		MinOutboundChanSize:           btcutil.Amount(cfg.MinOutboundChanSize),
+		MaxOutboundChanSize:           btcutil.Amount(cfg.MaxOutboundChanSize),
 		MaxPendingChannels:            cfg.MaxPendingChannels,
 		RejectPush:                    cfg.RejectPush,
+		RejectUnknownPeers:            cfg.RejectUnknownPeers,
+		AllowedPeers:                  cfg.AllowedPeers,
 		MaxLocalCSVDelay:              chainCfg.MaxLocalDelay,
 		NotifyOpenChannelEvent:        s.channelNotifier.NotifyOpenChannelEvent,
 		OpenChannelPredicate:          chanPredicate,
@@ -1337,6 +1422,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		RegisteredChains:              cfg.registeredChains,
 		MaxAnchorsCommitFeeRate: chainfee.SatPerKVByte(
 			s.cfg.MaxCommitFeeRateAnchors * 1000).FeePerKWeight(),
+		AliasManager: s.aliasMgr,
 	})
 	if err != nil {
 		return nil, err
@@ -1761,6 +1847,12 @@ func (s *server) Start() error {
 		}
 		cleanup = cleanup.add(s.sweeper.Stop)
 
+		if err := s.utxoConsolidator.Start(); err != nil {
+			startErr = err
+			return
+		}
+		cleanup = cleanup.add(s.utxoConsolidator.Stop)
+
 		if err := s.utxoNursery.Start(); err != nil {
 			startErr = err
 			return
@@ -2052,6 +2144,10 @@ func (s *server) Stop() error {
 		if err := s.authGossiper.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop authGossiper: %v", err)
 		}
+		if err := s.utxoConsolidator.Stop(); err != nil {
+			srvrLog.Warnf("failed to stop utxoConsolidator: %v",
+				err)
+		}
 		if err := s.sweeper.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop sweeper: %v", err)
 		}
@@ -3529,6 +3625,7 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		PendingCommitInterval:  s.cfg.PendingCommitInterval,
 		ChannelCommitBatchSize: s.cfg.ChannelCommitBatchSize,
 		HandleCustomMessage:    s.handleCustomMessage,
+		GetAliases:             s.aliasMgr.GetAliases,
 		Quit:                   s.quit,
 	}
 
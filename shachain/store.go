@@ -74,6 +74,13 @@ func NewRevocationStoreFromBytes(r io.Reader) (*RevocationStore, error) {
 		return nil, err
 	}
 
+	// A valid store never has more buckets than the maximum height of
+	// the tree, so a larger value means the serialization is corrupted.
+	if store.lenBuckets > maxHeight {
+		return nil, errors.Errorf("invalid number of buckets %v, "+
+			"max is %v", store.lenBuckets, maxHeight)
+	}
+
 	for i := uint8(0); i < store.lenBuckets; i++ {
 		var hashIndex index
 		err := binary.Read(r, binary.BigEndian, &hashIndex)
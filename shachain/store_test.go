@@ -471,3 +471,16 @@ func TestShaChainStore(t *testing.T) {
 		}
 	}
 }
+
+// TestShaChainStoreInvalidBuckets tests that decoding a store which claims more
+// buckets than the maximum tree height fails instead of panicking.
+func TestShaChainStoreInvalidBuckets(t *testing.T) {
+	t.Parallel()
+
+	// Only the bucket count is relevant here, as it's checked before any
+	// of the buckets are read.
+	b := bytes.NewReader([]byte{maxHeight + 1})
+	if _, err := NewRevocationStoreFromBytes(b); err == nil {
+		t.Fatal("expected error decoding store with too many buckets")
+	}
+}
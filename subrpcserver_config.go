@@ -101,12 +101,14 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 	graphDB *channeldb.ChannelGraph,
 	chanStateDB *channeldb.ChannelStateDB,
 	sweeper *sweep.UtxoSweeper,
+	utxoConsolidator *sweep.UtxoConsolidator,
 	tower *watchtower.Standalone,
 	towerClient wtclient.Client,
 	anchorTowerClient wtclient.Client,
 	tcpResolver lncfg.TCPResolver,
 	genInvoiceFeatures func() *lnwire.FeatureVector,
 	genAmpInvoiceFeatures func() *lnwire.FeatureVector,
+	getAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error),
 	rpcLogger btclog.Logger) error {
 
 	// First, we'll use reflect to obtain a version of the config struct
@@ -173,6 +175,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("Sweeper").Set(
 				reflect.ValueOf(sweeper),
 			)
+			subCfgValue.FieldByName("Consolidator").Set(
+				reflect.ValueOf(utxoConsolidator),
+			)
 			subCfgValue.FieldByName("Chain").Set(
 				reflect.ValueOf(cc.ChainIO),
 			)
@@ -199,6 +204,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("ChainNotifier").Set(
 				reflect.ValueOf(cc.ChainNotifier),
 			)
+			subCfgValue.FieldByName("Chain").Set(
+				reflect.ValueOf(cc.ChainIO),
+			)
 
 		case *invoicesrpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
@@ -240,6 +248,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("GenAmpInvoiceFeatures").Set(
 				reflect.ValueOf(genAmpInvoiceFeatures),
 			)
+			subCfgValue.FieldByName("GetAlias").Set(
+				reflect.ValueOf(getAlias),
+			)
 
 		// RouterRPC isn't conditionally compiled and doesn't need to be
 		// populated using reflection.
@@ -0,0 +1,329 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/labels"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/ticker"
+)
+
+var (
+	// ErrTooFewUtxos is returned if a consolidation is requested, but the
+	// wallet doesn't have enough small utxos that would be worth
+	// consolidating at the requested fee rate.
+	ErrTooFewUtxos = errors.New("not enough small utxos to consolidate")
+)
+
+// CraftConsolidationTx attempts to craft a WalletSweepPackage which
+// consolidates all wallet utxos with a value of at most maxUtxoValue into a
+// single output paying to the change address returned by changeAddr. Utxos
+// that cost more to spend at the given fee rate than they're worth are left
+// alone. ErrTooFewUtxos is returned if less than minUtxos utxos qualify for the
+// consolidation, in which case changeAddr isn't called. If checkTx is set, it
+// is called with the consolidation transaction while the coin selection lock
+// is still held.
+func CraftConsolidationTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
+	maxUtxoValue btcutil.Amount, minUtxos int,
+	changeAddr func() (btcutil.Address, error),
+	coinSelectLocker CoinSelectionLocker, utxoSource UtxoSource,
+	outpointLocker OutpointLocker, signer input.Signer, minConfs int32,
+	checkTx func(*wire.MsgTx) error) (*WalletSweepPackage, error) {
+
+	selectUtxos := func(utxos []*lnwallet.Utxo) ([]*lnwallet.Utxo,
+		error) {
+
+		selected := consolidationCandidates(
+			utxos, maxUtxoValue, feeRate,
+		)
+		if len(selected) < minUtxos {
+			return nil, ErrTooFewUtxos
+		}
+
+		return selected, nil
+	}
+
+	return craftWalletSweepTx(
+		feeRate, blockHeight, nil, changeAddr, coinSelectLocker,
+		utxoSource, outpointLocker, signer, minConfs, selectUtxos,
+		checkTx,
+	)
+}
+
+// consolidationCandidates returns the utxos with a value of at most
+// maxUtxoValue that yield positively when spent at the given fee rate.
+func consolidationCandidates(utxos []*lnwallet.Utxo,
+	maxUtxoValue btcutil.Amount,
+	feeRate chainfee.SatPerKWeight) []*lnwallet.Utxo {
+
+	// The weight of an empty transaction is subtracted from the weight
+	// estimates below, so we only account for the weight of the input.
+	var emptyTx input.TxWeightEstimator
+	baseWeight := emptyTx.Weight()
+
+	var candidates []*lnwallet.Utxo
+	for _, utxo := range utxos {
+		if utxo.Value > maxUtxoValue {
+			continue
+		}
+
+		// We can't consolidate utxos we don't know how to spend.
+		witnessType, _, err := walletUtxoWitnessType(utxo)
+		if err != nil {
+			continue
+		}
+
+		var weightEstimate input.TxWeightEstimator
+		err = witnessType.AddWeightEstimation(&weightEstimate)
+		if err != nil {
+			continue
+		}
+
+		inputWeight := int64(weightEstimate.Weight() - baseWeight)
+		if utxo.Value <= feeRate.FeeForWeight(inputWeight) {
+			log.Debugf("Skipping utxo %v of %v, it doesn't yield "+
+				"positively at %v", utxo.OutPoint, utxo.Value,
+				feeRate)
+
+			continue
+		}
+
+		candidates = append(candidates, utxo)
+	}
+
+	return candidates
+}
+
+// ConsolidatorConfig contains the dependencies and the policy of the
+// UtxoConsolidator.
+type ConsolidatorConfig struct {
+	// Active denotes whether utxos are consolidated in the background. If
+	// false, consolidations only happen when requested through
+	// Consolidate.
+	Active bool
+
+	// Ticker determines how often we check whether fees are low enough to
+	// consolidate the wallet utxos in the background.
+	Ticker ticker.Ticker
+
+	// ConfTarget is the confirmation target used to estimate the fee rate
+	// of background consolidations.
+	ConfTarget uint32
+
+	// MaxFeeRate is the maximum fee rate at which utxos are consolidated
+	// in the background.
+	MaxFeeRate chainfee.SatPerKWeight
+
+	// MaxUtxoValue is the default maximum value of the utxos that are
+	// consolidated.
+	MaxUtxoValue btcutil.Amount
+
+	// MinUtxos is the default minimum number of utxos that need to qualify
+	// for a consolidation to happen.
+	MinUtxos int
+
+	// MinConfs is the minimum number of confirmations a utxo needs to be
+	// consolidated.
+	MinConfs int32
+
+	// FeeEstimator is used to estimate the fee rate of background
+	// consolidations.
+	FeeEstimator chainfee.Estimator
+
+	// Wallet is the wallet whose utxos are consolidated.
+	Wallet Wallet
+
+	// OutpointLocker is used to lock the utxos while the consolidation
+	// transaction is crafted.
+	OutpointLocker OutpointLocker
+
+	// Signer is used to sign the consolidation transaction.
+	Signer input.Signer
+
+	// BestHeight returns the height of the best block, which is used as
+	// the lock time of the consolidation transaction.
+	BestHeight func() (uint32, error)
+
+	// NewAddress returns a new wallet change address the utxos are
+	// consolidated into. It's only called once enough utxos were selected
+	// for a consolidation.
+	NewAddress func() (btcutil.Address, error)
+
+	// CheckReservedValue checks that the given transaction doesn't spend
+	// the value we need to keep in the wallet to fee bump anchor channels.
+	// It's called while the coin selection lock is held.
+	CheckReservedValue func(tx *wire.MsgTx) error
+}
+
+// UtxoConsolidator combines small wallet utxos into a single larger one, either
+// on request or in the background whenever on-chain fees are low. This keeps
+// the wallet from accumulating utxos that are expensive to spend when fees are
+// high, for example when they're needed to fund a channel.
+type UtxoConsolidator struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *ConsolidatorConfig
+
+	// mu makes sure only one consolidation is crafted at a time.
+	mu sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewUtxoConsolidator returns a new UtxoConsolidator with the given config.
+func NewUtxoConsolidator(cfg *ConsolidatorConfig) *UtxoConsolidator {
+	return &UtxoConsolidator{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start starts the background consolidation if it's active.
+func (c *UtxoConsolidator) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	if !c.cfg.Active {
+		return nil
+	}
+
+	log.Infof("UTXO consolidator starting, max_fee_rate=%v, "+
+		"max_utxo_value=%v, min_utxos=%v", c.cfg.MaxFeeRate,
+		c.cfg.MaxUtxoValue, c.cfg.MinUtxos)
+
+	c.cfg.Ticker.Resume()
+
+	c.wg.Add(1)
+	go c.consolidationLoop()
+
+	return nil
+}
+
+// Stop stops the background consolidation.
+func (c *UtxoConsolidator) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	close(c.quit)
+	c.wg.Wait()
+
+	if c.cfg.Active {
+		c.cfg.Ticker.Stop()
+	}
+
+	return nil
+}
+
+// consolidationLoop periodically checks whether the fee rate is low enough to
+// consolidate the wallet utxos, and does so if it is.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *UtxoConsolidator) consolidationLoop() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.cfg.Ticker.Ticks():
+			c.maybeConsolidate()
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// maybeConsolidate consolidates the wallet utxos with the configured policy if
+// the current fee estimate doesn't exceed the maximum fee rate.
+func (c *UtxoConsolidator) maybeConsolidate() {
+	feeRate, err := c.cfg.FeeEstimator.EstimateFeePerKW(c.cfg.ConfTarget)
+	if err != nil {
+		log.Errorf("Unable to estimate consolidation fee rate: %v",
+			err)
+		return
+	}
+
+	if feeRate > c.cfg.MaxFeeRate {
+		log.Debugf("Not consolidating utxos, fee rate %v exceeds "+
+			"max fee rate %v", feeRate, c.cfg.MaxFeeRate)
+		return
+	}
+
+	tx, err := c.Consolidate(feeRate, 0, 0)
+	switch {
+	case errors.Is(err, ErrTooFewUtxos):
+		log.Debugf("Not consolidating utxos: %v", err)
+
+	case err != nil:
+		log.Errorf("Unable to consolidate utxos: %v", err)
+
+	default:
+		log.Infof("Consolidated %d utxos in tx %v at %v",
+			len(tx.TxIn), tx.TxHash(), feeRate)
+	}
+}
+
+// Consolidate crafts and publishes a transaction that consolidates the wallet
+// utxos with a value of at most maxUtxoValue at the given fee rate. If
+// maxUtxoValue or minUtxos are zero, the configured defaults are used.
+func (c *UtxoConsolidator) Consolidate(feeRate chainfee.SatPerKWeight,
+	maxUtxoValue btcutil.Amount, minUtxos int) (*wire.MsgTx, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if maxUtxoValue == 0 {
+		maxUtxoValue = c.cfg.MaxUtxoValue
+	}
+	if minUtxos == 0 {
+		minUtxos = c.cfg.MinUtxos
+	}
+
+	// Consolidating a single utxo would only pay fees without reducing
+	// the number of utxos.
+	if minUtxos < 2 {
+		return nil, fmt.Errorf("at least 2 utxos are needed for a "+
+			"consolidation, got %d", minUtxos)
+	}
+
+	if feeRate < chainfee.FeePerKwFloor {
+		return nil, fmt.Errorf("fee rate of %v is below the minimum "+
+			"relay fee rate of %v", feeRate, chainfee.FeePerKwFloor)
+	}
+
+	bestHeight, err := c.cfg.BestHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	sweepTxPkg, err := CraftConsolidationTx(
+		feeRate, bestHeight, maxUtxoValue, minUtxos, c.cfg.NewAddress,
+		c.cfg.Wallet, c.cfg.Wallet, c.cfg.OutpointLocker, c.cfg.Signer,
+		c.cfg.MinConfs, c.cfg.CheckReservedValue,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := sweepTxPkg.SweepTx
+
+	label := labels.MakeLabel(labels.LabelTypeConsolidation, nil)
+	if err := c.cfg.Wallet.PublishTransaction(tx, label); err != nil {
+		sweepTxPkg.CancelSweepAttempt()
+
+		return nil, fmt.Errorf("unable to broadcast consolidation "+
+			"transaction: %v", err)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,173 @@
+package sweep
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntest/mock"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+var (
+	// largeUtxo is a p2wkh output that is too large to be consolidated.
+	largeUtxo = &lnwallet.Utxo{
+		AddressType: lnwallet.WitnessPubKey,
+		PkScript:    testUtxos[0].PkScript,
+		Value:       btcutil.SatoshiPerBitcoin,
+		OutPoint: wire.OutPoint{
+			Index: 4,
+		},
+	}
+
+	// dustUtxo is a p2wkh output that costs more to spend than it's
+	// worth at the test fee rate.
+	dustUtxo = &lnwallet.Utxo{
+		AddressType: lnwallet.WitnessPubKey,
+		PkScript:    testUtxos[0].PkScript,
+		Value:       100,
+		OutPoint: wire.OutPoint{
+			Index: 5,
+		},
+	}
+)
+
+// TestCraftConsolidationTx tests that only small utxos that yield positively
+// are consolidated into a single output.
+func TestCraftConsolidationTx(t *testing.T) {
+	t.Parallel()
+
+	const (
+		feeRate      = chainfee.SatPerKWeight(1000)
+		maxUtxoValue = btcutil.Amount(10_000)
+	)
+
+	smallUtxos := testUtxos[:2]
+	utxos := append([]*lnwallet.Utxo{largeUtxo, dustUtxo}, smallUtxos...)
+
+	utxoSource := newMockUtxoSource(utxos)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	sweepPkg, err := CraftConsolidationTx(
+		feeRate, 10, maxUtxoValue, 2, newDeliveryAddr(nil),
+		coinSelectLocker, utxoSource, utxoLocker, &mock.DummySigner{},
+		1, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to make consolidation tx: %v", err)
+	}
+
+	// Only the small utxos should have been locked, the large one and the
+	// dust one must remain available.
+	assertUtxosLocked(t, utxoLocker, smallUtxos)
+	assertNoUtxosUnlocked(t, utxoLocker, smallUtxos)
+	if len(utxoLocker.lockedOutpoints) != len(smallUtxos) {
+		t.Fatalf("expected %v locked utxos, got %v", len(smallUtxos),
+			len(utxoLocker.lockedOutpoints))
+	}
+
+	sweepTx := sweepPkg.SweepTx
+	if len(sweepTx.TxIn) != len(smallUtxos) {
+		t.Fatalf("expected %v inputs, got %v", len(smallUtxos),
+			len(sweepTx.TxIn))
+	}
+	if len(sweepTx.TxOut) != 1 {
+		t.Fatalf("expected 1 output, got %v", len(sweepTx.TxOut))
+	}
+
+	// The single output pays the value of the inputs minus the fee to our
+	// change address.
+	output := sweepTx.TxOut[0]
+	if !bytes.Equal(sweepScript, output.PkScript) {
+		t.Fatalf("expected %x consolidation script, instead got %x",
+			sweepScript, output.PkScript)
+	}
+	if output.Value <= 0 || output.Value >= 3000 {
+		t.Fatalf("unexpected consolidation value %v", output.Value)
+	}
+
+	sweepPkg.CancelSweepAttempt()
+	assertUtxosUnlocked(t, utxoLocker, smallUtxos)
+}
+
+// TestCraftConsolidationTxTooFewUtxos tests that we don't consolidate and don't
+// lock any utxos if not enough of them qualify for a consolidation.
+func TestCraftConsolidationTxTooFewUtxos(t *testing.T) {
+	t.Parallel()
+
+	utxos := append([]*lnwallet.Utxo{largeUtxo, dustUtxo}, testUtxos[:2]...)
+
+	utxoSource := newMockUtxoSource(utxos)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	var numAddrs int
+	_, err := CraftConsolidationTx(
+		1000, 10, 10_000, 3, newDeliveryAddr(&numAddrs),
+		coinSelectLocker, utxoSource, utxoLocker, &mock.DummySigner{},
+		1, nil,
+	)
+	if !errors.Is(err, ErrTooFewUtxos) {
+		t.Fatalf("expected ErrTooFewUtxos, got: %v", err)
+	}
+
+	if len(utxoLocker.lockedOutpoints) != 0 {
+		t.Fatalf("no utxos should have been locked")
+	}
+
+	// No address should have been derived for a transaction that was
+	// never crafted.
+	if numAddrs != 0 {
+		t.Fatalf("expected no new addresses, got %v", numAddrs)
+	}
+}
+
+// TestCraftConsolidationTxCheckFail tests that the utxos of a consolidation
+// are unlocked again if the crafted transaction doesn't pass the check.
+func TestCraftConsolidationTxCheckFail(t *testing.T) {
+	t.Parallel()
+
+	utxos := append([]*lnwallet.Utxo{largeUtxo, dustUtxo}, testUtxos[:2]...)
+
+	utxoSource := newMockUtxoSource(utxos)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	errReserve := errors.New("reserved value violated")
+	var checkedTx *wire.MsgTx
+	checkTx := func(tx *wire.MsgTx) error {
+		checkedTx = tx
+		return errReserve
+	}
+
+	_, err := CraftConsolidationTx(
+		1000, 10, 10_000, 2, newDeliveryAddr(nil), coinSelectLocker,
+		utxoSource, utxoLocker, &mock.DummySigner{}, 1, checkTx,
+	)
+	if !errors.Is(err, errReserve) {
+		t.Fatalf("expected check error, got: %v", err)
+	}
+
+	if checkedTx == nil || len(checkedTx.TxIn) != 2 {
+		t.Fatalf("expected consolidation tx to be checked")
+	}
+
+	assertUtxosLocked(t, utxoLocker, testUtxos[:2])
+	assertUtxosUnlocked(t, utxoLocker, testUtxos[:2])
+}
+
+// newDeliveryAddr returns a function that returns the delivery address and
+// counts how often it's called in numAddrs, if set.
+func newDeliveryAddr(numAddrs *int) func() (btcutil.Address, error) {
+	return func() (btcutil.Address, error) {
+		if numAddrs != nil {
+			*numAddrs++
+		}
+
+		return deliveryAddr, nil
+	}
+}
@@ -62,6 +62,13 @@ var (
 	// it is/has already been stopped.
 	ErrSweeperShuttingDown = errors.New("utxo sweeper shutting down")
 
+	// ErrFeeRateNotIncreased is returned when the fee preference of an
+	// input that has already been published is updated to a fee rate that
+	// isn't higher than the one previously used. Such an update would
+	// never result in a valid replacement transaction.
+	ErrFeeRateNotIncreased = errors.New("new fee rate must be higher " +
+		"than the previously used fee rate")
+
 	// DefaultMaxSweepAttempts specifies the default maximum number of times
 	// an input is included in a publish attempt before giving up and
 	// returning an error to the caller.
@@ -706,15 +713,15 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 				)
 				if err != nil {
 					log.Warnf("unable to remove descendant "+
-						"transactions due to tx %v: ",
-						spendHash)
+						"transactions due to tx %v: %v",
+						spendHash, err)
 				}
 
 				log.Debugf("Detected spend related to in flight inputs "+
-					"(is_ours=%v): %v",
+					"(is_ours=%v): %v", isOurTx,
 					newLogClosure(func() string {
 						return spew.Sdump(spend.SpendingTx)
-					}), isOurTx,
+					}),
 				)
 			}
 
@@ -875,7 +882,7 @@ func (s *UtxoSweeper) sweepCluster(cluster inputCluster,
 	})
 }
 
-// bucketForFeeReate determines the proper bucket for a fee rate. This is done
+// bucketForFeeRate determines the proper bucket for a fee rate. This is done
 // in order to batch inputs with similar fee rates together.
 func (s *UtxoSweeper) bucketForFeeRate(
 	feeRate chainfee.SatPerKWeight) int {
@@ -1513,13 +1520,11 @@ func (s *UtxoSweeper) UpdateParams(input wire.OutPoint,
 }
 
 // handleUpdateReq handles an update request by simply updating the sweep
-// parameters of the pending input. Currently, no validation is done on the new
-// fee preference to ensure it will properly create a replacement transaction.
+// parameters of the pending input. If the input has already been published,
+// the new fee preference must result in a higher fee rate than the one
+// previously used, as the replacement transaction would otherwise be rejected.
 //
 // TODO(wilmer):
-//   * Validate fee preference to ensure we'll create a valid replacement
-//     transaction to allow the new fee rate to propagate throughout the
-//     network.
 //   * Ensure we don't combine this input with any other unconfirmed inputs that
 //     did not exist in the original sweep transaction, resulting in an invalid
 //     replacement transaction.
@@ -1536,6 +1541,22 @@ func (s *UtxoSweeper) handleUpdateReq(req *updateReq, bestHeight int32) (
 		return nil, lnwallet.ErrNotMine
 	}
 
+	// If the input has already been part of a published sweep, a
+	// replacement is only accepted by the network if it pays a higher fee
+	// rate, so we reject updates that wouldn't bump the fee.
+	if pendingInput.publishAttempts > 0 {
+		feeRate, err := s.feeRateForPreference(req.params.Fee)
+		if err != nil {
+			return nil, err
+		}
+
+		if feeRate <= pendingInput.lastFeeRate {
+			return nil, fmt.Errorf("%w: %v <= %v",
+				ErrFeeRateNotIncreased, feeRate,
+				pendingInput.lastFeeRate)
+		}
+	}
+
 	// Create the updated parameters struct. Leave the exclusive group
 	// unchanged.
 	newParams := pendingInput.params
@@ -1612,7 +1633,7 @@ func DefaultNextAttemptDeltaFunc(attempts int) int32 {
 	return 1 + rand.Int31n(1<<uint(attempts-1))
 }
 
-// ListSweeps returns a list of the the sweeps recorded by the sweep store.
+// ListSweeps returns a list of the sweeps recorded by the sweep store.
 func (s *UtxoSweeper) ListSweeps() ([]chainhash.Hash, error) {
 	return s.cfg.Store.ListSweeps()
 }
@@ -1,6 +1,7 @@
 package sweep
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"runtime/debug"
@@ -1234,6 +1235,15 @@ func TestBumpFeeRBF(t *testing.T) {
 		t.Fatalf("expected ErrNoFeePreference, got %v", err)
 	}
 
+	// Updating the input with the fee preference it was already published
+	// with can't result in a valid replacement, so it should be rejected.
+	_, err = ctx.sweeper.UpdateParams(
+		*input.OutPoint(), ParamsUpdate{Fee: lowFeePref},
+	)
+	if !errors.Is(err, ErrFeeRateNotIncreased) {
+		t.Fatalf("expected ErrFeeRateNotIncreased, got %v", err)
+	}
+
 	bumpResult, err := ctx.sweeper.UpdateParams(
 		*input.OutPoint(), ParamsUpdate{Fee: highFeePref},
 	)
@@ -1,6 +1,7 @@
 package sweep
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -12,6 +13,12 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
+var (
+	// ErrNoWalletUtxos is returned if a sweep of all wallet funds is
+	// requested, but the wallet has no outputs that can be swept.
+	ErrNoWalletUtxos = errors.New("no wallet outputs available to sweep")
+)
+
 const (
 	// defaultNumBlocksEstimate is the number of blocks that we fall back
 	// to issuing an estimate for if a fee pre fence doesn't specify an
@@ -164,7 +171,8 @@ type DeliveryAddr struct {
 // leftover amount after these outputs and transaction fee, is sent to a single
 // output, as specified by the change address. The sweep transaction will be
 // crafted with the target fee rate, and will use the utxoSource and
-// outpointLocker as sources for wallet funds.
+// outpointLocker as sources for wallet funds. ErrNoWalletUtxos is returned if
+// the wallet doesn't have any outputs that can be swept.
 func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 	deliveryAddrs []DeliveryAddr, changeAddr btcutil.Address,
 	coinSelectLocker CoinSelectionLocker, utxoSource UtxoSource,
@@ -173,6 +181,58 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 
 	// TODO(roasbeef): turn off ATPL as well when available?
 
+	return craftWalletSweepTx(
+		feeRate, blockHeight, deliveryAddrs,
+		func() (btcutil.Address, error) {
+			return changeAddr, nil
+		},
+		coinSelectLocker, utxoSource, outpointLocker, signer, minConfs,
+		nil, nil,
+	)
+}
+
+// walletUtxoWitnessType maps the address type of the given wallet utxo to the
+// witness type and sighash type needed to sweep it.
+func walletUtxoWitnessType(utxo *lnwallet.Utxo) (input.WitnessType,
+	txscript.SigHashType, error) {
+
+	switch utxo.AddressType {
+
+	// If this is a p2wkh output, then we'll assume it's a witness key hash
+	// witness type.
+	case lnwallet.WitnessPubKey:
+		return input.WitnessKeyHash, txscript.SigHashAll, nil
+
+	// If this is a p2sh output, then as since it's under control of the
+	// wallet, we'll assume it's a nested p2sh output.
+	case lnwallet.NestedWitnessPubKey:
+		return input.NestedWitnessKeyHash, txscript.SigHashAll, nil
+
+	case lnwallet.TaprootPubkey:
+		return input.TaprootPubKeySpend, txscript.SigHashDefault, nil
+
+	// All other output types we count as unknown and will fail to sweep.
+	default:
+		return nil, 0, fmt.Errorf("unable to sweep coins, unknown "+
+			"script: %x", utxo.PkScript[:])
+	}
+}
+
+// craftWalletSweepTx crafts a WalletSweepPackage that sweeps the wallet utxos
+// picked by selectUtxos to the delivery addresses, sending any leftover amount
+// to the change address returned by changeAddr. If selectUtxos is nil, all
+// utxos of the wallet are swept. The utxos are selected and locked, and the
+// transaction is crafted and passed to checkTx, if set, all while holding the
+// coin selection lock. The change address is only requested once utxos were
+// selected.
+func craftWalletSweepTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
+	deliveryAddrs []DeliveryAddr,
+	changeAddr func() (btcutil.Address, error),
+	coinSelectLocker CoinSelectionLocker, utxoSource UtxoSource,
+	outpointLocker OutpointLocker, signer input.Signer, minConfs int32,
+	selectUtxos func([]*lnwallet.Utxo) ([]*lnwallet.Utxo, error),
+	checkTx func(*wire.MsgTx) error) (*WalletSweepPackage, error) {
+
 	var allOutputs []*lnwallet.Utxo
 
 	// We'll make a function closure up front that allows us to unlock all
@@ -187,8 +247,11 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 
 	// Next, we'll use the coinSelectLocker to ensure that no coin
 	// selection takes place while we fetch and lock all outputs the wallet
-	// knows of.  Otherwise, it may be possible for a new funding flow to
-	// lock an output while we fetch the set of unspent witnesses.
+	// knows of, and craft the sweep transaction. Otherwise, it may be
+	// possible for a new funding flow to lock an output while we fetch the
+	// set of unspent witnesses, or to change the wallet balance the sweep
+	// transaction is checked against.
+	var sweepTx *wire.MsgTx
 	err := coinSelectLocker.WithCoinSelectLock(func() error {
 		// Now that we can be sure that no other coin selection
 		// operations are going on, we can grab a clean snapshot of the
@@ -197,7 +260,23 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 			minConfs, math.MaxInt32,
 		)
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to fetch wallet utxos: %w",
+				err)
+		}
+
+		if selectUtxos != nil {
+			utxos, err = selectUtxos(utxos)
+			if err != nil {
+				return fmt.Errorf("unable to select wallet "+
+					"utxos: %w", err)
+			}
+		}
+
+		// Leased outputs and outputs with less than minConfs
+		// confirmations aren't returned by the utxo source, so there
+		// might be nothing left to sweep.
+		if len(utxos) == 0 {
+			return ErrNoWalletUtxos
 		}
 
 		// We'll now lock each UTXO to ensure that other callers don't
@@ -209,6 +288,23 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 
 		allOutputs = append(allOutputs, utxos...)
 
+		addr, err := changeAddr()
+		if err != nil {
+			return err
+		}
+
+		sweepTx, err = craftSweepTxForUtxos(
+			allOutputs, deliveryAddrs, addr, blockHeight, feeRate,
+			signer,
+		)
+		if err != nil {
+			return err
+		}
+
+		if checkTx != nil {
+			return checkTx(sweepTx)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -216,15 +312,35 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 		// in case we had any lingering outputs.
 		unlockOutputs()
 
-		return nil, fmt.Errorf("unable to fetch+lock wallet "+
-			"utxos: %v", err)
+		return nil, err
 	}
 
-	// Now that we've locked all the potential outputs to sweep, we'll
-	// assemble an input for each of them, so we can hand it off to the
-	// sweeper to generate and sign a transaction for us.
+	return &WalletSweepPackage{
+		SweepTx:            sweepTx,
+		CancelSweepAttempt: unlockOutputs,
+	}, nil
+}
+
+// craftSweepTxForUtxos crafts and signs a transaction that sweeps the given
+// wallet utxos to the delivery addresses, sending any leftover amount to the
+// change address.
+func craftSweepTxForUtxos(utxos []*lnwallet.Utxo, deliveryAddrs []DeliveryAddr,
+	changeAddr btcutil.Address, blockHeight uint32,
+	feeRate chainfee.SatPerKWeight, signer input.Signer) (*wire.MsgTx,
+	error) {
+
+	// We'll assemble an input for each of the outputs, so we can hand it
+	// off to the sweeper to generate and sign a transaction for us.
 	var inputsToSweep []input.Input
-	for _, output := range allOutputs {
+	for _, output := range utxos {
+		// Based on the output type, we'll map it to the proper witness
+		// type so we can generate the set of input scripts needed to
+		// sweep the output.
+		witnessType, hashType, err := walletUtxoWitnessType(output)
+		if err != nil {
+			return nil, err
+		}
+
 		// As we'll be signing for outputs under control of the wallet,
 		// we only need to populate the output value and output script.
 		// The rest of the items will be populated internally within
@@ -234,38 +350,7 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 				PkScript: output.PkScript,
 				Value:    int64(output.Value),
 			},
-			HashType: txscript.SigHashAll,
-		}
-
-		pkScript := output.PkScript
-
-		// Based on the output type, we'll map it to the proper witness
-		// type so we can generate the set of input scripts needed to
-		// sweep the output.
-		var witnessType input.WitnessType
-		switch output.AddressType {
-
-		// If this is a p2wkh output, then we'll assume it's a witness
-		// key hash witness type.
-		case lnwallet.WitnessPubKey:
-			witnessType = input.WitnessKeyHash
-
-		// If this is a p2sh output, then as since it's under control
-		// of the wallet, we'll assume it's a nested p2sh output.
-		case lnwallet.NestedWitnessPubKey:
-			witnessType = input.NestedWitnessKeyHash
-
-		case lnwallet.TaprootPubkey:
-			witnessType = input.TaprootPubKeySpend
-			signDesc.HashType = txscript.SigHashDefault
-
-		// All other output types we count as unknown and will fail to
-		// sweep.
-		default:
-			unlockOutputs()
-
-			return nil, fmt.Errorf("unable to sweep coins, "+
-				"unknown script: %x", pkScript[:])
+			HashType: hashType,
 		}
 
 		// Now that we've constructed the items required, we'll make an
@@ -282,8 +367,6 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 	for _, d := range deliveryAddrs {
 		pkScript, err := txscript.PayToAddrScript(d.Addr)
 		if err != nil {
-			unlockOutputs()
-
 			return nil, err
 		}
 
@@ -297,25 +380,13 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 	// to create the sweep transaction.
 	changePkScript, err := txscript.PayToAddrScript(changeAddr)
 	if err != nil {
-		unlockOutputs()
-
 		return nil, err
 	}
 
 	// Finally, we'll ask the sweeper to craft a sweep transaction which
-	// respects our fee preference and targets all the UTXOs of the wallet.
-	sweepTx, err := createSweepTx(
+	// respects our fee preference and targets all the given UTXOs.
+	return createSweepTx(
 		inputsToSweep, txOuts, changePkScript, blockHeight, feeRate,
 		signer,
 	)
-	if err != nil {
-		unlockOutputs()
-
-		return nil, err
-	}
-
-	return &WalletSweepPackage{
-		SweepTx:            sweepTx,
-		CancelSweepAttempt: unlockOutputs,
-	}, nil
 }
@@ -330,6 +330,24 @@ func TestCraftSweepAllTxUnknownWitnessType(t *testing.T) {
 	assertUtxosLockedAndUnlocked(t, utxoLocker, testUtxos)
 }
 
+// TestCraftSweepAllTxNoUtxos tests that we fail with ErrNoWalletUtxos if the
+// wallet has no outputs that can be swept.
+func TestCraftSweepAllTxNoUtxos(t *testing.T) {
+	t.Parallel()
+
+	utxoSource := newMockUtxoSource(nil)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	_, err := CraftSweepAllTx(
+		0, 10, nil, deliveryAddr, coinSelectLocker, utxoSource,
+		utxoLocker, nil, nil, 0,
+	)
+	if err != ErrNoWalletUtxos {
+		t.Fatalf("expected ErrNoWalletUtxos, got: %v", err)
+	}
+}
+
 // TestCraftSweepAllTx tests that we'll properly lock all available outputs
 // within the wallet, and craft a single sweep transaction that pays to the
 // target output.
@@ -1,6 +1,7 @@
 package walletunlocker
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"errors"
@@ -434,6 +435,14 @@ func (u *UnlockerService) InitWallet(ctx context.Context,
 
 	// The aezeed is the preferred and default way of initializing a wallet.
 	case len(in.CipherSeedMnemonic) > 0:
+		// Make sure we got exactly the number of words of a mnemonic,
+		// as any additional words would otherwise be dropped silently.
+		if len(in.CipherSeedMnemonic) != aezeed.NumMnemonicWords {
+			return nil, fmt.Errorf("mnemonic must be exactly %d "+
+				"words, got %d words", aezeed.NumMnemonicWords,
+				len(in.CipherSeedMnemonic))
+		}
+
 		// We'll map the user provided aezeed and passphrase into a
 		// decoded cipher seed instance.
 		var mnemonic aezeed.Mnemonic
@@ -674,6 +683,13 @@ func (u *UnlockerService) UnlockWallet(ctx context.Context,
 	in *lnrpc.UnlockWalletRequest) (*lnrpc.UnlockWalletResponse, error) {
 
 	password := in.WalletPassword
+
+	// Require that the recovery window be non-negative, as it would
+	// otherwise wrap around to an enormous address look-ahead.
+	if in.RecoveryWindow < 0 {
+		return nil, fmt.Errorf("recovery window %d must be "+
+			"non-negative", in.RecoveryWindow)
+	}
 	recoveryWindow := uint32(in.RecoveryWindow)
 
 	unlockedWallet, unloadFn, err := u.LoadAndUnlock(
@@ -758,6 +774,15 @@ func (u *UnlockerService) ChangePassword(ctx context.Context,
 		return nil, err
 	}
 
+	// Rotating the password only makes sense if it actually changes,
+	// unless the request is used to rotate the macaroon root key or switch
+	// to stateless init while keeping the password.
+	rotateMacaroons := in.NewMacaroonRootKey || in.StatelessInit
+	if bytes.Equal(in.CurrentPassword, in.NewPassword) && !rotateMacaroons {
+		return nil, errors.New("new password must be different from " +
+			"the current password")
+	}
+
 	// Load the existing wallet in order to proceed with the password change.
 	w, err := loader.OpenExistingWallet(publicPw, false)
 	if err != nil {
@@ -363,6 +363,19 @@ func TestCreateWalletInvalidEntropy(t *testing.T) {
 	ctx := context.Background()
 	_, err = service.InitWallet(ctx, req)
 	require.Error(t, err)
+
+	// A valid mnemonic followed by an additional word must be rejected as
+	// well, instead of silently ignoring the extra word.
+	pass := []byte("test")
+	_, mnemonic := createSeedAndMnemonic(t, pass)
+	req = &lnrpc.InitWalletRequest{
+		WalletPassword:     testPassword,
+		CipherSeedMnemonic: append(mnemonic[:], "abandon"),
+		AezeedPassphrase:   pass,
+	}
+	_, err = service.InitWallet(ctx, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "mnemonic must be exactly")
 }
 
 // TestUnlockWallet checks that trying to unlock non-existing wallet fail, that
@@ -405,6 +418,14 @@ func TestUnlockWallet(t *testing.T) {
 	_, err = service.UnlockWallet(ctx, wrongReq)
 	require.Error(t, err)
 
+	// A negative recovery window should be rejected as well.
+	negativeReq := &lnrpc.UnlockWalletRequest{
+		WalletPassword: testPassword,
+		RecoveryWindow: -1,
+	}
+	_, err = service.UnlockWallet(ctx, negativeReq)
+	require.Error(t, err)
+
 	// With the correct password, we should be able to unlock the wallet.
 	errChan := make(chan error, 1)
 	go func() {
@@ -515,6 +536,15 @@ func TestChangeWalletPasswordNewRootkey(t *testing.T) {
 	_, err = service.ChangePassword(ctx, wrongReq)
 	require.Error(t, err)
 
+	// Attempting to change the wallet's password to the current one
+	// should fail as well.
+	sameReq := &lnrpc.ChangePasswordRequest{
+		CurrentPassword: testPassword,
+		NewPassword:     testPassword,
+	}
+	_, err = service.ChangePassword(ctx, sameReq)
+	require.Error(t, err)
+
 	// When providing the correct wallet's current password and a new
 	// password that meets the length requirement, the password change
 	// should succeed.
@@ -545,6 +575,65 @@ func TestChangeWalletPasswordNewRootkey(t *testing.T) {
 	}
 }
 
+// TestChangeWalletPasswordSameRootKeyRotation checks that the macaroon root
+// key can be rotated without changing the wallet's password.
+func TestChangeWalletPasswordSameRootKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	testDir, err := ioutil.TempDir("", "testchangepasswordsame")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(testDir)
+	}()
+
+	store, err := openOrCreateTestMacStore(
+		testDir, &testPassword, testNetParams,
+	)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// Create a file that will act as a macaroon file that should be
+	// deleted once the root key was rotated.
+	file, err := ioutil.TempFile(testDir, "")
+	require.NoError(t, err)
+	tempFile := file.Name()
+	require.NoError(t, file.Close())
+
+	service := walletunlocker.New(
+		testNetParams, []string{tempFile}, false,
+		testLoaderOpts(testDir),
+	)
+	service.SetMacaroonDB(store.Backend)
+
+	createTestWallet(t, testDir, testNetParams)
+
+	// Keeping the same password while rotating the root key is allowed.
+	req := &lnrpc.ChangePasswordRequest{
+		CurrentPassword:    testPassword,
+		NewPassword:        testPassword,
+		NewMacaroonRootKey: true,
+	}
+	errChan := make(chan error, 1)
+	go doChangePassword(service, testDir, req, errChan)
+
+	select {
+	case err := <-errChan:
+		t.Fatalf("ChangePassword call failed: %v", err)
+
+	case unlockMsg := <-service.UnlockMsgs:
+		require.Equal(t, testPassword, unlockMsg.Passphrase)
+
+		service.MacResponseChan <- testMac
+
+	case <-time.After(defaultTestTimeout):
+		t.Fatalf("password not received")
+	}
+
+	// The macaroon file should no longer exist.
+	_, err = os.Stat(tempFile)
+	require.True(t, os.IsNotExist(err))
+}
+
 // TestChangeWalletPasswordStateless checks that trying to change the password
 // of an existing wallet that was initialized stateless works when when the
 // --stateless_init flat is set. Also checks that if no password is given,